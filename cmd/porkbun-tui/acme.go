@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/bc/porkbun-tui/internal/acme"
+	"github.com/bc/porkbun-tui/internal/api"
+)
+
+const acmeUsage = "Usage: porkbun-tui acme present|cleanup [--timeout 2m] <fqdn> <value>"
+
+func runACME(args []string) int {
+	if len(args) == 0 || (args[0] != "present" && args[0] != "cleanup") {
+		fmt.Fprintln(os.Stderr, acmeUsage)
+		if len(args) > 0 && (args[0] == "-h" || args[0] == "--help") {
+			return 0
+		}
+		return 2
+	}
+	action := args[0]
+
+	fs := flag.NewFlagSet("acme "+action, flag.ContinueOnError)
+	timeout := fs.Duration("timeout", acme.DefaultTimeout, "How long present waits for the record to be listed")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), acmeUsage)
+		fmt.Fprintln(fs.Output())
+		fmt.Fprintln(fs.Output(), "DNS-01 hook for ACME clients. present creates the _acme-challenge TXT")
+		fmt.Fprintln(fs.Output(), "record and returns once the API lists it; cleanup deletes it. Arguments")
+		fmt.Fprintln(fs.Output(), "follow lego's exec provider (fqdn value, or domain token keyAuth in RAW")
+		fmt.Fprintln(fs.Output(), "mode); without them, certbot's CERTBOT_DOMAIN and CERTBOT_VALIDATION")
+		fmt.Fprintln(fs.Output(), "are used.")
+		fmt.Fprintln(fs.Output())
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args[1:]); err != nil {
+		return parseExit(err)
+	}
+
+	var target, value string
+	switch rest := fs.Args(); len(rest) {
+	case 0:
+		target, value = os.Getenv("CERTBOT_DOMAIN"), os.Getenv("CERTBOT_VALIDATION")
+	case 2:
+		target, value = rest[0], rest[1]
+	case 3: // lego RAW mode: domain, token, key authorization
+		target, value = rest[0], acme.KeyAuthDigest(rest[2])
+	}
+	if target == "" || value == "" {
+		fs.Usage()
+		return 2
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return fail(err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	domains, err := client.ListDomains(ctx)
+	if err != nil {
+		return fail(fmt.Errorf("listing domains: %w", err))
+	}
+	zone, name, err := api.ZoneOf(acme.ChallengeName(target), domains)
+	if err != nil {
+		return fail(err)
+	}
+
+	s := &acme.Solver{
+		Records: client,
+		Timeout: *timeout,
+		Log:     log.New(os.Stderr, "acme: ", log.LstdFlags),
+	}
+	if action == "present" {
+		err = s.Present(ctx, zone, name, value)
+	} else {
+		err = s.CleanUp(ctx, zone, name, value)
+	}
+	if err != nil {
+		return fail(err)
+	}
+	return 0
+}
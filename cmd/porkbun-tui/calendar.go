@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/bc/porkbun-tui/internal/config"
+	"github.com/bc/porkbun-tui/internal/ical"
+)
+
+func runCalendar(args []string) int {
+	if len(args) == 0 || args[0] != "export" {
+		fmt.Fprintln(os.Stderr, "Usage: porkbun-tui calendar export [-o file] [--cached]")
+		if len(args) > 0 && (args[0] == "-h" || args[0] == "--help") {
+			return 0
+		}
+		return 2
+	}
+
+	fs := flag.NewFlagSet("calendar export", flag.ContinueOnError)
+	out := fs.String("o", "", "Write the .ics to this file instead of stdout")
+	cached := fs.Bool("cached", false, "Use cached data only, no API calls")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: porkbun-tui calendar export [-o file] [--cached]")
+		fmt.Fprintln(fs.Output())
+		fmt.Fprintln(fs.Output(), "Writes every domain's expiration as an all-day iCalendar event with")
+		fmt.Fprintln(fs.Output(), "reminder alarms (calendar_reminders in config.yaml, default 30/7/1 days).")
+		fmt.Fprintln(fs.Output(), "Event UIDs are stable, so re-importing updates events in place.")
+		fmt.Fprintln(fs.Output())
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args[1:]); err != nil {
+		return parseExit(err)
+	}
+
+	settings, err := config.LoadSettings()
+	if err != nil {
+		return fail(err)
+	}
+
+	domains, pricing, err := loadPortfolio(context.Background(), *cached)
+	if err != nil {
+		return fail(err)
+	}
+
+	var w io.Writer = os.Stdout
+	if *out != "" {
+		file, err := os.Create(*out)
+		if err != nil {
+			return fail(err)
+		}
+		defer file.Close()
+		w = file
+	}
+
+	cal := ical.Expirations(domains, pricing, settings.CalendarReminders)
+	if err := ical.Write(w, cal, time.Now()); err != nil {
+		return fail(err)
+	}
+	return 0
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/bc/porkbun-tui/internal/api"
+	"github.com/bc/porkbun-tui/internal/cache"
+	"github.com/bc/porkbun-tui/internal/config"
+)
+
+// command is a non-interactive subcommand: porkbun-tui <name> [flags].
+// run returns the process exit code.
+type command struct {
+	name    string
+	summary string
+	run     func(args []string) int
+}
+
+var commands = []command{
+	{"forecast", "Renewal cost forecast as CSV", runForecast},
+	{"calendar", "Export expirations as an iCalendar (.ics) file", runCalendar},
+	{"expiring", "Check alert rules; Nagios-style exit codes", runExpiring},
+	{"watch", "Refresh on a schedule and send webhook notifications", runWatch},
+	{"metrics", "Prometheus exporter for the portfolio", runMetrics},
+	{"digest", "Email a summary of renewals and security issues", runDigest},
+	{"serve", "Read-only web dashboard of the portfolio", runServe},
+	{"ddns", "Keep an A/AAAA record pointed at this machine's public IP", runDDNS},
+	{"acme", "DNS-01 challenge hook for lego and certbot", runACME},
+	{"ssl", "Write a domain's Porkbun SSL certificate and key to files", runSSL},
+	{"ns-rollback", "Restore nameservers from a migration rollback file", runNSRollback},
+}
+
+func lookupCommand(name string) (command, bool) {
+	for _, c := range commands {
+		if c.name == name {
+			return c, true
+		}
+	}
+	return command{}, false
+}
+
+// credentialsHint follows the error when no credentials are configured.
+const credentialsHint = `
+Set your Porkbun API credentials:
+  export PORKBUN_API_KEY=pk1_xxx
+  export PORKBUN_SECRET_KEY=sk1_xxx
+
+Or create ~/.config/porkbun-tui/config.yaml:
+  api_key: pk1_xxx
+  secret_key: sk1_xxx`
+
+// newClient loads credentials and builds an API client. When they are
+// missing, the error carries the same setup hint the TUI prints.
+func newClient() (*api.Client, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("%w\n%s", err, credentialsHint)
+	}
+	return api.NewClient(cfg), nil
+}
+
+// loadDomains fetches the domain list from the API and refreshes the cache,
+// or reads the cache only when cached is set.
+func loadDomains(ctx context.Context, cached bool) ([]api.Domain, error) {
+	l, err := newLoader(cached)
+	if err != nil {
+		return nil, err
+	}
+	domains, _, err := l.load(ctx, false)
+	return domains, err
+}
+
+// loadPortfolio is loadDomains plus TLD pricing. Pricing failures are
+// non-fatal, as in the TUI: the cached copy is used instead.
+func loadPortfolio(ctx context.Context, cached bool) ([]api.Domain, map[string]api.TLDPricing, error) {
+	l, err := newLoader(cached)
+	if err != nil {
+		return nil, nil, err
+	}
+	return l.load(ctx, true)
+}
+
+// loader reads the portfolio from the API through the cache, or from the
+// cache alone. Long-running commands keep one so the client (and its
+// request statistics) lives across refreshes.
+type loader struct {
+	client *api.Client // nil when cached
+	cache  *cache.Cache
+	cached bool
+}
+
+func newLoader(cached bool) (*loader, error) {
+	appCache, err := cache.New()
+	if err != nil && cached {
+		return nil, fmt.Errorf("opening cache: %w", err)
+	}
+	l := &loader{cache: appCache, cached: cached}
+	if !cached {
+		if l.client, err = newClient(); err != nil {
+			return nil, err
+		}
+	}
+	return l, nil
+}
+
+func (l *loader) load(ctx context.Context, withPricing bool) ([]api.Domain, map[string]api.TLDPricing, error) {
+	if l.cached {
+		domains, updated, err := l.cache.LoadDomains()
+		if err != nil {
+			return nil, nil, fmt.Errorf("reading cached domains: %w", err)
+		}
+		// An empty result from a missing cache must not pass for an
+		// empty portfolio (a silent OK in the expiring check).
+		if updated.IsZero() {
+			return nil, nil, errors.New("no cached domains; run once without --cached")
+		}
+		var pricing map[string]api.TLDPricing
+		if withPricing {
+			pricing, _, _ = l.cache.LoadPricing()
+		}
+		return domains, pricing, nil
+	}
+
+	domains, err := l.client.ListDomains(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("listing domains: %w", err)
+	}
+	if l.cache != nil {
+		_ = l.cache.SaveDomains(domains)
+	}
+	if !withPricing {
+		return domains, nil, nil
+	}
+
+	pricing, err := l.client.GetPricing(ctx)
+	if err == nil {
+		if l.cache != nil {
+			_ = l.cache.SavePricing(pricing)
+		}
+	} else if l.cache != nil {
+		pricing, _, _ = l.cache.LoadPricing()
+	}
+
+	return domains, pricing, nil
+}
+
+// parseExit maps a flag parsing error to an exit code: -h is a success.
+func parseExit(err error) int {
+	if errors.Is(err, flag.ErrHelp) {
+		return 0
+	}
+	return 2
+}
+
+func fail(err error) int {
+	fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	return 1
+}
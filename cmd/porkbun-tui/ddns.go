@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/bc/porkbun-tui/internal/alerts"
+	"github.com/bc/porkbun-tui/internal/api"
+	"github.com/bc/porkbun-tui/internal/ddns"
+)
+
+func runDDNS(args []string) int {
+	fs := flag.NewFlagSet("ddns", flag.ContinueOnError)
+	domain := fs.String("domain", "", "Record to keep current, e.g. home.example.com (required)")
+	recordType := fs.String("type", "A", "A for the public IPv4 address, AAAA for IPv6")
+	interval := fs.String("interval", "", "Check again at this interval (e.g. 5m) instead of once")
+	ttl := fs.String("ttl", "", "TTL of a newly created record (default Porkbun's, 600)")
+	ipv6Endpoint := fs.String("ipv6-endpoint", "", "API base URL to learn the IPv6 address from (default the dual-stack API host)")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: porkbun-tui ddns --domain home.example.com [--type A|AAAA] [--interval 5m]")
+		fmt.Fprintln(fs.Output())
+		fmt.Fprintln(fs.Output(), "Points the record at this machine's public address, as Porkbun's ping")
+		fmt.Fprintln(fs.Output(), "reports it, creating the record if missing and editing it only when the")
+		fmt.Fprintln(fs.Output(), "address changed. Without --interval it checks once, e.g. from cron.")
+		fmt.Fprintln(fs.Output())
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return parseExit(err)
+	}
+	if *domain == "" {
+		fs.Usage()
+		return 2
+	}
+	*recordType = strings.ToUpper(*recordType)
+	if *recordType != "A" && *recordType != "AAAA" {
+		return fail(fmt.Errorf("--type must be A or AAAA, not %s", *recordType))
+	}
+	if *ipv6Endpoint != "" && *recordType != "AAAA" {
+		return fail(errors.New("--ipv6-endpoint only applies to --type AAAA"))
+	}
+	var every time.Duration
+	if *interval != "" {
+		var err error
+		if every, err = alerts.ParseDuration(*interval); err != nil || every < time.Minute {
+			return fail(fmt.Errorf("--interval %q: must be at least 1m", *interval))
+		}
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return fail(err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	domains, err := client.ListDomains(ctx)
+	if err != nil {
+		return fail(fmt.Errorf("listing domains: %w", err))
+	}
+	zone, name, err := api.ZoneOf(*domain, domains)
+	if err != nil {
+		return fail(err)
+	}
+
+	ipv6 := *recordType == "AAAA"
+	u := &ddns.Updater{
+		Records: client,
+		PublicIP: func(ctx context.Context) (string, error) {
+			return client.PublicIP(ctx, ipv6, *ipv6Endpoint)
+		},
+		Zone: zone,
+		Name: name,
+		Type: *recordType,
+		TTL:  *ttl,
+		Log:  log.New(os.Stderr, "ddns: ", log.LstdFlags),
+	}
+
+	if every == 0 {
+		res, err := u.Sync(ctx)
+		if err != nil {
+			return fail(err)
+		}
+		u.LogResult(res)
+		return 0
+	}
+
+	u.Log.Printf("keeping %s %s current, checking every %s", u.FQDN(), u.Type, every)
+	if err := u.Run(ctx, every); err != nil && !errors.Is(err, context.Canceled) {
+		return fail(err)
+	}
+	return 0
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/bc/porkbun-tui/internal/alerts"
+	"github.com/bc/porkbun-tui/internal/config"
+	"github.com/bc/porkbun-tui/internal/digest"
+)
+
+// defaultDigestWithin is the digest's expiry window when neither the flag
+// nor config.yaml sets one.
+const defaultDigestWithin = "60d"
+
+func runDigest(args []string) int {
+	fs := flag.NewFlagSet("digest", flag.ContinueOnError)
+	within := fs.String("within", "", "List domains expiring within this window (default from config, else 60d)")
+	printAs := fs.String("print", "", "Print the text or html digest to stdout instead of sending it")
+	cached := fs.Bool("cached", false, "Use cached data only, no API calls")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: porkbun-tui digest [--within 60d] [--print text|html] [--cached]")
+		fmt.Fprintln(fs.Output())
+		fmt.Fprintln(fs.Output(), "Emails a summary of expiring domains, monthly renewal costs and domains")
+		fmt.Fprintln(fs.Output(), "with security flags off to the recipients in the digest section of")
+		fmt.Fprintln(fs.Output(), "config.yaml. Run it from cron for a weekly report.")
+		fmt.Fprintln(fs.Output())
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return parseExit(err)
+	}
+	if *printAs != "" && *printAs != "text" && *printAs != "html" {
+		fmt.Fprintf(os.Stderr, "--print must be text or html, not %q\n", *printAs)
+		return 2
+	}
+
+	settings, err := config.LoadSettings()
+	if err != nil {
+		return fail(err)
+	}
+	dc := settings.Digest
+
+	if *within == "" {
+		*within = dc.Within
+	}
+	if *within == "" {
+		*within = defaultDigestWithin
+	}
+	window, err := alerts.ParseDuration(*within)
+	if err != nil {
+		return fail(fmt.Errorf("within: %w", err))
+	}
+
+	mailer := &digest.Mailer{
+		Host:     dc.SMTP.Host,
+		Port:     dc.SMTP.Port,
+		Username: dc.SMTP.Username,
+		Password: dc.SMTP.Password,
+		TLS:      dc.SMTP.TLS,
+	}
+	if *printAs == "" {
+		// Check the mail settings before spending API calls.
+		if err := mailer.Validate(); err != nil {
+			return fail(fmt.Errorf("digest.smtp: %w", err))
+		}
+		if dc.From == "" || len(dc.To) == 0 {
+			return fail(fmt.Errorf("digest: set from and to in config.yaml"))
+		}
+	}
+
+	domains, pricing, err := loadPortfolio(context.Background(), *cached)
+	if err != nil {
+		return fail(err)
+	}
+
+	now := time.Now()
+	report := digest.Build(domains, pricing, now, window)
+	report.Budget = settings.MonthlyBudget
+
+	switch *printAs {
+	case "text":
+		if err := digest.RenderText(os.Stdout, report); err != nil {
+			return fail(err)
+		}
+		return 0
+	case "html":
+		if err := digest.RenderHTML(os.Stdout, report); err != nil {
+			return fail(err)
+		}
+		return 0
+	}
+
+	var text, html bytes.Buffer
+	if err := digest.RenderText(&text, report); err != nil {
+		return fail(err)
+	}
+	if err := digest.RenderHTML(&html, report); err != nil {
+		return fail(err)
+	}
+	subject := dc.Subject
+	if subject == "" {
+		subject = report.Subject()
+	}
+	msg := digest.Message{From: dc.From, To: dc.To, Subject: subject, Text: text.String(), HTML: html.String()}
+	if err := mailer.Send(msg, now); err != nil {
+		return fail(fmt.Errorf("sending digest: %w", err))
+	}
+	fmt.Fprintf(os.Stderr, "Sent digest to %d recipients: %s\n", len(dc.To), subject)
+	return 0
+}
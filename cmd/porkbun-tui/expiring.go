@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bc/porkbun-tui/internal/alerts"
+	"github.com/bc/porkbun-tui/internal/config"
+)
+
+// exitUnknown is the Nagios UNKNOWN status: the check itself failed.
+const exitUnknown = 3
+
+func runExpiring(args []string) int {
+	fs := flag.NewFlagSet("expiring", flag.ContinueOnError)
+	within := fs.String("within", "", "Warn about domains expiring within this window (e.g. 30d); overrides the configured rules")
+	critical := fs.String("critical", "", "With --within: critical for domains expiring within this window (e.g. 7d)")
+	cached := fs.Bool("cached", false, "Use cached data only, no API calls")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: porkbun-tui expiring [--within 30d [--critical 7d]] [--cached]")
+		fmt.Fprintln(fs.Output())
+		fmt.Fprintln(fs.Output(), "Prints domains that trip an alert rule (the alerts list in config.yaml,")
+		fmt.Fprintln(fs.Output(), "or --within) and exits 0 OK, 1 WARNING, 2 CRITICAL or 3 UNKNOWN.")
+		fmt.Fprintln(fs.Output())
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return exitUnknown
+	}
+
+	rules, err := expiringRules(*within, *critical)
+	if err != nil {
+		return unknown(err)
+	}
+
+	domains, err := loadDomains(context.Background(), *cached)
+	if err != nil {
+		return unknown(err)
+	}
+
+	hits := alerts.Evaluate(rules, domains, time.Now())
+	status := alerts.Worst(hits)
+
+	if len(hits) == 0 {
+		fmt.Printf("EXPIRING OK - %d domains, no alerts\n", len(domains))
+		return int(status)
+	}
+
+	names := make([]string, len(hits))
+	for i, h := range hits {
+		names[i] = h.Domain.Name
+	}
+	fmt.Printf("EXPIRING %s - %d of %d domains: %s\n", status, len(hits), len(domains), strings.Join(names, ", "))
+	for _, h := range hits {
+		days := int(time.Until(h.Domain.ExpireDate).Hours() / 24)
+		fmt.Printf("%-8s  %-30s  %s  %4dd  %s\n", h.Severity, h.Domain.Name, h.Domain.ExpireDate.Format("2006-01-02"), days, h.RuleNames())
+	}
+	return int(status)
+}
+
+// expiringRules is the ad-hoc --within/--critical pair when given, else the
+// rules from config.yaml.
+func expiringRules(within, critical string) ([]alerts.Rule, error) {
+	if within == "" {
+		if critical != "" {
+			return nil, errors.New("--critical needs --within")
+		}
+		cfg, err := config.LoadSettings()
+		if err != nil {
+			return nil, err
+		}
+		rules, err := alerts.Compile(cfg.Alerts)
+		if err != nil {
+			return nil, err
+		}
+		if len(rules) == 0 {
+			return nil, errors.New("no alert rules configured; add alerts to config.yaml or pass --within")
+		}
+		return rules, nil
+	}
+
+	warn, err := alerts.ExpiresWithin(within, alerts.Warning)
+	if err != nil {
+		return nil, fmt.Errorf("--within: %w", err)
+	}
+	rules := []alerts.Rule{warn}
+	if critical != "" {
+		crit, err := alerts.ExpiresWithin(critical, alerts.Critical)
+		if err != nil {
+			return nil, fmt.Errorf("--critical: %w", err)
+		}
+		rules = append(rules, crit)
+	}
+	return rules, nil
+}
+
+func unknown(err error) int {
+	fmt.Printf("EXPIRING UNKNOWN - %v\n", err)
+	return exitUnknown
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/bc/porkbun-tui/internal/portfolio"
+)
+
+func runForecast(args []string) int {
+	fs := flag.NewFlagSet("forecast", flag.ContinueOnError)
+	years := fs.Int("years", 1, fmt.Sprintf("Forecast horizon in years (%d-%d)", portfolio.MinForecastYears, portfolio.MaxForecastYears))
+	by := fs.String("by", "month", "Breakdown: month, tld or label")
+	out := fs.String("o", "", "Write CSV to this file instead of stdout")
+	cached := fs.Bool("cached", false, "Use cached data only, no API calls")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: porkbun-tui forecast [--years N] [--by month|tld|label] [-o file] [--cached]")
+		fmt.Fprintln(fs.Output())
+		fmt.Fprintln(fs.Output(), "Projects renewal spend from each domain's expiration date and the")
+		fmt.Fprintln(fs.Output(), "current renewal price, and writes it as CSV.")
+		fmt.Fprintln(fs.Output())
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return parseExit(err)
+	}
+
+	if *years < portfolio.MinForecastYears || *years > portfolio.MaxForecastYears {
+		return fail(fmt.Errorf("--years must be between %d and %d", portfolio.MinForecastYears, portfolio.MaxForecastYears))
+	}
+	grouping, err := portfolio.ParseGrouping(*by)
+	if err != nil {
+		return fail(err)
+	}
+
+	domains, pricing, err := loadPortfolio(context.Background(), *cached)
+	if err != nil {
+		return fail(err)
+	}
+
+	f := portfolio.NewForecast(domains, pricing, time.Now(), *years)
+
+	var w io.Writer = os.Stdout
+	if *out != "" {
+		file, err := os.Create(*out)
+		if err != nil {
+			return fail(err)
+		}
+		defer file.Close()
+		w = file
+	}
+
+	if err := f.WriteCSV(w, grouping); err != nil {
+		return fail(err)
+	}
+	if f.Unpriced > 0 {
+		fmt.Fprintf(os.Stderr, "Warning: %d renewals have no pricing and are counted as $0\n", f.Unpriced)
+	}
+	return 0
+}
@@ -19,6 +19,13 @@ func printUsage() {
 	fmt.Println("porkbun-tui - Terminal UI for managing Porkbun domains")
 	fmt.Println()
 	fmt.Println("Usage: porkbun-tui [options]")
+	fmt.Println("       porkbun-tui <command> [flags]")
+	fmt.Println()
+	fmt.Println("Commands:")
+	for _, c := range commands {
+		fmt.Printf("  %-14s %s\n", c.name, c.summary)
+	}
+	fmt.Println("  Run 'porkbun-tui <command> -h' for a command's flags.")
 	fmt.Println()
 	fmt.Println("Options:")
 	fmt.Println("  -h, --help      Show this help message")
@@ -54,6 +61,13 @@ func printUsage() {
 }
 
 func main() {
+	// Subcommands run without the TUI
+	if len(os.Args) > 1 {
+		if c, ok := lookupCommand(os.Args[1]); ok {
+			os.Exit(c.run(os.Args[2:]))
+		}
+	}
+
 	// Parse flags
 	showHelp := flag.Bool("help", false, "Show help")
 	showVersion := flag.Bool("version", false, "Show version")
@@ -89,22 +103,23 @@ func main() {
 	}
 
 	var client *api.Client
+	var cfg *config.Config
 
 	if *demoMode {
 		// Demo mode: use built-in sample data
 		cachedDomains = demo.Domains()
 		cachedPricing = demo.Pricing()
+		// Settings still apply; credentials are not needed
+		cfg, err = config.LoadSettings()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
 	} else {
 		// Normal mode: load config and create API client
-		cfg, err := config.Load()
+		cfg, err = config.Load()
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			fmt.Fprintln(os.Stderr, "\nSet your Porkbun API credentials:")
-			fmt.Fprintln(os.Stderr, "  export PORKBUN_API_KEY=pk1_xxx")
-			fmt.Fprintln(os.Stderr, "  export PORKBUN_SECRET_KEY=sk1_xxx")
-			fmt.Fprintln(os.Stderr, "\nOr create ~/.config/porkbun-tui/config.yaml:")
-			fmt.Fprintln(os.Stderr, "  api_key: pk1_xxx")
-			fmt.Fprintln(os.Stderr, "  secret_key: sk1_xxx")
+			fmt.Fprintln(os.Stderr, credentialsHint)
 			os.Exit(1)
 		}
 		client = api.NewClient(cfg)
@@ -112,6 +127,10 @@ func main() {
 
 	// Create and run app
 	app := tui.NewApp(client, appCache, cachedDomains, cachedPricing, *demoMode)
+	if err := app.SetConfig(cfg); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
 	p := tea.NewProgram(app, tea.WithAltScreen())
 
 	if _, err := p.Run(); err != nil {
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/bc/porkbun-tui/internal/alerts"
+	"github.com/bc/porkbun-tui/internal/api"
+	"github.com/bc/porkbun-tui/internal/metrics"
+)
+
+func runMetrics(args []string) int {
+	fs := flag.NewFlagSet("metrics", flag.ContinueOnError)
+	listen := fs.String("listen", ":9115", "Serve /metrics on this address")
+	textfile := fs.String("textfile", "", "Write metrics to this .prom file once and exit (node_exporter textfile collector)")
+	refresh := fs.String("refresh", "15m", "Time between API refreshes when serving")
+	cached := fs.Bool("cached", false, "Read the cache only, no API calls (e.g. next to porkbun-tui watch)")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: porkbun-tui metrics [--listen :9115 | --textfile file.prom] [--refresh 15m] [--cached]")
+		fmt.Fprintln(fs.Output())
+		fmt.Fprintln(fs.Output(), "Exports per-domain expiry, auto-renew, lock, privacy and renewal price")
+		fmt.Fprintln(fs.Output(), "gauges, TLD and label aggregates, and API request statistics in the")
+		fmt.Fprintln(fs.Output(), "Prometheus text format.")
+		fmt.Fprintln(fs.Output())
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return parseExit(err)
+	}
+
+	every, err := alerts.ParseDuration(*refresh)
+	if err != nil || every < time.Minute {
+		return fail(fmt.Errorf("--refresh %q: must be at least 1m", *refresh))
+	}
+
+	l, err := newLoader(*cached)
+	if err != nil {
+		return fail(err)
+	}
+	var stats *api.Stats
+	if l.client != nil {
+		stats = l.client.Stats()
+	}
+	exporter := metrics.NewExporter(stats)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	update := func() error {
+		domains, pricing, err := l.load(ctx, true)
+		if err != nil {
+			exporter.SetRefreshFailed()
+			return err
+		}
+		exporter.SetPortfolio(domains, pricing)
+		return nil
+	}
+
+	if *textfile != "" {
+		if err := update(); err != nil {
+			return fail(err)
+		}
+		if err := exporter.WriteTextfile(*textfile); err != nil {
+			return fail(err)
+		}
+		return 0
+	}
+
+	logger := log.New(os.Stderr, "metrics: ", log.LstdFlags)
+	if err := update(); err != nil {
+		logger.Printf("refresh failed: %v", err)
+	}
+	go func() {
+		ticker := time.NewTicker(every)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := update(); err != nil {
+					logger.Printf("refresh failed: %v", err)
+				}
+			}
+		}
+	}()
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", exporter)
+	srv := &http.Server{Addr: *listen, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		shutdown, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		srv.Shutdown(shutdown)
+	}()
+
+	logger.Printf("serving http://%s/metrics, refreshing every %s", *listen, every)
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fail(err)
+	}
+	return 0
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/bc/porkbun-tui/internal/nsmigrate"
+)
+
+func runNSRollback(args []string) int {
+	fs := flag.NewFlagSet("ns-rollback", flag.ContinueOnError)
+	dryRun := fs.Bool("dry-run", false, "Print what would change without changing it")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: porkbun-tui ns-rollback [--dry-run] <file>")
+		fmt.Fprintln(fs.Output())
+		fmt.Fprintln(fs.Output(), "Puts back the nameservers recorded in a rollback file written by a")
+		fmt.Fprintln(fs.Output(), "nameserver migration. Exits 1 if any domain could not be restored.")
+		fmt.Fprintln(fs.Output())
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return parseExit(err)
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+
+	r, err := nsmigrate.ReadRollback(fs.Arg(0))
+	if err != nil {
+		return fail(err)
+	}
+	for _, domain := range r.Skipped {
+		fmt.Fprintf(os.Stderr, "%s: no previous nameservers recorded, skipped\n", domain)
+	}
+	if *dryRun {
+		for _, domain := range r.Domains() {
+			fmt.Printf("%s: %s\n", domain, strings.Join(r.Previous[domain], ", "))
+		}
+		if len(r.Skipped) > 0 {
+			return 1
+		}
+		return 0
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return fail(err)
+	}
+	failed := len(r.Skipped)
+	for _, domain := range r.Domains() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		err := client.UpdateNameservers(ctx, domain, r.Previous[domain])
+		cancel()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", domain, err)
+			failed++
+			continue
+		}
+		fmt.Printf("%s: %s\n", domain, strings.Join(r.Previous[domain], ", "))
+	}
+	if failed > 0 {
+		fmt.Fprintf(os.Stderr, "%d of %d domains not restored\n", failed, len(r.Previous)+len(r.Skipped))
+		return 1
+	}
+	return 0
+}
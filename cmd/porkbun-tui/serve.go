@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/bc/porkbun-tui/internal/alerts"
+	"github.com/bc/porkbun-tui/internal/config"
+	"github.com/bc/porkbun-tui/internal/web"
+)
+
+// defaultServeListen keeps the dashboard on this machine unless asked;
+// --listen :8080 shares it with the LAN.
+const defaultServeListen = "localhost:8080"
+
+func runServe(args []string) int {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	listen := fs.String("listen", "", "Address to serve on (default from config, else localhost:8080)")
+	refresh := fs.String("refresh", "15m", "Time between API refreshes of domains and pricing")
+	cached := fs.Bool("cached", false, "Read the cache only, no API calls (e.g. next to porkbun-tui watch)")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: porkbun-tui serve [--listen localhost:8080] [--refresh 15m] [--cached]")
+		fmt.Fprintln(fs.Output())
+		fmt.Fprintln(fs.Output(), "Serves a read-only web dashboard of the domain list, calendar, TLD costs")
+		fmt.Fprintln(fs.Output(), "and per-domain DNS records from the local cache, and a JSON API under /v1.")
+		fmt.Fprintln(fs.Output(), "Set serve.username and serve.password in config.yaml to require basic")
+		fmt.Fprintln(fs.Output(), "auth; serve.api_token enables nameserver and DNS changes through the API.")
+		fmt.Fprintln(fs.Output())
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return parseExit(err)
+	}
+
+	every, err := alerts.ParseDuration(*refresh)
+	if err != nil || every < time.Minute {
+		return fail(fmt.Errorf("--refresh %q: must be at least 1m", *refresh))
+	}
+
+	settings, err := config.LoadSettings()
+	if err != nil {
+		return fail(err)
+	}
+	sc := settings.Serve
+	if *listen == "" {
+		*listen = sc.Listen
+	}
+	if *listen == "" {
+		*listen = defaultServeListen
+	}
+	if sc.Username != "" && sc.Password == "" {
+		return fail(errors.New("serve.username is set without a password"))
+	}
+	rules, err := alerts.Compile(settings.Alerts)
+	if err != nil {
+		return fail(err)
+	}
+
+	l, err := newLoader(*cached)
+	if err != nil {
+		return fail(err)
+	}
+	if l.cache == nil {
+		return fail(errors.New("the dashboard needs the cache directory"))
+	}
+
+	dashboard := &web.Server{
+		Store:    l.cache,
+		Rules:    rules,
+		Budget:   settings.MonthlyBudget,
+		Username: sc.Username,
+		Password: sc.Password,
+		Token:    sc.APIToken,
+		Log:      log.New(os.Stderr, "serve: ", log.LstdFlags),
+	}
+	if l.client != nil {
+		dashboard.DNS = l.client
+		dashboard.Writer = l.client
+	}
+	logger := dashboard.Log
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if !*cached {
+		// The pages read the cache; refreshing it is all it takes.
+		go func() {
+			ticker := time.NewTicker(every)
+			defer ticker.Stop()
+			for {
+				if _, _, err := l.load(ctx, true); err != nil {
+					logger.Printf("refresh failed: %v", err)
+				}
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+				}
+			}
+		}()
+	}
+
+	srv := &http.Server{Addr: *listen, Handler: dashboard.Handler(), ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		shutdown, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		srv.Shutdown(shutdown)
+	}()
+
+	if sc.Username == "" {
+		logger.Printf("serving http://%s/ without authentication", *listen)
+	} else {
+		logger.Printf("serving http://%s/", *listen)
+	}
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fail(err)
+	}
+	return 0
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bc/porkbun-tui/internal/certs"
+)
+
+func runSSL(args []string) int {
+	fs := flag.NewFlagSet("ssl", flag.ContinueOnError)
+	out := fs.String("out", "", "Directory to write the files to (default ./<domain>)")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: porkbun-tui ssl [--out DIR] <domain>")
+		fmt.Fprintln(fs.Output())
+		fmt.Fprintln(fs.Output(), "Retrieves the domain's free Porkbun certificate and writes "+certs.CertFile+",")
+		fmt.Fprintln(fs.Output(), certs.ChainFile+", "+certs.FullChainFile+" and "+certs.KeyFile+", each readable by")
+		fmt.Fprintln(fs.Output(), "the owner only. Existing files are replaced.")
+		fmt.Fprintln(fs.Output())
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return parseExit(err)
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+	domain := strings.ToLower(strings.TrimSuffix(fs.Arg(0), "."))
+	dir := *out
+	if dir == "" {
+		dir = domain
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return fail(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	bundle, err := client.RetrieveSSLBundle(ctx, domain)
+	if err != nil {
+		return fail(fmt.Errorf("retrieving the SSL bundle for %s: %w", domain, err))
+	}
+	chain, err := certs.ParseChain(bundle.CertificateChain)
+	if err != nil {
+		return fail(err)
+	}
+	files, err := certs.Write(dir, bundle)
+	if err != nil {
+		return fail(err)
+	}
+
+	leaf := chain[0]
+	fmt.Printf("%s: %s, valid until %s (%d days)\n", domain,
+		strings.Join(certs.Names(leaf), ", "), leaf.NotAfter.Format("2006-01-02"), certs.DaysLeft(leaf, time.Now()))
+	for _, path := range []string{files.Cert, files.Chain, files.FullChain, files.Key} {
+		fmt.Println(path)
+	}
+	return 0
+}
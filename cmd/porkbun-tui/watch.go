@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/bc/porkbun-tui/internal/alerts"
+	"github.com/bc/porkbun-tui/internal/api"
+	"github.com/bc/porkbun-tui/internal/cache"
+	"github.com/bc/porkbun-tui/internal/config"
+	"github.com/bc/porkbun-tui/internal/watch"
+)
+
+// Watch defaults, when config.yaml doesn't set them.
+const (
+	defaultWatchInterval = time.Hour
+	defaultWatchDedupe   = 7 * 24 * time.Hour
+)
+
+func runWatch(args []string) int {
+	fs := flag.NewFlagSet("watch", flag.ContinueOnError)
+	interval := fs.String("interval", "", "Time between refreshes (default from config, else 1h)")
+	once := fs.Bool("once", false, "Run a single refresh and exit")
+	dns := fs.Bool("dns", false, "Also snapshot and diff DNS records (one API call per domain)")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: porkbun-tui watch [--interval 1h] [--once] [--dns]")
+		fmt.Fprintln(fs.Output())
+		fmt.Fprintln(fs.Output(), "Refreshes domains and pricing on a schedule, evaluates the alert rules")
+		fmt.Fprintln(fs.Output(), "and portfolio changes, and POSTs JSON events to the webhooks in the")
+		fmt.Fprintln(fs.Output(), "watch section of config.yaml. Refreshed data lands in the TUI's cache.")
+		fmt.Fprintln(fs.Output())
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return parseExit(err)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fail(err)
+	}
+	wc := cfg.Watch
+
+	every := defaultWatchInterval
+	if *interval == "" {
+		*interval = wc.Interval
+	}
+	if *interval != "" {
+		if every, err = alerts.ParseDuration(*interval); err != nil || every < time.Minute {
+			return fail(fmt.Errorf("interval %q: must be at least 1m", *interval))
+		}
+	}
+	window := defaultWatchDedupe
+	if wc.Dedupe != "" {
+		if window, err = alerts.ParseDuration(wc.Dedupe); err != nil {
+			return fail(fmt.Errorf("watch.dedupe: %w", err))
+		}
+	}
+
+	rules, err := alerts.Compile(cfg.Alerts)
+	if err != nil {
+		return fail(err)
+	}
+
+	appCache, err := cache.New()
+	if err != nil {
+		return fail(fmt.Errorf("opening cache: %w", err))
+	}
+	dedupe, err := watch.LoadDedupe(filepath.Join(appCache.Dir(), watch.DedupeFile), window)
+	if err != nil {
+		return fail(fmt.Errorf("reading dedupe state: %w", err))
+	}
+	snapshot, err := watch.LoadSnapshot(filepath.Join(appCache.Dir(), watch.SnapshotFile))
+	if err != nil {
+		return fail(fmt.Errorf("reading watch snapshot: %w", err))
+	}
+
+	var notifiers []watch.Notifier
+	for _, url := range wc.Webhooks {
+		wh := watch.NewWebhook(url)
+		if wc.Retries != nil {
+			wh.Retries = max(0, *wc.Retries)
+		}
+		notifiers = append(notifiers, wh)
+	}
+
+	logger := log.New(os.Stderr, "watch: ", log.LstdFlags)
+	if len(notifiers) == 0 {
+		logger.Print("no webhooks configured; refreshing the cache only")
+	}
+
+	w := &watch.Watcher{
+		Source:    api.NewClient(cfg),
+		Store:     appCache,
+		Snapshot:  snapshot,
+		Notifiers: notifiers,
+		Rules:     rules,
+		Dedupe:    dedupe,
+		DNS:       *dns || wc.DNS,
+		Log:       logger,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if *once {
+		report, err := w.RunOnce(ctx)
+		logger.Printf("checked %d domains: %d events, %d sent, %d suppressed",
+			report.Domains, report.Events, report.Sent, report.Suppressed)
+		if err != nil {
+			return fail(err)
+		}
+		return 0
+	}
+
+	logger.Printf("refreshing every %s", every)
+	if err := w.Run(ctx, every); err != nil && !errors.Is(err, context.Canceled) {
+		return fail(err)
+	}
+	return 0
+}
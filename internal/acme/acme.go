@@ -0,0 +1,161 @@
+// Package acme answers ACME DNS-01 challenges with Porkbun TXT records.
+package acme
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/bc/porkbun-tui/internal/api"
+)
+
+// ChallengeLabel prefixes the name a DNS-01 challenge is published under.
+const ChallengeLabel = "_acme-challenge"
+
+// Defaults for waiting on a new record.
+const (
+	DefaultTimeout = 2 * time.Minute
+	DefaultPoll    = 5 * time.Second
+)
+
+// Records reads and writes DNS records; *api.Client satisfies it.
+type Records interface {
+	GetDNSRecords(ctx context.Context, domain string) ([]api.DNSRecord, error)
+	CreateDNSRecord(ctx context.Context, domain string, r api.DNSRecord) (string, error)
+	DeleteDNSRecord(ctx context.Context, domain, id string) error
+}
+
+// ChallengeName is the record name for a challenge on fqdn. It accepts
+// what the clients pass: lego's "_acme-challenge.example.com.", certbot's
+// "example.com", or a wildcard "*.example.com".
+func ChallengeName(fqdn string) string {
+	fqdn = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(fqdn)), ".")
+	fqdn = strings.TrimPrefix(fqdn, "*.")
+	if fqdn == ChallengeLabel || strings.HasPrefix(fqdn, ChallengeLabel+".") {
+		return fqdn
+	}
+	return ChallengeLabel + "." + fqdn
+}
+
+// KeyAuthDigest is the TXT value for a key authorization, for clients
+// that pass the raw key authorization (lego's RAW exec mode).
+func KeyAuthDigest(keyAuth string) string {
+	sum := sha256.Sum256([]byte(keyAuth))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// Solver publishes and removes challenge records in Zone. Name is the
+// challenge record's name relative to the zone.
+type Solver struct {
+	Records Records
+	Timeout time.Duration // waiting for a new record to be listed; 0 is DefaultTimeout
+	Poll    time.Duration // 0 is DefaultPoll
+	Log     *log.Logger
+}
+
+// find returns the TXT records at name in zone holding value.
+func (s *Solver) find(ctx context.Context, zone, name, value string) ([]api.DNSRecord, error) {
+	records, err := s.Records.GetDNSRecords(ctx, zone)
+	if err != nil {
+		return nil, fmt.Errorf("reading records for %s: %w", zone, err)
+	}
+	var matching []api.DNSRecord
+	for _, r := range records {
+		if r.Type == "TXT" && api.Subdomain(r.Name, zone) == name && unquote(r.Content) == value {
+			matching = append(matching, r)
+		}
+	}
+	return matching, nil
+}
+
+// unquote drops the quotes some listings put around TXT content.
+func unquote(s string) string {
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+// Present creates the TXT record, unless it already exists, and waits
+// until the API lists it. Other TXT records at the name stay: a wildcard
+// and its apex are validated with two values at once.
+func (s *Solver) Present(ctx context.Context, zone, name, value string) error {
+	matching, err := s.find(ctx, zone, name, value)
+	if err != nil {
+		return err
+	}
+	if len(matching) > 0 {
+		s.logf("%s TXT already present", fqdn(name, zone))
+		return nil
+	}
+
+	rec := api.DNSRecord{Name: name, Type: "TXT", Content: value, TTL: fmt.Sprint(api.MinTTL)}
+	if err := api.ValidateDNSRecord(rec); err != nil {
+		return err
+	}
+	if _, err := s.Records.CreateDNSRecord(ctx, zone, rec); err != nil {
+		return fmt.Errorf("creating %s TXT: %w", fqdn(name, zone), err)
+	}
+	s.logf("created %s TXT", fqdn(name, zone))
+	return s.wait(ctx, zone, name, value)
+}
+
+func (s *Solver) wait(ctx context.Context, zone, name, value string) error {
+	timeout, poll := s.Timeout, s.Poll
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+	if poll == 0 {
+		poll = DefaultPoll
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for {
+		matching, err := s.find(ctx, zone, name, value)
+		if err == nil && len(matching) > 0 {
+			return nil
+		}
+		if err != nil {
+			s.logf("checking for the record: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%s TXT not listed after %s", fqdn(name, zone), timeout)
+		case <-time.After(poll):
+		}
+	}
+}
+
+// CleanUp deletes the TXT records at name holding value. Nothing to
+// delete is not an error, so a retried cleanup succeeds.
+func (s *Solver) CleanUp(ctx context.Context, zone, name, value string) error {
+	matching, err := s.find(ctx, zone, name, value)
+	if err != nil {
+		return err
+	}
+	for _, r := range matching {
+		if err := s.Records.DeleteDNSRecord(ctx, zone, r.ID); err != nil {
+			return fmt.Errorf("deleting %s TXT (id %s): %w", fqdn(name, zone), r.ID, err)
+		}
+	}
+	s.logf("removed %d %s TXT records", len(matching), fqdn(name, zone))
+	return nil
+}
+
+func fqdn(name, zone string) string {
+	if name == "" {
+		return zone
+	}
+	return name + "." + zone
+}
+
+func (s *Solver) logf(format string, args ...any) {
+	if s.Log != nil {
+		s.Log.Printf(format, args...)
+	}
+}
@@ -0,0 +1,137 @@
+package acme
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bc/porkbun-tui/internal/api"
+)
+
+// fakeRecords lists a created record only after lag more reads, like an
+// API that is briefly behind its own writes.
+type fakeRecords struct {
+	records []api.DNSRecord
+	pending []api.DNSRecord
+	lag     int
+	nextID  int
+	writes  []string
+}
+
+func (f *fakeRecords) GetDNSRecords(context.Context, string) ([]api.DNSRecord, error) {
+	if len(f.pending) > 0 {
+		if f.lag == 0 {
+			f.records = append(f.records, f.pending...)
+			f.pending = nil
+		} else {
+			f.lag--
+		}
+	}
+	return f.records, nil
+}
+
+func (f *fakeRecords) CreateDNSRecord(_ context.Context, domain string, r api.DNSRecord) (string, error) {
+	f.nextID++
+	r.ID = strconv.Itoa(f.nextID)
+	r.Name = fqdn(r.Name, domain) // listed by full name, like the API
+	f.pending = append(f.pending, r)
+	f.writes = append(f.writes, "create "+r.Name+" "+r.Content)
+	return r.ID, nil
+}
+
+func (f *fakeRecords) DeleteDNSRecord(_ context.Context, domain, id string) error {
+	f.writes = append(f.writes, "delete "+id)
+	for i, r := range f.records {
+		if r.ID == id {
+			f.records = append(f.records[:i], f.records[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func solver(f *fakeRecords) *Solver {
+	return &Solver{Records: f, Poll: time.Millisecond, Timeout: time.Second}
+}
+
+func TestChallengeName(t *testing.T) {
+	for in, want := range map[string]string{
+		"_acme-challenge.example.com.": "_acme-challenge.example.com",
+		"example.com":                  "_acme-challenge.example.com",
+		"*.example.com":                "_acme-challenge.example.com",
+		"Www.Example.com":              "_acme-challenge.www.example.com",
+	} {
+		if got := ChallengeName(in); got != want {
+			t.Errorf("ChallengeName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestKeyAuthDigest(t *testing.T) {
+	// RFC 8555 §8.4: base64url(SHA-256(key authorization)), unpadded.
+	if got := KeyAuthDigest("token.thumbprint"); len(got) != 43 || strings.ContainsAny(got, "+/=") {
+		t.Errorf("digest %q is not unpadded base64url of a SHA-256", got)
+	}
+}
+
+func TestPresentWaitsUntilListed(t *testing.T) {
+	f := &fakeRecords{lag: 3}
+
+	if err := solver(f).Present(context.Background(), "example.com", "_acme-challenge", "v1"); err != nil {
+		t.Fatalf("Present: %v", err)
+	}
+	if len(f.records) != 1 || f.records[0].Content != "v1" || f.records[0].TTL != "600" {
+		t.Errorf("Present returned before the record was listed: %+v", f.records)
+	}
+}
+
+func TestPresentTimesOut(t *testing.T) {
+	f := &fakeRecords{lag: 1 << 30}
+	s := solver(f)
+	s.Timeout = 20 * time.Millisecond
+
+	if err := s.Present(context.Background(), "example.com", "_acme-challenge", "v1"); err == nil {
+		t.Fatal("Present returned without the record ever being listed")
+	}
+}
+
+func TestPresentKeepsOtherValuesAndIsIdempotent(t *testing.T) {
+	f := &fakeRecords{records: []api.DNSRecord{
+		{ID: "7", Name: "_acme-challenge.example.com", Type: "TXT", Content: "wildcard-value"},
+	}}
+	s := solver(f)
+
+	for i := 0; i < 2; i++ {
+		if err := s.Present(context.Background(), "example.com", "_acme-challenge", "apex-value"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if strings.Join(f.writes, ";") != "create _acme-challenge.example.com apex-value" {
+		t.Errorf("writes = %v; want one create and no deletes", f.writes)
+	}
+	if len(f.records) != 2 {
+		t.Errorf("records = %+v", f.records)
+	}
+}
+
+func TestCleanUpRemovesOnlyItsValue(t *testing.T) {
+	f := &fakeRecords{records: []api.DNSRecord{
+		{ID: "7", Name: "_acme-challenge.example.com", Type: "TXT", Content: "keep"},
+		{ID: "8", Name: "_acme-challenge.example.com", Type: "TXT", Content: `"mine"`},
+		{ID: "9", Name: "example.com", Type: "TXT", Content: "mine"},
+	}}
+	s := solver(f)
+
+	if err := s.CleanUp(context.Background(), "example.com", "_acme-challenge", "mine"); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(f.writes, ";") != "delete 8" {
+		t.Errorf("writes = %v", f.writes)
+	}
+	// Already gone: still a success.
+	if err := s.CleanUp(context.Background(), "example.com", "_acme-challenge", "mine"); err != nil {
+		t.Errorf("second cleanup: %v", err)
+	}
+}
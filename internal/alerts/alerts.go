@@ -0,0 +1,236 @@
+// Package alerts evaluates the expiry alert rules from config.yaml against
+// the domain list. The TUI highlights hits; the expiring command turns
+// them into monitoring exit codes.
+package alerts
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bc/porkbun-tui/internal/api"
+	"github.com/bc/porkbun-tui/internal/config"
+)
+
+// Severity orders hits. Its values are the Nagios plugin exit codes.
+type Severity int
+
+const (
+	OK Severity = iota
+	Warning
+	Critical
+)
+
+func (s Severity) String() string {
+	switch s {
+	case Warning:
+		return "WARNING"
+	case Critical:
+		return "CRITICAL"
+	default:
+		return "OK"
+	}
+}
+
+// ParseSeverity reads a config severity; empty means Warning.
+func ParseSeverity(s string) (Severity, error) {
+	switch strings.ToLower(s) {
+	case "", "warning", "warn":
+		return Warning, nil
+	case "critical", "crit":
+		return Critical, nil
+	}
+	return OK, fmt.Errorf("unknown severity %q (want warning or critical)", s)
+}
+
+// ParseDuration extends time.ParseDuration with day (d) and week (w)
+// units, as a single integer count: "30d", "2w", or any Go duration.
+func ParseDuration(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if n := len(s); n > 1 {
+		var unit time.Duration
+		switch s[n-1] {
+		case 'd':
+			unit = 24 * time.Hour
+		case 'w':
+			unit = 7 * 24 * time.Hour
+		}
+		if unit != 0 {
+			count, err := strconv.Atoi(s[:n-1])
+			if err != nil || count < 0 {
+				return 0, fmt.Errorf("invalid duration %q", s)
+			}
+			return time.Duration(count) * unit, nil
+		}
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid duration %q (use e.g. 30d, 2w or 12h)", s)
+	}
+	return d, nil
+}
+
+// Rule matches domains for which every set condition holds, so
+// {Within: 30d, AutoRenew: false} reads "expires within 30 days and
+// auto-renew is off".
+type Rule struct {
+	Name     string
+	Severity Severity
+
+	// Within matches domains expiring within this long from now, or
+	// already expired. Zero means no expiry condition.
+	Within       time.Duration
+	AutoRenew    *bool
+	SecurityLock *bool
+	WhoisPrivacy *bool
+
+	within string // as written, for descriptions
+}
+
+// Compile validates the config rules, naming the first bad one.
+func Compile(raw []config.AlertRule) ([]Rule, error) {
+	rules := make([]Rule, 0, len(raw))
+	for i, r := range raw {
+		rule, err := compile(r)
+		if err != nil {
+			return nil, fmt.Errorf("alert rule %d: %w", i+1, err)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+func compile(r config.AlertRule) (Rule, error) {
+	severity, err := ParseSeverity(r.Severity)
+	if err != nil {
+		return Rule{}, err
+	}
+	rule := Rule{
+		Name:         r.Name,
+		Severity:     severity,
+		AutoRenew:    r.AutoRenew,
+		SecurityLock: r.SecurityLock,
+		WhoisPrivacy: r.WhoisPrivacy,
+		within:       r.Within,
+	}
+	if r.Within != "" {
+		if rule.Within, err = ParseDuration(r.Within); err != nil {
+			return Rule{}, err
+		}
+	}
+	if rule.Within == 0 && r.AutoRenew == nil && r.SecurityLock == nil && r.WhoisPrivacy == nil {
+		return Rule{}, fmt.Errorf("%q has no conditions", rule)
+	}
+	return rule, nil
+}
+
+// ExpiresWithin is the ad-hoc rule behind expiring --within.
+func ExpiresWithin(within string, severity Severity) (Rule, error) {
+	d, err := ParseDuration(within)
+	if err != nil {
+		return Rule{}, err
+	}
+	if d == 0 {
+		return Rule{}, fmt.Errorf("duration %q is zero", within)
+	}
+	return Rule{Severity: severity, Within: d, within: within}, nil
+}
+
+// String is the rule's name, or a description of its conditions.
+func (r Rule) String() string {
+	if r.Name != "" {
+		return r.Name
+	}
+	var parts []string
+	if r.Within != 0 {
+		within := r.within
+		if within == "" {
+			within = r.Within.String()
+		}
+		parts = append(parts, "expires within "+within)
+	}
+	flag := func(name string, v *bool) {
+		if v == nil {
+			return
+		}
+		state := "off"
+		if *v {
+			state = "on"
+		}
+		parts = append(parts, name+" "+state)
+	}
+	flag("auto-renew", r.AutoRenew)
+	flag("security lock", r.SecurityLock)
+	flag("WHOIS privacy", r.WhoisPrivacy)
+	return strings.Join(parts, ", ")
+}
+
+// Matches reports whether d satisfies every condition of the rule at now.
+func (r Rule) Matches(d api.Domain, now time.Time) bool {
+	if r.Within != 0 && d.ExpireDate.After(now.Add(r.Within)) {
+		return false
+	}
+	if r.AutoRenew != nil && d.AutoRenew != *r.AutoRenew {
+		return false
+	}
+	if r.SecurityLock != nil && d.SecurityLock != *r.SecurityLock {
+		return false
+	}
+	if r.WhoisPrivacy != nil && d.WhoisPrivacy != *r.WhoisPrivacy {
+		return false
+	}
+	return true
+}
+
+// Hit is a domain and every rule it trips.
+type Hit struct {
+	Domain   api.Domain
+	Rules    []Rule
+	Severity Severity // the worst of Rules
+}
+
+// RuleNames joins the names of the rules the domain tripped.
+func (h Hit) RuleNames() string {
+	names := make([]string, len(h.Rules))
+	for i, r := range h.Rules {
+		names[i] = r.String()
+	}
+	return strings.Join(names, "; ")
+}
+
+// Evaluate returns one Hit per domain that trips any rule, most severe
+// first, then soonest expiring.
+func Evaluate(rules []Rule, domains []api.Domain, now time.Time) []Hit {
+	var hits []Hit
+	for _, d := range domains {
+		hit := Hit{Domain: d}
+		for _, r := range rules {
+			if r.Matches(d, now) {
+				hit.Rules = append(hit.Rules, r)
+				hit.Severity = max(hit.Severity, r.Severity)
+			}
+		}
+		if len(hit.Rules) > 0 {
+			hits = append(hits, hit)
+		}
+	}
+
+	sort.SliceStable(hits, func(i, j int) bool {
+		if hits[i].Severity != hits[j].Severity {
+			return hits[i].Severity > hits[j].Severity
+		}
+		return hits[i].Domain.ExpireDate.Before(hits[j].Domain.ExpireDate)
+	})
+	return hits
+}
+
+// Worst is the highest severity among hits, OK when there are none.
+func Worst(hits []Hit) Severity {
+	worst := OK
+	for _, h := range hits {
+		worst = max(worst, h.Severity)
+	}
+	return worst
+}
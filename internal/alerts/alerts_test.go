@@ -0,0 +1,123 @@
+package alerts
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bc/porkbun-tui/internal/api"
+	"github.com/bc/porkbun-tui/internal/config"
+)
+
+var now = time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func boolPtr(b bool) *bool { return &b }
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Duration
+	}{
+		{"30d", 30 * 24 * time.Hour},
+		{"2w", 14 * 24 * time.Hour},
+		{"36h", 36 * time.Hour},
+		{" 7d ", 7 * 24 * time.Hour},
+	}
+	for _, tt := range tests {
+		got, err := ParseDuration(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseDuration(%q) = %v, %v; want %v", tt.in, got, err, tt.want)
+		}
+	}
+
+	for _, bad := range []string{"", "d", "soon", "-3d", "1.5d"} {
+		if _, err := ParseDuration(bad); err == nil {
+			t.Errorf("ParseDuration(%q) succeeded, want error", bad)
+		}
+	}
+}
+
+func TestCompileRejectsBadRules(t *testing.T) {
+	bad := []config.AlertRule{
+		{Name: "nothing"},
+		{Within: "soon"},
+		{Within: "7d", Severity: "page-me"},
+	}
+	for _, r := range bad {
+		if _, err := Compile([]config.AlertRule{r}); err == nil {
+			t.Errorf("Compile(%+v) succeeded, want error", r)
+		}
+	}
+}
+
+func TestEvaluateCombinesConditions(t *testing.T) {
+	rules, err := Compile([]config.AlertRule{
+		{Name: "lapsing", Within: "30d", AutoRenew: boolPtr(false), Severity: "critical"},
+		{Within: "7d"},
+		{SecurityLock: boolPtr(false)},
+	})
+	if err != nil {
+		t.Fatalf("Compile: %v", err)
+	}
+
+	domains := []api.Domain{
+		{Name: "fine.com", AutoRenew: true, SecurityLock: true, ExpireDate: now.AddDate(1, 0, 0)},
+		{Name: "lapsing.com", AutoRenew: false, SecurityLock: true, ExpireDate: now.AddDate(0, 0, 20)},
+		{Name: "renewing.com", AutoRenew: true, SecurityLock: true, ExpireDate: now.AddDate(0, 0, 20)},
+		{Name: "soon.com", AutoRenew: true, SecurityLock: true, ExpireDate: now.AddDate(0, 0, 3)},
+		{Name: "unlocked.com", AutoRenew: true, SecurityLock: false, ExpireDate: now.AddDate(1, 0, 0)},
+		{Name: "expired.com", AutoRenew: false, SecurityLock: true, ExpireDate: now.AddDate(0, 0, -2)},
+	}
+
+	hits := Evaluate(rules, domains, now)
+
+	got := map[string]Hit{}
+	for _, h := range hits {
+		got[h.Domain.Name] = h
+	}
+	if _, ok := got["fine.com"]; ok {
+		t.Error("fine.com tripped a rule")
+	}
+	if _, ok := got["renewing.com"]; ok {
+		t.Error("renewing.com matched the lapsing rule despite auto-renew on")
+	}
+	if h := got["lapsing.com"]; h.Severity != Critical || h.RuleNames() != "lapsing" {
+		t.Errorf("lapsing.com = %v %q, want CRITICAL lapsing", h.Severity, h.RuleNames())
+	}
+	if h := got["soon.com"]; h.Severity != Warning || h.RuleNames() != "expires within 7d" {
+		t.Errorf("soon.com = %v %q", h.Severity, h.RuleNames())
+	}
+	if h := got["unlocked.com"]; h.RuleNames() != "security lock off" {
+		t.Errorf("unlocked.com rules = %q", h.RuleNames())
+	}
+	// Already expired counts as "within" and trips both expiry rules.
+	if h := got["expired.com"]; len(h.Rules) != 2 || h.Severity != Critical {
+		t.Errorf("expired.com = %d rules, %v", len(h.Rules), h.Severity)
+	}
+
+	// Critical first, then soonest expiring.
+	if hits[0].Domain.Name != "expired.com" || hits[1].Domain.Name != "lapsing.com" {
+		t.Errorf("order = %s, %s; want expired.com, lapsing.com", hits[0].Domain.Name, hits[1].Domain.Name)
+	}
+	if Worst(hits) != Critical {
+		t.Errorf("Worst = %v, want CRITICAL", Worst(hits))
+	}
+	if Worst(nil) != OK {
+		t.Error("Worst of no hits is not OK")
+	}
+}
+
+func TestExpiresWithin(t *testing.T) {
+	r, err := ExpiresWithin("30d", Warning)
+	if err != nil {
+		t.Fatalf("ExpiresWithin: %v", err)
+	}
+	if !r.Matches(api.Domain{ExpireDate: now.AddDate(0, 0, 29)}, now) {
+		t.Error("domain expiring in 29 days not matched by --within 30d")
+	}
+	if r.Matches(api.Domain{ExpireDate: now.AddDate(0, 0, 31)}, now) {
+		t.Error("domain expiring in 31 days matched by --within 30d")
+	}
+	if _, err := ExpiresWithin("0d", Warning); err == nil {
+		t.Error("a zero window was accepted")
+	}
+}
@@ -0,0 +1,48 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// SetAutoRenew turns auto-renew on or off for domains in one call. The
+// call can succeed for some domains and not others: failed maps each
+// domain the registry didn't update to its reason. err is set when the
+// call as a whole failed, in which case no domain is known to be updated.
+func (c *Client) SetAutoRenew(ctx context.Context, domains []string, on bool) (failed map[string]error, err error) {
+	if len(domains) == 0 {
+		return nil, nil
+	}
+	status := "off"
+	if on {
+		status = "on"
+	}
+
+	var parsed struct {
+		Results map[string]struct {
+			Status  string `json:"status"`
+			Message string `json:"message"`
+		} `json:"results"`
+	}
+	// The domain in the path is ignored when the body lists domains.
+	params := map[string]any{"status": status, "domains": domains}
+	if err := c.call(ctx, "updateAutoRenew", []string{domains[0]}, params, &parsed); err != nil {
+		return nil, err
+	}
+
+	failed = make(map[string]error)
+	for _, d := range domains {
+		r, ok := parsed.Results[d]
+		switch {
+		case !ok:
+			failed[d] = errors.New("no result for this domain")
+		case r.Status != "SUCCESS":
+			if r.Message == "" {
+				r.Message = "update refused"
+			}
+			failed[d] = fmt.Errorf("porkbun: %s", r.Message)
+		}
+	}
+	return failed, nil
+}
@@ -0,0 +1,53 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestSetAutoRenewReportsPerDomainFailures(t *testing.T) {
+	var gotPath string
+	var gotBody map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		json.NewDecoder(r.Body).Decode(&gotBody)
+		w.Write([]byte(`{"status":"SUCCESS","results":{
+			"a.com":{"status":"SUCCESS","message":"Auto renew status updated."},
+			"b.com":{"status":"ERROR","message":"Domain is not eligible."}
+		}}`))
+	}))
+	defer server.Close()
+
+	failed, err := newTestClient(server.URL).SetAutoRenew(context.Background(), []string{"a.com", "b.com", "c.com"}, false)
+	if err != nil {
+		t.Fatalf("SetAutoRenew: %v", err)
+	}
+	if gotPath != "/domain/updateAutoRenew/a.com" {
+		t.Errorf("path = %s", gotPath)
+	}
+	if gotBody["status"] != "off" || !reflect.DeepEqual(gotBody["domains"], []any{"a.com", "b.com", "c.com"}) {
+		t.Errorf("body = %v", gotBody)
+	}
+	if len(failed) != 2 || failed["a.com"] != nil {
+		t.Fatalf("failed = %v, want b.com and c.com", failed)
+	}
+	if got := failed["b.com"].Error(); got != "porkbun: Domain is not eligible." {
+		t.Errorf("b.com error = %q", got)
+	}
+}
+
+func TestSetAutoRenewCallFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"status":"ERROR","message":"Invalid API key."}`))
+	}))
+	defer server.Close()
+
+	if _, err := newTestClient(server.URL).SetAutoRenew(context.Background(), []string{"a.com"}, true); err == nil {
+		t.Error("SetAutoRenew succeeded on an error response")
+	}
+}
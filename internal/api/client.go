@@ -27,6 +27,9 @@ type Client struct {
 	// premature client timeout leaves the user unsure whether they were
 	// charged. Falls back to httpClient when nil (tests).
 	purchaseClient *http.Client
+	// stats sees every request, SDK calls included. Nil in tests that
+	// build a Client directly.
+	stats *Stats
 }
 
 type Domain struct {
@@ -67,7 +70,16 @@ type TLDPricing struct {
 }
 
 func NewClient(cfg *config.Config) *Client {
+	stats := newStats()
+	// The limiter sits outside stats so latency doesn't count the wait.
+	transport := &limitTransport{
+		base:    &statsTransport{base: http.DefaultTransport, stats: stats},
+		limiter: NewLimiter(DefaultRequestInterval),
+	}
+
+	var sdkClient porkbun.HTTPClient = &http.Client{Transport: transport}
 	pb := porkbun.NewClient(&porkbun.Options{
+		HttpClient:   &sdkClient,
 		ApiKey:       cfg.APIKey,
 		SecretApiKey: cfg.SecretKey,
 	})
@@ -76,8 +88,9 @@ func NewClient(cfg *config.Config) *Client {
 		apiKey:         cfg.APIKey,
 		secretKey:      cfg.SecretKey,
 		baseURL:        defaultBaseURL,
-		httpClient:     &http.Client{Timeout: 15 * time.Second},
-		purchaseClient: &http.Client{Timeout: 60 * time.Second},
+		httpClient:     &http.Client{Timeout: 15 * time.Second, Transport: transport},
+		purchaseClient: &http.Client{Timeout: 60 * time.Second, Transport: transport},
+		stats:          stats,
 	}
 }
 
@@ -229,6 +242,61 @@ func NewClientWithBaseURL(cfg *config.Config, baseURL string) *Client {
 	return c
 }
 
+// call POSTs to a /domain/<method>/<args...> endpoint with the
+// credentials and params, and decodes a SUCCESS response into out when it
+// isn't nil.
+func (c *Client) call(ctx context.Context, method string, args []string, params map[string]any, out any) error {
+	body := map[string]any{
+		"apikey":       c.apiKey,
+		"secretapikey": c.secretKey,
+	}
+	for k, v := range params {
+		body[k] = v
+	}
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	endpoint := c.baseURL + "/domain/" + method
+	for _, a := range args {
+		endpoint += "/" + url.PathEscape(a)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var raw json.RawMessage
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return fmt.Errorf("porkbun: %s returned HTTP %d with an invalid body: %w", method, resp.StatusCode, err)
+	}
+	var status struct {
+		Status  string `json:"status"`
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(raw, &status); err != nil {
+		return fmt.Errorf("porkbun: %s returned HTTP %d with an invalid body: %w", method, resp.StatusCode, err)
+	}
+	if status.Status != "SUCCESS" {
+		if status.Message != "" {
+			return fmt.Errorf("porkbun: %s", status.Message)
+		}
+		return fmt.Errorf("porkbun: %s failed (HTTP %d)", method, resp.StatusCode)
+	}
+	if out != nil {
+		return json.Unmarshal(raw, out)
+	}
+	return nil
+}
+
 type RegistrationResult struct {
 	Domain       string
 	OrderID      int
@@ -373,3 +441,54 @@ func (c *Client) GetPricing(ctx context.Context) (map[string]TLDPricing, error)
 
 	return pricing, nil
 }
+
+func recordID(id string) (int64, error) {
+	n, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid record ID %q", id)
+	}
+	return n, nil
+}
+
+// CreateDNSRecord adds a record and returns its ID. r.Name is relative to
+// the domain; callers validate with ValidateDNSRecord first.
+func (c *Client) CreateDNSRecord(ctx context.Context, domain string, r DNSRecord) (string, error) {
+	resp, err := c.pb.Dns.CreateRecord(ctx, domain, &porkbun.DnsRecord{
+		Name:    r.Name,
+		Type:    porkbun.DnsRecordType(r.Type),
+		Content: r.Content,
+		TTL:     r.TTL,
+		Prio:    r.Priority,
+		Notes:   r.Notes,
+	})
+	if err != nil {
+		return "", err
+	}
+	return strconv.FormatInt(resp.ID, 10), nil
+}
+
+// EditDNSRecord replaces the record with the given ID.
+func (c *Client) EditDNSRecord(ctx context.Context, domain, id string, r DNSRecord) error {
+	n, err := recordID(id)
+	if err != nil {
+		return err
+	}
+	_, err = c.pb.Dns.EditRecord(ctx, domain, n, &porkbun.EditRecord{
+		Name:    r.Name,
+		Type:    porkbun.DnsRecordType(r.Type),
+		Content: r.Content,
+		TTL:     r.TTL,
+		Prio:    r.Priority,
+	})
+	return err
+}
+
+// DeleteDNSRecord removes the record with the given ID.
+func (c *Client) DeleteDNSRecord(ctx context.Context, domain, id string) error {
+	n, err := recordID(id)
+	if err != nil {
+		return err
+	}
+	_, err = c.pb.Dns.DeleteRecord(ctx, domain, n)
+	return err
+}
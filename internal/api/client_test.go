@@ -7,6 +7,8 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+
+	"github.com/bc/porkbun-tui/internal/config"
 )
 
 func newTestClient(serverURL string) *Client {
@@ -341,3 +343,89 @@ func TestCheckAvailabilityMalformedResponse(t *testing.T) {
 		t.Fatal("CheckAvailability returned nil error, want error")
 	}
 }
+
+func TestStatsCountRequestsByEndpoint(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "taken.com") {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+		w.Write([]byte(`{"status":"SUCCESS","response":{"avail":"yes","price":"1.00"}}`))
+	}))
+	defer server.Close()
+
+	c := NewClientWithBaseURL(&config.Config{APIKey: "pk1_test", SecretKey: "sk1_test"}, server.URL)
+	c.CheckAvailability(context.Background(), "a.com")
+	c.CheckAvailability(context.Background(), "b.com")
+	c.CheckAvailability(context.Background(), "taken.com")
+
+	snap := c.Stats().Snapshot()
+	want := []RequestCount{
+		{Endpoint: "domain/checkDomain", Code: "200", Count: 2},
+		{Endpoint: "domain/checkDomain", Code: "400", Count: 1},
+	}
+	if len(snap.Requests) != len(want) {
+		t.Fatalf("requests = %+v, want %+v", snap.Requests, want)
+	}
+	for i := range want {
+		if snap.Requests[i] != want[i] {
+			t.Errorf("requests[%d] = %+v, want %+v", i, snap.Requests[i], want[i])
+		}
+	}
+	if len(snap.Latencies) != 1 || snap.Latencies[0].Count != 3 {
+		t.Fatalf("latencies = %+v", snap.Latencies)
+	}
+	if b := snap.Latencies[0].Buckets; b[len(b)-1] != 3 {
+		t.Errorf("cumulative buckets = %v, want the last to hold all 3 requests", b)
+	}
+}
+
+func TestEndpointOf(t *testing.T) {
+	tests := map[string]string{
+		"/api/json/v3/dns/retrieve/example.com":   "dns/retrieve",
+		"/api/json/v3/domain/listAll":             "domain/listAll",
+		"/api/json/v3/ping":                       "ping",
+		"/domain/checkDomain/example.com":         "domain/checkDomain",
+		"/api/json/v3/dns/editByNameType/a.com/A": "dns/editByNameType",
+	}
+	for path, want := range tests {
+		if got := endpointOf(path); got != want {
+			t.Errorf("endpointOf(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestNilStatsSnapshot(t *testing.T) {
+	c := newTestClient("http://unused")
+	if snap := c.Stats().Snapshot(); len(snap.Requests) != 0 {
+		t.Errorf("nil stats snapshot = %+v", snap)
+	}
+}
+
+func TestPublicIPv4(t *testing.T) {
+	var gotPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		w.Write([]byte(`{"status":"SUCCESS","yourIp":"198.51.100.7"}`))
+	}))
+	defer server.Close()
+
+	ip, err := newTestClient(server.URL).PublicIP(context.Background(), false, server.URL)
+	if err != nil {
+		t.Fatalf("PublicIP: %v", err)
+	}
+	if ip != "198.51.100.7" || gotPath != "/ping" {
+		t.Errorf("ip = %q via %s", ip, gotPath)
+	}
+}
+
+func TestPublicIPRejectsWrongFamily(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status":"SUCCESS","yourIp":"2001:db8::1"}`))
+	}))
+	defer server.Close()
+
+	// An IPv6 answer must never end up in an A record.
+	if ip, err := newTestClient(server.URL).PublicIP(context.Background(), false, server.URL); err == nil {
+		t.Errorf("PublicIP = %q, want a family error", ip)
+	}
+}
@@ -0,0 +1,143 @@
+package api
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/tuzzmaniandevil/porkbun-go"
+)
+
+// DSRecord is a delegation signer record published at the registry. The
+// key tag identifies it; Porkbun deletes by key tag.
+type DSRecord struct {
+	KeyTag     string
+	Algorithm  string
+	DigestType string
+	Digest     string
+}
+
+var dnssecAlgorithms = map[string]string{
+	"1":  "RSAMD5",
+	"3":  "DSA",
+	"5":  "RSASHA1",
+	"6":  "DSA-NSEC3-SHA1",
+	"8":  "RSASHA256",
+	"10": "RSASHA512",
+	"12": "ECC-GOST",
+	"13": "ECDSAP256SHA256",
+	"14": "ECDSAP384SHA384",
+	"15": "ED25519",
+	"16": "ED448",
+}
+
+// digestLengths are the hex lengths of each digest type.
+var digestLengths = map[string]int{
+	"1": 40, // SHA-1
+	"2": 64, // SHA-256
+	"3": 64, // GOST R 34.11-94
+	"4": 96, // SHA-384
+}
+
+var digestNames = map[string]string{"1": "SHA-1", "2": "SHA-256", "3": "GOST", "4": "SHA-384"}
+
+// AlgorithmName is the mnemonic of a DNSSEC algorithm number, or the
+// number itself when unknown.
+func AlgorithmName(alg string) string {
+	if name, ok := dnssecAlgorithms[alg]; ok {
+		return name
+	}
+	return alg
+}
+
+// DigestTypeName is the name of a DS digest type number, or the number
+// itself when unknown.
+func DigestTypeName(t string) string {
+	if name, ok := digestNames[t]; ok {
+		return name
+	}
+	return t
+}
+
+// GetDSRecords lists the domain's DS records, sorted by key tag. None
+// means DNSSEC is off at the registry.
+func (c *Client) GetDSRecords(ctx context.Context, domain string) ([]DSRecord, error) {
+	resp, err := c.pb.Dns.GetDnssecRecords(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]DSRecord, 0, len(resp.Records))
+	for _, r := range resp.Records {
+		records = append(records, DSRecord{
+			KeyTag:     r.KeyTag,
+			Algorithm:  string(r.Alg),
+			DigestType: string(r.DigestType),
+			Digest:     strings.ToUpper(r.Digest),
+		})
+	}
+	sort.Slice(records, func(i, j int) bool {
+		a, _ := strconv.Atoi(records[i].KeyTag)
+		b, _ := strconv.Atoi(records[j].KeyTag)
+		return a < b
+	})
+	return records, nil
+}
+
+// CreateDSRecord publishes a DS record; callers get r from ParseDSRecord.
+func (c *Client) CreateDSRecord(ctx context.Context, domain string, r DSRecord) error {
+	_, err := c.pb.Dns.CreateDnssecRecord(ctx, domain, &porkbun.DnssecRecordData{
+		KeyTag:     r.KeyTag,
+		Alg:        porkbun.DnssecAlgorithm(r.Algorithm),
+		DigestType: porkbun.DnssecDigestType(r.DigestType),
+		Digest:     r.Digest,
+	})
+	return err
+}
+
+// DeleteDSRecord removes the DS record with the given key tag.
+func (c *Client) DeleteDSRecord(ctx context.Context, domain, keyTag string) error {
+	_, err := c.pb.Dns.DeleteDnssecRecord(ctx, domain, keyTag)
+	return err
+}
+
+// ParseDSRecord reads a DS record as DNS providers show it: either the
+// four fields ("2371 13 2 1F98...") or a full zone-file line
+// ("example.com. 3600 IN DS 2371 13 2 1F98..."). Digests split into
+// groups by whitespace are joined.
+func ParseDSRecord(text string) (DSRecord, error) {
+	fields := strings.Fields(text)
+	for i, f := range fields {
+		if strings.EqualFold(f, "DS") {
+			fields = fields[i+1:]
+			break
+		}
+	}
+	if len(fields) < 4 {
+		return DSRecord{}, fmt.Errorf("a DS record is \"<key tag> <algorithm> <digest type> <digest>\"")
+	}
+
+	r := DSRecord{
+		KeyTag:     fields[0],
+		Algorithm:  fields[1],
+		DigestType: fields[2],
+		Digest:     strings.ToUpper(strings.Join(fields[3:], "")),
+	}
+	if tag, err := strconv.Atoi(r.KeyTag); err != nil || tag < 0 || tag > 65535 {
+		return DSRecord{}, fmt.Errorf("key tag %q must be a number from 0 to 65535", r.KeyTag)
+	}
+	if !porkbun.DnssecAlgorithm(r.Algorithm).IsValid() {
+		return DSRecord{}, fmt.Errorf("unknown DNSSEC algorithm %q", r.Algorithm)
+	}
+	want, ok := digestLengths[r.DigestType]
+	if !ok {
+		return DSRecord{}, fmt.Errorf("unknown digest type %q", r.DigestType)
+	}
+	if _, err := hex.DecodeString(r.Digest); err != nil || len(r.Digest) != want {
+		return DSRecord{}, fmt.Errorf("a %s digest is %d hex characters", DigestTypeName(r.DigestType), want)
+	}
+	return r, nil
+}
@@ -0,0 +1,46 @@
+package api
+
+import "testing"
+
+func TestParseDSRecord(t *testing.T) {
+	const digest = "1f987cc6583e92df0890718c42e4dd4d4a3d8b9d3a28f2bb9f9e6d3fbe4e8a0c"
+	want := DSRecord{KeyTag: "2371", Algorithm: "13", DigestType: "2", Digest: "1F987CC6583E92DF0890718C42E4DD4D4A3D8B9D3A28F2BB9F9E6D3FBE4E8A0C"}
+
+	for _, in := range []string{
+		"2371 13 2 " + digest,
+		"example.com. 3600 IN DS 2371 13 2 " + digest,
+		"  2371\t13 2 " + digest[:32] + " " + digest[32:] + "\n",
+	} {
+		got, err := ParseDSRecord(in)
+		if err != nil {
+			t.Errorf("ParseDSRecord(%q): %v", in, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("ParseDSRecord(%q) = %+v", in, got)
+		}
+	}
+
+	for _, bad := range []string{
+		"",
+		"2371 13 2",
+		"70000 13 2 " + digest,
+		"2371 7 2 " + digest,
+		"2371 13 9 " + digest,
+		"2371 13 1 " + digest,
+		"2371 13 2 " + digest[:63] + "z",
+	} {
+		if _, err := ParseDSRecord(bad); err == nil {
+			t.Errorf("%q accepted", bad)
+		}
+	}
+}
+
+func TestAlgorithmName(t *testing.T) {
+	if got := AlgorithmName("13"); got != "ECDSAP256SHA256" {
+		t.Errorf("AlgorithmName(13) = %s", got)
+	}
+	if got := AlgorithmName("99"); got != "99" {
+		t.Errorf("AlgorithmName(99) = %s", got)
+	}
+}
@@ -0,0 +1,75 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tuzzmaniandevil/porkbun-go"
+)
+
+// URL forward types.
+const (
+	ForwardTemporary = "temporary" // 302
+	ForwardPermanent = "permanent" // 301
+)
+
+// URLForward redirects a domain, or one subdomain of it, to Location.
+// Subdomain is empty for the apex. IncludePath appends the request path
+// to Location; Wildcard forwards every subdomain too.
+type URLForward struct {
+	ID          string
+	Subdomain   string
+	Location    string
+	Type        string
+	IncludePath bool
+	Wildcard    bool
+}
+
+func (c *Client) GetURLForwards(ctx context.Context, domain string) ([]URLForward, error) {
+	resp, err := c.pb.Domains.GetDomainURLForwarding(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	forwards := make([]URLForward, 0, len(resp.Forwards))
+	for _, f := range resp.Forwards {
+		forwards = append(forwards, URLForward{
+			ID:          f.Id,
+			Subdomain:   f.Subdomain,
+			Location:    f.Location,
+			Type:        string(f.Type),
+			IncludePath: f.IncludePath == "yes",
+			Wildcard:    f.Wildcard == "yes",
+		})
+	}
+	return forwards, nil
+}
+
+// AddURLForward creates a forward; callers validate with
+// ValidateURLForward first. Porkbun doesn't return the new ID.
+func (c *Client) AddURLForward(ctx context.Context, domain string, f URLForward) error {
+	_, err := c.pb.Domains.AddDomainUrlForward(ctx, domain, &porkbun.UrlForward{
+		Subdomain:   f.Subdomain,
+		Location:    f.Location,
+		Type:        porkbun.ForwardType(f.Type),
+		IncludePath: yesNo(f.IncludePath),
+		Wildcard:    yesNo(f.Wildcard),
+	})
+	return err
+}
+
+// DeleteURLForward removes the forward with the given ID.
+func (c *Client) DeleteURLForward(ctx context.Context, domain, id string) error {
+	if id == "" {
+		return fmt.Errorf("invalid forward ID %q", id)
+	}
+	_, err := c.pb.Domains.DeleteDomainUrlForward(ctx, domain, id)
+	return err
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
@@ -0,0 +1,136 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// GlueHost is a nameserver host under a domain with the addresses the
+// registry publishes for it. Host is the full name, e.g. ns1.example.com.
+type GlueHost struct {
+	Host string
+	IPv4 []string
+	IPv6 []string
+}
+
+// IPs returns the host's addresses, IPv4 first.
+func (g GlueHost) IPs() []string {
+	return append(append([]string(nil), g.IPv4...), g.IPv6...)
+}
+
+type glueResponse struct {
+	// Hosts is a list of [hostname, {"v4": [...], "v6": [...]}] pairs.
+	Hosts []json.RawMessage `json:"hosts"`
+}
+
+type glueAddresses struct {
+	V4 []string `json:"v4"`
+	V6 []string `json:"v6"`
+}
+
+// GetGlueRecords lists the domain's glue hosts. Like the other glue
+// calls it goes to the endpoint directly; the porkbun-go SDK doesn't
+// expose glue.
+func (c *Client) GetGlueRecords(ctx context.Context, domain string) ([]GlueHost, error) {
+	var parsed glueResponse
+	if err := c.call(ctx, "getGlue", []string{domain}, nil, &parsed); err != nil {
+		return nil, err
+	}
+
+	hosts := make([]GlueHost, 0, len(parsed.Hosts))
+	for _, raw := range parsed.Hosts {
+		var pair []json.RawMessage
+		if err := json.Unmarshal(raw, &pair); err != nil || len(pair) != 2 {
+			return nil, fmt.Errorf("porkbun: getGlue returned an unexpected host entry %s", raw)
+		}
+		var host string
+		var addrs glueAddresses
+		if err := json.Unmarshal(pair[0], &host); err != nil {
+			return nil, fmt.Errorf("porkbun: getGlue returned an unexpected host name %s", pair[0])
+		}
+		if err := json.Unmarshal(pair[1], &addrs); err != nil {
+			return nil, fmt.Errorf("porkbun: getGlue returned unexpected addresses for %s", host)
+		}
+		hosts = append(hosts, GlueHost{Host: strings.ToLower(host), IPv4: addrs.V4, IPv6: addrs.V6})
+	}
+	return hosts, nil
+}
+
+// CreateGlueRecord adds a glue host. subdomain is relative to the domain
+// ("ns1"); ips come from NormalizeGlueIPs.
+func (c *Client) CreateGlueRecord(ctx context.Context, domain, subdomain string, ips []string) error {
+	return c.call(ctx, "createGlue", []string{domain, subdomain}, map[string]any{"ips": ips}, nil)
+}
+
+// UpdateGlueRecord replaces all of a glue host's addresses.
+func (c *Client) UpdateGlueRecord(ctx context.Context, domain, subdomain string, ips []string) error {
+	return c.call(ctx, "updateGlue", []string{domain, subdomain}, map[string]any{"ips": ips}, nil)
+}
+
+// DeleteGlueRecord removes a glue host. The registry refuses while the
+// host is still a nameserver of some domain.
+func (c *Client) DeleteGlueRecord(ctx context.Context, domain, subdomain string) error {
+	return c.call(ctx, "deleteGlue", []string{domain, subdomain}, nil, nil)
+}
+
+// NormalizeGlueIPs checks a glue host's addresses: at least one, each a
+// valid IPv4 or IPv6 address, none repeated. Addresses come back in
+// canonical form, separated from a comma- or space-separated list.
+func NormalizeGlueIPs(list string) ([]string, error) {
+	var out []string
+	seen := make(map[string]bool)
+	for _, f := range strings.FieldsFunc(list, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' }) {
+		ip := net.ParseIP(f)
+		if ip == nil {
+			return nil, fmt.Errorf("%q is not an IP address", f)
+		}
+		s := ip.String()
+		if seen[s] {
+			return nil, fmt.Errorf("%s is listed twice", s)
+		}
+		seen[s] = true
+		out = append(out, s)
+	}
+	if len(out) == 0 {
+		return nil, errors.New("a glue host needs at least one IP address")
+	}
+	return out, nil
+}
+
+// MissingGlue returns the nameservers that are under domain itself (so a
+// resolver can't find their address without glue) but have no glue host.
+func MissingGlue(domain string, nameservers []string, glue []GlueHost) []string {
+	have := make(map[string]bool, len(glue))
+	for _, g := range glue {
+		if len(g.IPv4)+len(g.IPv6) > 0 {
+			have[strings.TrimSuffix(strings.ToLower(g.Host), ".")] = true
+		}
+	}
+	var missing []string
+	for _, ns := range nameservers {
+		ns = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(ns)), ".")
+		if (ns == domain || strings.HasSuffix(ns, "."+domain)) && !have[ns] {
+			missing = append(missing, ns)
+		}
+	}
+	return missing
+}
+
+// GlueSubdomain turns a glue host typed as "ns1" or "ns1.example.com"
+// into the subdomain the glue endpoints take. The apex can't be a glue
+// host.
+func GlueSubdomain(host, domain string) (string, error) {
+	host = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(host)), ".")
+	sub := Subdomain(host, domain)
+	if sub == "" {
+		return "", errors.New("a glue host needs a name under the domain, e.g. ns1")
+	}
+	if !validHostname(sub) {
+		return "", fmt.Errorf("invalid glue host %q", host)
+	}
+	return sub, nil
+}
@@ -0,0 +1,115 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGetGlueRecordsParsesHostPairs(t *testing.T) {
+	var gotPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		w.Write([]byte(`{"status":"SUCCESS","hosts":[
+			["ns1.example.com",{"v6":["2001:db8::1"],"v4":["192.0.2.1","192.0.2.2"]}],
+			["NS2.example.com",{"v4":["192.0.2.3"]}]
+		]}`))
+	}))
+	defer server.Close()
+
+	hosts, err := newTestClient(server.URL).GetGlueRecords(context.Background(), "example.com")
+	if err != nil {
+		t.Fatalf("GetGlueRecords: %v", err)
+	}
+	if gotPath != "/domain/getGlue/example.com" {
+		t.Errorf("path = %s", gotPath)
+	}
+	want := []GlueHost{
+		{Host: "ns1.example.com", IPv4: []string{"192.0.2.1", "192.0.2.2"}, IPv6: []string{"2001:db8::1"}},
+		{Host: "ns2.example.com", IPv4: []string{"192.0.2.3"}},
+	}
+	if !reflect.DeepEqual(hosts, want) {
+		t.Errorf("hosts = %+v, want %+v", hosts, want)
+	}
+}
+
+func TestCreateGlueRecordSendsIPs(t *testing.T) {
+	var gotPath string
+	var gotBody map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		json.NewDecoder(r.Body).Decode(&gotBody)
+		w.Write([]byte(`{"status":"SUCCESS"}`))
+	}))
+	defer server.Close()
+
+	err := newTestClient(server.URL).CreateGlueRecord(context.Background(), "example.com", "ns1", []string{"192.0.2.1", "2001:db8::1"})
+	if err != nil {
+		t.Fatalf("CreateGlueRecord: %v", err)
+	}
+	if gotPath != "/domain/createGlue/example.com/ns1" {
+		t.Errorf("path = %s", gotPath)
+	}
+	if gotBody["apikey"] != "pk1_test" || !reflect.DeepEqual(gotBody["ips"], []any{"192.0.2.1", "2001:db8::1"}) {
+		t.Errorf("body = %v", gotBody)
+	}
+}
+
+func TestGlueCallReportsAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"status":"ERROR","message":"Host is in use as a nameserver."}`))
+	}))
+	defer server.Close()
+
+	err := newTestClient(server.URL).DeleteGlueRecord(context.Background(), "example.com", "ns1")
+	if err == nil || !strings.Contains(err.Error(), "in use as a nameserver") {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestNormalizeGlueIPs(t *testing.T) {
+	got, err := NormalizeGlueIPs(" 192.0.2.1, 2001:DB8:0::1  192.0.2.2")
+	if err != nil {
+		t.Fatalf("NormalizeGlueIPs: %v", err)
+	}
+	if want := []string{"192.0.2.1", "2001:db8::1", "192.0.2.2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	for _, bad := range []string{"", " , ", "192.0.2.300", "192.0.2.1,192.0.2.1", "ns1.example.com"} {
+		if _, err := NormalizeGlueIPs(bad); err == nil {
+			t.Errorf("%q accepted", bad)
+		}
+	}
+}
+
+func TestGlueSubdomain(t *testing.T) {
+	for in, want := range map[string]string{"ns1": "ns1", "NS1.example.com.": "ns1", "a.ns.example.com": "a.ns"} {
+		if got, err := GlueSubdomain(in, "example.com"); err != nil || got != want {
+			t.Errorf("GlueSubdomain(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	for _, bad := range []string{"", "example.com", "ns_1", "-ns"} {
+		if _, err := GlueSubdomain(bad, "example.com"); err == nil {
+			t.Errorf("%q accepted", bad)
+		}
+	}
+}
+
+func TestMissingGlue(t *testing.T) {
+	glue := []GlueHost{
+		{Host: "ns1.example.com", IPv4: []string{"192.0.2.1"}},
+		{Host: "ns3.example.com"}, // no addresses is no glue
+	}
+	ns := []string{"NS1.example.com.", "ns2.example.com", "ns3.example.com", "ns1.other.net", "ns.notexample.com"}
+
+	got := MissingGlue("example.com", ns, glue)
+	if want := []string{"ns2.example.com", "ns3.example.com"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("MissingGlue = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,31 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestLimiterSpacesRequests(t *testing.T) {
+	l := NewLimiter(20 * time.Millisecond)
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := l.Wait(context.Background()); err != nil {
+			t.Fatalf("Wait: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Errorf("3 requests took %v, want at least 40ms", elapsed)
+	}
+}
+
+func TestLimiterWaitHonoursContext(t *testing.T) {
+	l := NewLimiter(time.Hour)
+	l.Wait(context.Background()) // takes the free slot
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := l.Wait(ctx); err == nil {
+		t.Error("Wait returned nil after its context expired")
+	}
+}
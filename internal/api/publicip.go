@@ -0,0 +1,85 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+// IPv4BaseURL is Porkbun's IPv4-only API host. A ping through it reports
+// the caller's public IPv4 address.
+const IPv4BaseURL = "https://api-ipv4.porkbun.com/api/json/v3"
+
+type pingResponse struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+	YourIP  string `json:"yourIp"`
+}
+
+// PublicIP pings the API over IPv4 (or IPv6) only and returns the address
+// Porkbun saw. baseURL defaults to IPv4BaseURL for IPv4 and the regular,
+// dual-stack API host for IPv6; the connection is pinned to the family
+// either way, so a dual-stack host can't answer over the other one.
+func (c *Client) PublicIP(ctx context.Context, ipv6 bool, baseURL string) (string, error) {
+	network, family := "tcp4", "IPv4"
+	if ipv6 {
+		network, family = "tcp6", "IPv6"
+	}
+	if baseURL == "" {
+		baseURL = IPv4BaseURL
+		if ipv6 {
+			baseURL = defaultBaseURL
+		}
+	}
+
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = func(ctx context.Context, _, addr string) (net.Conn, error) {
+		return dialer.DialContext(ctx, network, addr)
+	}
+	var rt http.RoundTripper = transport
+	if c.stats != nil {
+		rt = &statsTransport{base: transport, stats: c.stats}
+	}
+	client := &http.Client{Timeout: 15 * time.Second, Transport: rt}
+
+	body, err := json.Marshal(map[string]string{
+		"apikey":       c.apiKey,
+		"secretapikey": c.secretKey,
+	})
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, baseURL+"/ping", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("pinging over %s: %w", family, err)
+	}
+	defer resp.Body.Close()
+
+	var parsed pingResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return "", fmt.Errorf("porkbun: ping returned HTTP %d with an invalid body: %w", resp.StatusCode, err)
+	}
+	if parsed.Status != "SUCCESS" {
+		if parsed.Message != "" {
+			return "", fmt.Errorf("porkbun: %s", parsed.Message)
+		}
+		return "", fmt.Errorf("porkbun: ping failed (HTTP %d)", resp.StatusCode)
+	}
+
+	ip := net.ParseIP(parsed.YourIP)
+	if ip == nil || (ip.To4() != nil) == ipv6 {
+		return "", fmt.Errorf("porkbun: ping reported %q, not an %s address", parsed.YourIP, family)
+	}
+	return ip.String(), nil
+}
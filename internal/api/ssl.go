@@ -0,0 +1,26 @@
+package api
+
+import "context"
+
+// SSLBundle is the free certificate Porkbun issues for a domain, as PEM.
+// CertificateChain holds the leaf first, then the intermediates.
+type SSLBundle struct {
+	CertificateChain string
+	PrivateKey       string
+	PublicKey        string
+}
+
+// RetrieveSSLBundle fetches the domain's certificate bundle, private key
+// included. Porkbun only has one once the domain uses its nameservers and
+// the certificate has been issued.
+func (c *Client) RetrieveSSLBundle(ctx context.Context, domain string) (*SSLBundle, error) {
+	resp, err := c.pb.Ssl.Retrieve(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+	return &SSLBundle{
+		CertificateChain: resp.Certificatechain,
+		PrivateKey:       resp.Privatekey,
+		PublicKey:        resp.Publickey,
+	}, nil
+}
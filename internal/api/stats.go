@@ -0,0 +1,165 @@
+package api
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LatencyBuckets are the upper bounds, in seconds, of the request latency
+// histogram.
+var LatencyBuckets = []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+// Stats counts API requests by endpoint and outcome and records their
+// latency. Every Client made by NewClient has one; it is safe for
+// concurrent use.
+type Stats struct {
+	mu        sync.Mutex
+	requests  map[requestKey]uint64
+	latencies map[string]*latency
+}
+
+type requestKey struct {
+	endpoint, code string
+}
+
+type latency struct {
+	buckets []uint64 // per bucket, not cumulative
+	count   uint64
+	sum     float64
+}
+
+// RequestCount is the number of requests to an endpoint that ended with a
+// status code, or "error" when no response arrived.
+type RequestCount struct {
+	Endpoint string
+	Code     string
+	Count    uint64
+}
+
+// Latency is an endpoint's latency histogram. Buckets are cumulative
+// counts for LatencyBuckets, Prometheus-style.
+type Latency struct {
+	Endpoint string
+	Buckets  []uint64
+	Count    uint64
+	Sum      float64
+}
+
+// StatsSnapshot is a consistent copy of the counters, sorted by endpoint.
+type StatsSnapshot struct {
+	Requests  []RequestCount
+	Latencies []Latency
+}
+
+func newStats() *Stats {
+	return &Stats{
+		requests:  make(map[requestKey]uint64),
+		latencies: make(map[string]*latency),
+	}
+}
+
+func (s *Stats) record(endpoint, code string, d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests[requestKey{endpoint, code}]++
+
+	l, ok := s.latencies[endpoint]
+	if !ok {
+		l = &latency{buckets: make([]uint64, len(LatencyBuckets))}
+		s.latencies[endpoint] = l
+	}
+	secs := d.Seconds()
+	for i, bound := range LatencyBuckets {
+		if secs <= bound {
+			l.buckets[i]++
+			break
+		}
+	}
+	l.count++
+	l.sum += secs
+}
+
+// Snapshot copies the counters. A nil Stats has none.
+func (s *Stats) Snapshot() StatsSnapshot {
+	var snap StatsSnapshot
+	if s == nil {
+		return snap
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for k, n := range s.requests {
+		snap.Requests = append(snap.Requests, RequestCount{Endpoint: k.endpoint, Code: k.code, Count: n})
+	}
+	sort.Slice(snap.Requests, func(i, j int) bool {
+		a, b := snap.Requests[i], snap.Requests[j]
+		if a.Endpoint != b.Endpoint {
+			return a.Endpoint < b.Endpoint
+		}
+		return a.Code < b.Code
+	})
+
+	for endpoint, l := range s.latencies {
+		cumulative := make([]uint64, len(l.buckets))
+		var running uint64
+		for i, n := range l.buckets {
+			running += n
+			cumulative[i] = running
+		}
+		snap.Latencies = append(snap.Latencies, Latency{Endpoint: endpoint, Buckets: cumulative, Count: l.count, Sum: l.sum})
+	}
+	sort.Slice(snap.Latencies, func(i, j int) bool {
+		return snap.Latencies[i].Endpoint < snap.Latencies[j].Endpoint
+	})
+	return snap
+}
+
+// Stats returns the client's request statistics, nil for clients built
+// without NewClient.
+func (c *Client) Stats() *Stats {
+	return c.stats
+}
+
+// statsTransport records every request that passes through it.
+type statsTransport struct {
+	base  http.RoundTripper
+	stats *Stats
+}
+
+func (t *statsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+
+	code := "error"
+	if err == nil {
+		code = strconv.Itoa(resp.StatusCode)
+	}
+	t.stats.record(endpointOf(req.URL.Path), code, time.Since(start))
+	return resp, err
+}
+
+// endpointOf reduces a request path to its API method, dropping the
+// version prefix and per-domain arguments so the endpoint label stays
+// bounded: /api/json/v3/dns/retrieve/example.com is "dns/retrieve".
+func endpointOf(path string) string {
+	segs := strings.Split(strings.Trim(path, "/"), "/")
+	for i, s := range segs {
+		if s == "v3" {
+			segs = segs[i+1:]
+			break
+		}
+	}
+	if len(segs) > 2 {
+		segs = segs[:2]
+	}
+	endpoint := strings.Join(segs, "/")
+	if endpoint == "" {
+		return "/"
+	}
+	return endpoint
+}
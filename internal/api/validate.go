@@ -0,0 +1,200 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	porkbun "github.com/tuzzmaniandevil/porkbun-go"
+)
+
+// MinTTL is the lowest TTL Porkbun accepts, and its default.
+const MinTTL = 600
+
+// A domain's nameserver set must have between MinNameservers and
+// MaxNameservers hosts.
+const (
+	MinNameservers = 2
+	MaxNameservers = 13
+)
+
+// NormalizeNameservers checks nameservers before they are sent to the
+// registry: blanks are dropped, names are lowercased without a trailing
+// dot, each must be a distinct hostname, and there must be
+// MinNameservers to MaxNameservers of them. Every writer (the TUI's
+// nameserver editor and migrations, config presets, the serve API) goes
+// through it.
+func NormalizeNameservers(ns []string) ([]string, error) {
+	var out []string
+	seen := make(map[string]bool)
+	for _, n := range ns {
+		n = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(n)), ".")
+		if n == "" {
+			continue
+		}
+		if err := CheckNameserver(n); err != nil {
+			return nil, err
+		}
+		if seen[n] {
+			return nil, fmt.Errorf("nameserver %s is listed twice", n)
+		}
+		seen[n] = true
+		out = append(out, n)
+	}
+	if len(out) < MinNameservers {
+		return nil, fmt.Errorf("at least %d nameservers are required", MinNameservers)
+	}
+	if len(out) > MaxNameservers {
+		return nil, fmt.Errorf("at most %d nameservers are allowed, got %d", MaxNameservers, len(out))
+	}
+	return out, nil
+}
+
+// CheckNameserver checks one nameserver as NormalizeNameservers would,
+// after lowercasing and dropping a trailing dot. IP addresses are turned
+// away: the registry wants the host's name, with glue records for its
+// addresses.
+func CheckNameserver(n string) error {
+	if net.ParseIP(n) != nil {
+		return fmt.Errorf("%s is an IP address; nameservers are set by hostname", n)
+	}
+	if !validHostname(n) || !strings.Contains(n, ".") {
+		return fmt.Errorf("%q is not a valid nameserver hostname", n)
+	}
+	return nil
+}
+
+// ValidateDNSRecord checks a record before it is created or edited. Name
+// is relative to the domain: empty for the apex, "www", "*" or "*.dev".
+func ValidateDNSRecord(r DNSRecord) error {
+	if !porkbun.DnsRecordType(r.Type).IsValid() {
+		return fmt.Errorf("unsupported record type %q", r.Type)
+	}
+	if r.Name != "" && !validRecordName(r.Name) {
+		return fmt.Errorf("invalid record name %q", r.Name)
+	}
+	if strings.TrimSpace(r.Content) == "" {
+		return errors.New("record content is required")
+	}
+	if r.TTL != "" {
+		ttl, err := strconv.Atoi(r.TTL)
+		if err != nil || ttl < MinTTL {
+			return fmt.Errorf("TTL must be a number of seconds, at least %d", MinTTL)
+		}
+	}
+	if r.Priority != "" {
+		prio, err := strconv.Atoi(r.Priority)
+		if err != nil || prio < 0 || prio > 65535 {
+			return errors.New("priority must be a number from 0 to 65535")
+		}
+	}
+
+	switch r.Type {
+	case "A":
+		if ip := net.ParseIP(r.Content); ip == nil || ip.To4() == nil {
+			return fmt.Errorf("A record content %q is not an IPv4 address", r.Content)
+		}
+	case "AAAA":
+		if ip := net.ParseIP(r.Content); ip == nil || ip.To4() != nil {
+			return fmt.Errorf("AAAA record content %q is not an IPv6 address", r.Content)
+		}
+	case "CNAME", "ALIAS", "NS", "MX":
+		if !validHostname(strings.TrimSuffix(r.Content, ".")) {
+			return fmt.Errorf("%s record content %q is not a hostname", r.Type, r.Content)
+		}
+	}
+	if r.Type == "CNAME" && r.Name == "" {
+		return errors.New("a CNAME can't be at the domain apex; use ALIAS")
+	}
+	return nil
+}
+
+// ValidateURLForward checks a forward before it is added. Subdomain is a
+// hostname relative to the domain, empty for the apex; Location must be an
+// absolute http or https URL.
+func ValidateURLForward(f URLForward) error {
+	if f.Subdomain != "" && !validHostname(f.Subdomain) {
+		return fmt.Errorf("invalid subdomain %q", f.Subdomain)
+	}
+	u, err := url.Parse(strings.TrimSpace(f.Location))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("forward location %q must be an http:// or https:// URL", f.Location)
+	}
+	if f.Type != ForwardTemporary && f.Type != ForwardPermanent {
+		return fmt.Errorf("forward type must be %s or %s, not %q", ForwardTemporary, ForwardPermanent, f.Type)
+	}
+	return nil
+}
+
+// Subdomain makes a record name relative to domain. Porkbun lists records
+// by full name ("www.example.com") but creates them by subdomain ("www").
+func Subdomain(name, domain string) string {
+	name = strings.TrimSuffix(strings.ToLower(name), ".")
+	if name == domain {
+		return ""
+	}
+	return strings.TrimSuffix(name, "."+domain)
+}
+
+// ZoneOf finds the domain in domains that owns fqdn (the longest match,
+// so a registered lab.example.com wins over example.com) and returns the
+// name relative to it.
+func ZoneOf(fqdn string, domains []Domain) (zone, name string, err error) {
+	fqdn = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(fqdn)), ".")
+	for _, d := range domains {
+		if (fqdn == d.Name || strings.HasSuffix(fqdn, "."+d.Name)) && len(d.Name) > len(zone) {
+			zone = d.Name
+		}
+	}
+	if zone == "" {
+		return "", "", fmt.Errorf("%s is not under any domain in the account", fqdn)
+	}
+	return zone, Subdomain(fqdn, zone), nil
+}
+
+func validHostname(s string) bool {
+	if s == "" || len(s) > 253 {
+		return false
+	}
+	for _, label := range strings.Split(s, ".") {
+		if !validLabel(label, false) {
+			return false
+		}
+	}
+	return true
+}
+
+// validRecordName is a relative name; service labels (_dmarc) and a
+// leading wildcard are allowed.
+func validRecordName(s string) bool {
+	if len(s) > 253 {
+		return false
+	}
+	for i, label := range strings.Split(s, ".") {
+		if label == "*" && i == 0 {
+			continue
+		}
+		if !validLabel(label, true) {
+			return false
+		}
+	}
+	return true
+}
+
+func validLabel(label string, underscore bool) bool {
+	if label == "" || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+		return false
+	}
+	for _, c := range label {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '-':
+		case c == '_' && underscore:
+		default:
+			return false
+		}
+	}
+	return true
+}
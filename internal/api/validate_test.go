@@ -0,0 +1,150 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeNameservers(t *testing.T) {
+	got, err := NormalizeNameservers([]string{" NS1.Example.com. ", "", "ns2.example.com"})
+	if err != nil {
+		t.Fatalf("NormalizeNameservers: %v", err)
+	}
+	if len(got) != 2 || got[0] != "ns1.example.com" || got[1] != "ns2.example.com" {
+		t.Errorf("got %v", got)
+	}
+
+	for _, bad := range [][]string{
+		{},
+		{"", "  "},
+		{"ns1.example.com", "ns1.example.com."},
+		{"localhost"},
+		{"ns1.exa mple.com"},
+		{"-ns.example.com"},
+		{"192.0.2.53", "ns1.example.com"},
+		{"ns1.example.com,"},
+		{"ns1.example.com"},
+	} {
+		if _, err := NormalizeNameservers(bad); err == nil {
+			t.Errorf("%q accepted", bad)
+		}
+	}
+}
+
+func TestNormalizeNameserversBounds(t *testing.T) {
+	hosts := func(n int) []string {
+		ns := make([]string, n)
+		for i := range ns {
+			ns[i] = fmt.Sprintf("ns%d.example.com", i+1)
+		}
+		return ns
+	}
+	for n, wantErr := range map[int]string{
+		1:              "at least 2",
+		MinNameservers: "",
+		MaxNameservers: "",
+		14:             "at most 13",
+	} {
+		_, err := NormalizeNameservers(hosts(n))
+		switch {
+		case wantErr == "" && err != nil:
+			t.Errorf("%d nameservers rejected: %v", n, err)
+		case wantErr != "" && (err == nil || !strings.Contains(err.Error(), wantErr)):
+			t.Errorf("%d nameservers: err = %v, want %q", n, err, wantErr)
+		}
+	}
+}
+
+func TestValidateDNSRecord(t *testing.T) {
+	valid := []DNSRecord{
+		{Type: "A", Content: "192.0.2.1"},
+		{Type: "AAAA", Name: "www", Content: "2001:db8::1", TTL: "3600"},
+		{Type: "CNAME", Name: "*.dev", Content: "example.net."},
+		{Type: "MX", Content: "mx.example.net", Priority: "10"},
+		{Type: "TXT", Name: "_dmarc", Content: "v=DMARC1; p=none"},
+		{Type: "ALIAS", Content: "lb.example.net"},
+	}
+	for _, r := range valid {
+		if err := ValidateDNSRecord(r); err != nil {
+			t.Errorf("%+v rejected: %v", r, err)
+		}
+	}
+
+	invalid := []DNSRecord{
+		{Type: "SPF", Content: "v=spf1"},
+		{Type: "A", Content: ""},
+		{Type: "A", Content: "2001:db8::1"},
+		{Type: "AAAA", Content: "192.0.2.1"},
+		{Type: "A", Content: "192.0.2.1", TTL: "60"},
+		{Type: "MX", Content: "mx.example.net", Priority: "high"},
+		{Type: "CNAME", Content: "example.net"},
+		{Type: "CNAME", Name: "www", Content: "not a host"},
+		{Type: "A", Name: "bad name", Content: "192.0.2.1"},
+		{Type: "A", Name: "a.*", Content: "192.0.2.1"},
+	}
+	for _, r := range invalid {
+		if err := ValidateDNSRecord(r); err == nil {
+			t.Errorf("%+v accepted", r)
+		}
+	}
+}
+
+func TestValidateURLForward(t *testing.T) {
+	valid := []URLForward{
+		{Location: "https://example.net", Type: ForwardPermanent},
+		{Subdomain: "shop", Location: "http://example.net/store?ref=1", Type: ForwardTemporary, IncludePath: true},
+	}
+	for _, f := range valid {
+		if err := ValidateURLForward(f); err != nil {
+			t.Errorf("%+v rejected: %v", f, err)
+		}
+	}
+
+	invalid := []URLForward{
+		{Location: "example.net", Type: ForwardPermanent},
+		{Location: "ftp://example.net", Type: ForwardPermanent},
+		{Location: "https://", Type: ForwardPermanent},
+		{Location: "https://example.net", Type: "301"},
+		{Subdomain: "*", Location: "https://example.net", Type: ForwardPermanent},
+		{Subdomain: "bad_label", Location: "https://example.net", Type: ForwardPermanent},
+	}
+	for _, f := range invalid {
+		if err := ValidateURLForward(f); err == nil {
+			t.Errorf("%+v accepted", f)
+		}
+	}
+}
+
+func TestSubdomain(t *testing.T) {
+	for name, want := range map[string]string{
+		"example.com":      "",
+		"www.example.com":  "www",
+		"WWW.Example.com.": "www",
+		"www":              "www",
+		"a.b.example.com":  "a.b",
+	} {
+		if got := Subdomain(name, "example.com"); got != want {
+			t.Errorf("Subdomain(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestZoneOf(t *testing.T) {
+	domains := []Domain{{Name: "example.com"}, {Name: "lab.example.com"}, {Name: "example.co.uk"}}
+
+	for fqdn, want := range map[string][2]string{
+		"home.example.com":    {"example.com", "home"},
+		"example.com.":        {"example.com", ""},
+		"nas.lab.example.com": {"lab.example.com", "nas"},
+		"Home.Example.co.uk":  {"example.co.uk", "home"},
+	} {
+		zone, name, err := ZoneOf(fqdn, domains)
+		if err != nil || zone != want[0] || name != want[1] {
+			t.Errorf("ZoneOf(%q) = %q, %q, %v; want %q", fqdn, zone, name, err, want)
+		}
+	}
+	if _, _, err := ZoneOf("home.other.net", domains); err == nil {
+		t.Error("a name outside the account was accepted")
+	}
+}
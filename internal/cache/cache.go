@@ -2,8 +2,10 @@ package cache
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/bc/porkbun-tui/internal/api"
@@ -12,6 +14,10 @@ import (
 const (
 	domainsFile = "domains.json"
 	pricingFile = "pricing.json"
+	dnsDir      = "dns" // one <domain>.json per domain
+	dnssecFile  = "dnssec.json"
+	nsFile      = "ns.json"
+	nsUndoFile  = "ns_undo.json" // kept by Clear: it isn't a copy of anything
 )
 
 type Cache struct {
@@ -28,6 +34,38 @@ type CachedPricing struct {
 	UpdatedAt time.Time                 `json:"updated_at"`
 }
 
+type CachedDNS struct {
+	Data      []api.DNSRecord `json:"data"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+// CachedDNSSEC maps domain names to whether DS records are published.
+// Domains never checked are absent.
+type CachedDNSSEC struct {
+	Data      map[string]bool `json:"data"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+// CachedNS maps domain names to their nameservers. Domains never
+// checked are absent.
+type CachedNS struct {
+	Data      map[string][]string `json:"data"`
+	UpdatedAt time.Time           `json:"updated_at"`
+}
+
+// NSUndo is a domain's nameservers from before its last change.
+type NSUndo struct {
+	Previous  []string  `json:"previous"`
+	ChangedAt time.Time `json:"changed_at"`
+}
+
+// CachedNSUndo maps domain names to the nameservers their last change
+// replaced.
+type CachedNSUndo struct {
+	Data      map[string]NSUndo `json:"data"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
 // New creates a new cache instance using ~/.cache/porkbun-tui/
 func New() (*Cache, error) {
 	homeDir, err := os.UserHomeDir()
@@ -115,14 +153,182 @@ func (c *Cache) SavePricing(pricing map[string]api.TLDPricing) error {
 	return os.WriteFile(path, data, 0644)
 }
 
+// dnsPath is the cache file for a domain's DNS records. The domain comes
+// from the API, but is still refused if it could escape the cache dir.
+func (c *Cache) dnsPath(domain string) (string, error) {
+	if domain == "" || strings.ContainsAny(domain, `/\`) || strings.HasPrefix(domain, ".") {
+		return "", fmt.Errorf("invalid domain name %q", domain)
+	}
+	return filepath.Join(c.dir, dnsDir, domain+".json"), nil
+}
+
+// LoadDNS loads a domain's cached DNS records from disk
+func (c *Cache) LoadDNS(domain string) ([]api.DNSRecord, time.Time, error) {
+	path, err := c.dnsPath(domain)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, time.Time{}, nil // No cache, not an error
+		}
+		return nil, time.Time{}, err
+	}
+
+	var cached CachedDNS
+	if err := json.Unmarshal(data, &cached); err != nil {
+		return nil, time.Time{}, err
+	}
+
+	return cached.Data, cached.UpdatedAt, nil
+}
+
+// SaveDNS saves a domain's DNS records to the cache
+func (c *Cache) SaveDNS(domain string, records []api.DNSRecord) error {
+	path, err := c.dnsPath(domain)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	cached := CachedDNS{
+		Data:      records,
+		UpdatedAt: time.Now(),
+	}
+
+	data, err := json.MarshalIndent(cached, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0644)
+}
+
+// LoadDNSSEC loads the cached DNSSEC status of each checked domain
+func (c *Cache) LoadDNSSEC() (map[string]bool, time.Time, error) {
+	path := filepath.Join(c.dir, dnssecFile)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, time.Time{}, nil // No cache, not an error
+		}
+		return nil, time.Time{}, err
+	}
+
+	var cached CachedDNSSEC
+	if err := json.Unmarshal(data, &cached); err != nil {
+		return nil, time.Time{}, err
+	}
+
+	return cached.Data, cached.UpdatedAt, nil
+}
+
+// SaveDNSSEC saves the DNSSEC status of each checked domain
+func (c *Cache) SaveDNSSEC(status map[string]bool) error {
+	cached := CachedDNSSEC{
+		Data:      status,
+		UpdatedAt: time.Now(),
+	}
+
+	data, err := json.MarshalIndent(cached, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(c.dir, dnssecFile)
+	return os.WriteFile(path, data, 0644)
+}
+
+// LoadNS loads the cached nameservers of each checked domain
+func (c *Cache) LoadNS() (map[string][]string, time.Time, error) {
+	path := filepath.Join(c.dir, nsFile)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, time.Time{}, nil // No cache, not an error
+		}
+		return nil, time.Time{}, err
+	}
+
+	var cached CachedNS
+	if err := json.Unmarshal(data, &cached); err != nil {
+		return nil, time.Time{}, err
+	}
+
+	return cached.Data, cached.UpdatedAt, nil
+}
+
+// SaveNS saves the nameservers of each checked domain
+func (c *Cache) SaveNS(nameservers map[string][]string) error {
+	cached := CachedNS{
+		Data:      nameservers,
+		UpdatedAt: time.Now(),
+	}
+
+	data, err := json.MarshalIndent(cached, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(c.dir, nsFile)
+	return os.WriteFile(path, data, 0644)
+}
+
+// LoadNSUndo loads the nameservers each domain's last change replaced
+func (c *Cache) LoadNSUndo() (map[string]NSUndo, error) {
+	path := filepath.Join(c.dir, nsUndoFile)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil // No history, not an error
+		}
+		return nil, err
+	}
+
+	var cached CachedNSUndo
+	if err := json.Unmarshal(data, &cached); err != nil {
+		return nil, err
+	}
+
+	return cached.Data, nil
+}
+
+// SaveNSUndo saves the nameservers each domain's last change replaced
+func (c *Cache) SaveNSUndo(undo map[string]NSUndo) error {
+	cached := CachedNSUndo{
+		Data:      undo,
+		UpdatedAt: time.Now(),
+	}
+
+	data, err := json.MarshalIndent(cached, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(c.dir, nsUndoFile)
+	return os.WriteFile(path, data, 0644)
+}
+
+// Dir is the cache directory, for state files kept next to the cache.
+func (c *Cache) Dir() string {
+	return c.dir
+}
+
 // Clear removes all cached data
 func (c *Cache) Clear() error {
-	files := []string{domainsFile, pricingFile}
+	files := []string{domainsFile, pricingFile, dnssecFile, nsFile}
 	for _, f := range files {
 		path := filepath.Join(c.dir, f)
 		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
 			return err
 		}
 	}
-	return nil
+	return os.RemoveAll(filepath.Join(c.dir, dnsDir))
 }
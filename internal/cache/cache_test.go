@@ -3,6 +3,7 @@ package cache
 import (
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
 	"time"
 
@@ -239,3 +240,131 @@ func TestNew(t *testing.T) {
 		t.Errorf("cache dir should exist: %s", c.dir)
 	}
 }
+
+func TestCache_SaveAndLoadDNS(t *testing.T) {
+	c := newTestCache(t)
+
+	records := []api.DNSRecord{{ID: "1", Name: "example.com", Type: "A", Content: "192.0.2.1", TTL: "600"}}
+	if err := c.SaveDNS("example.com", records); err != nil {
+		t.Fatalf("SaveDNS failed: %v", err)
+	}
+
+	loaded, updatedAt, err := c.LoadDNS("example.com")
+	if err != nil {
+		t.Fatalf("LoadDNS failed: %v", err)
+	}
+	if updatedAt.IsZero() || len(loaded) != 1 || loaded[0].Content != "192.0.2.1" {
+		t.Errorf("LoadDNS = %+v at %v", loaded, updatedAt)
+	}
+
+	// Unknown domain: no cache, not an error
+	if loaded, _, err := c.LoadDNS("other.com"); err != nil || loaded != nil {
+		t.Errorf("LoadDNS(other.com) = %v, %v; want nil, nil", loaded, err)
+	}
+
+	if err := c.Clear(); err != nil {
+		t.Fatalf("Clear failed: %v", err)
+	}
+	if loaded, _, _ := c.LoadDNS("example.com"); loaded != nil {
+		t.Error("Clear left cached DNS records behind")
+	}
+}
+
+func TestCache_DNSRejectsPathNames(t *testing.T) {
+	c := newTestCache(t)
+
+	for _, name := range []string{"", "../escape", "a/b", ".hidden"} {
+		if err := c.SaveDNS(name, nil); err == nil {
+			t.Errorf("SaveDNS(%q) succeeded, want error", name)
+		}
+	}
+}
+
+func TestCache_SaveAndLoadDNSSEC(t *testing.T) {
+	c := newTestCache(t)
+
+	if loaded, _, err := c.LoadDNSSEC(); err != nil || loaded != nil {
+		t.Fatalf("LoadDNSSEC on empty cache = %v, %v; want nil, nil", loaded, err)
+	}
+
+	if err := c.SaveDNSSEC(map[string]bool{"signed.com": true, "plain.com": false}); err != nil {
+		t.Fatalf("SaveDNSSEC failed: %v", err)
+	}
+	loaded, updatedAt, err := c.LoadDNSSEC()
+	if err != nil {
+		t.Fatalf("LoadDNSSEC failed: %v", err)
+	}
+	if updatedAt.IsZero() || !loaded["signed.com"] || loaded["plain.com"] {
+		t.Errorf("LoadDNSSEC = %v at %v", loaded, updatedAt)
+	}
+	if _, ok := loaded["plain.com"]; !ok {
+		t.Error("a checked domain without DNSSEC was dropped")
+	}
+
+	if err := c.Clear(); err != nil {
+		t.Fatalf("Clear failed: %v", err)
+	}
+	if loaded, _, _ := c.LoadDNSSEC(); loaded != nil {
+		t.Error("Clear left the DNSSEC status behind")
+	}
+}
+
+func TestCache_SaveAndLoadNS(t *testing.T) {
+	c := newTestCache(t)
+
+	if loaded, _, err := c.LoadNS(); err != nil || loaded != nil {
+		t.Fatalf("LoadNS on empty cache = %v, %v; want nil, nil", loaded, err)
+	}
+
+	ns := map[string][]string{"example.com": {"ada.ns.cloudflare.com", "bob.ns.cloudflare.com"}}
+	if err := c.SaveNS(ns); err != nil {
+		t.Fatalf("SaveNS failed: %v", err)
+	}
+	loaded, updatedAt, err := c.LoadNS()
+	if err != nil {
+		t.Fatalf("LoadNS failed: %v", err)
+	}
+	if updatedAt.IsZero() || !reflect.DeepEqual(loaded, ns) {
+		t.Errorf("LoadNS = %v at %v, want %v", loaded, updatedAt, ns)
+	}
+
+	if err := c.Clear(); err != nil {
+		t.Fatalf("Clear failed: %v", err)
+	}
+	if loaded, _, _ := c.LoadNS(); loaded != nil {
+		t.Error("Clear left the nameservers behind")
+	}
+}
+
+func TestCache_SaveAndLoadNSUndo(t *testing.T) {
+	c := newTestCache(t)
+
+	if loaded, err := c.LoadNSUndo(); err != nil || loaded != nil {
+		t.Fatalf("LoadNSUndo on empty cache = %v, %v; want nil, nil", loaded, err)
+	}
+
+	changed := time.Date(2026, 10, 18, 14, 25, 0, 0, time.UTC)
+	undo := map[string]NSUndo{
+		"example.com": {Previous: []string{"ns1.example.net", "ns2.example.net"}, ChangedAt: changed},
+	}
+	if err := c.SaveNSUndo(undo); err != nil {
+		t.Fatalf("SaveNSUndo failed: %v", err)
+	}
+	loaded, err := c.LoadNSUndo()
+	if err != nil {
+		t.Fatalf("LoadNSUndo failed: %v", err)
+	}
+	got := loaded["example.com"]
+	if len(got.Previous) != 2 || got.Previous[1] != "ns2.example.net" || !got.ChangedAt.Equal(changed) {
+		t.Errorf("LoadNSUndo = %+v", loaded)
+	}
+
+	// The undo history isn't a copy of Porkbun's data; clearing the cache
+	// must not throw it away.
+	if err := c.Clear(); err != nil {
+		t.Fatalf("Clear failed: %v", err)
+	}
+	if loaded, _ := c.LoadNSUndo(); loaded == nil {
+		t.Error("Clear removed the nameserver undo history")
+	}
+}
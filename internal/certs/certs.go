@@ -0,0 +1,156 @@
+// Package certs reads Porkbun's SSL bundles: it parses the PEM chain for
+// display and writes the certificate, chain and key out for a web server.
+package certs
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/bc/porkbun-tui/internal/api"
+)
+
+// File names written by Write, following certbot's layout so existing
+// server configs can point at them.
+const (
+	CertFile      = "cert.pem"
+	ChainFile     = "chain.pem"
+	FullChainFile = "fullchain.pem"
+	KeyFile       = "privkey.pem"
+)
+
+// ParseChain decodes every CERTIFICATE block in chain, in order. It fails
+// on a chain without certificates or with one that doesn't parse.
+func ParseChain(chain string) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	rest := []byte(chain)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("certificate %d: %w", len(certs)+1, err)
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, errors.New("no certificates in the bundle")
+	}
+	return certs, nil
+}
+
+// DaysLeft is the number of whole days until cert expires, negative once
+// it has.
+func DaysLeft(cert *x509.Certificate, now time.Time) int {
+	return int(cert.NotAfter.Sub(now).Hours() / 24)
+}
+
+// Names lists the certificate's subject alternative names: DNS names, then
+// IP addresses.
+func Names(cert *x509.Certificate) []string {
+	names := append([]string(nil), cert.DNSNames...)
+	for _, ip := range cert.IPAddresses {
+		names = append(names, ip.String())
+	}
+	return names
+}
+
+// Files are the paths Write produced.
+type Files struct {
+	Cert, Chain, FullChain, Key string
+}
+
+// Write saves the bundle into dir, creating it (0700) if needed: the leaf
+// certificate, the intermediates, both together, and the private key.
+// Every file is 0600 — the key is secret, and keeping the set uniform
+// means a later chmod can't be forgotten for one of them. Each file is
+// replaced atomically so a server reloading mid-write never reads half a
+// key.
+func Write(dir string, b *api.SSLBundle) (*Files, error) {
+	if strings.TrimSpace(b.PrivateKey) == "" {
+		return nil, errors.New("the bundle has no private key")
+	}
+	if _, err := ParseChain(b.CertificateChain); err != nil {
+		return nil, err
+	}
+	leaf, intermediates := splitChain(b.CertificateChain)
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	f := &Files{
+		Cert:      filepath.Join(dir, CertFile),
+		Chain:     filepath.Join(dir, ChainFile),
+		FullChain: filepath.Join(dir, FullChainFile),
+		Key:       filepath.Join(dir, KeyFile),
+	}
+	for _, w := range []struct{ path, data string }{
+		{f.Key, b.PrivateKey},
+		{f.Cert, leaf},
+		{f.Chain, intermediates},
+		{f.FullChain, leaf + intermediates},
+	} {
+		if err := writeFile(w.path, w.data); err != nil {
+			return nil, err
+		}
+	}
+	return f, nil
+}
+
+// splitChain re-encodes the chain's certificate blocks as the leaf and
+// the intermediates, dropping anything else Porkbun put between them.
+func splitChain(chain string) (leaf, intermediates string) {
+	var b strings.Builder
+	rest := []byte(chain)
+	first := true
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		encoded := string(pem.EncodeToMemory(&pem.Block{Type: block.Type, Bytes: block.Bytes}))
+		if first {
+			leaf, first = encoded, false
+			continue
+		}
+		b.WriteString(encoded)
+	}
+	return leaf, b.String()
+}
+
+func writeFile(path, data string) error {
+	if data != "" && !strings.HasSuffix(data, "\n") {
+		data += "\n"
+	}
+	// CreateTemp makes the file 0600, so the key is never readable by
+	// others, not even briefly.
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+"-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.WriteString(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,157 @@
+package certs
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bc/porkbun-tui/internal/api"
+)
+
+var now = time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+// testBundle issues a leaf for example.com from a throwaway CA, the way
+// Porkbun returns it: leaf first, then the intermediate.
+func testBundle(t *testing.T) *api.SSLBundle {
+	t.Helper()
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caTmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		NotBefore:             now.AddDate(-1, 0, 0),
+		NotAfter:              now.AddDate(1, 0, 0),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTmpl, caTmpl, &caKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ca, _ := x509.ParseCertificate(caDER)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leafTmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		DNSNames:     []string{"example.com", "*.example.com"},
+		IPAddresses:  []net.IP{net.ParseIP("192.0.2.1")},
+		NotBefore:    now.AddDate(0, 0, -80),
+		NotAfter:     now.AddDate(0, 0, 10),
+	}
+	leafDER, err := x509.CreateCertificate(rand.Reader, leafTmpl, ca, &key.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encode := func(typ string, der []byte) string {
+		return string(pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: der}))
+	}
+	return &api.SSLBundle{
+		CertificateChain: encode("CERTIFICATE", leafDER) + "\n" + encode("CERTIFICATE", caDER),
+		PrivateKey:       encode("PRIVATE KEY", keyDER),
+	}
+}
+
+func TestParseChain(t *testing.T) {
+	chain, err := ParseChain(testBundle(t).CertificateChain)
+	if err != nil {
+		t.Fatalf("ParseChain: %v", err)
+	}
+	if len(chain) != 2 {
+		t.Fatalf("%d certificates, want 2", len(chain))
+	}
+	leaf := chain[0]
+	if leaf.Issuer.CommonName != "Test CA" {
+		t.Errorf("issuer = %q", leaf.Issuer.CommonName)
+	}
+	if got := strings.Join(Names(leaf), ","); got != "example.com,*.example.com,192.0.2.1" {
+		t.Errorf("names = %s", got)
+	}
+	if got := DaysLeft(leaf, now); got != 10 {
+		t.Errorf("days left = %d, want 10", got)
+	}
+}
+
+func TestParseChainRejectsEmptyAndGarbage(t *testing.T) {
+	if _, err := ParseChain(""); err == nil {
+		t.Error("empty chain accepted")
+	}
+	bad := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("junk")}))
+	if _, err := ParseChain(bad); err == nil {
+		t.Error("unparseable certificate accepted")
+	}
+}
+
+func TestWriteSplitsChainWithPrivatePermissions(t *testing.T) {
+	b := testBundle(t)
+	dir := filepath.Join(t.TempDir(), "example.com")
+
+	files, err := Write(dir, b)
+	if err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	read := func(path string) string {
+		t.Helper()
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if perm := info.Mode().Perm(); perm != 0o600 {
+			t.Errorf("%s mode = %o, want 600", filepath.Base(path), perm)
+		}
+		data, _ := os.ReadFile(path)
+		return string(data)
+	}
+
+	if info, _ := os.Stat(dir); info.Mode().Perm() != 0o700 {
+		t.Errorf("dir mode = %o, want 700", info.Mode().Perm())
+	}
+	if read(files.Key) != b.PrivateKey {
+		t.Error("private key not written verbatim")
+	}
+	for path, want := range map[string]int{files.Cert: 1, files.Chain: 1, files.FullChain: 2} {
+		certs, err := ParseChain(read(path))
+		if err != nil || len(certs) != want {
+			t.Errorf("%s: %d certificates (%v), want %d", filepath.Base(path), len(certs), err, want)
+			continue
+		}
+		if path == files.Cert && certs[0].Subject.CommonName != "example.com" {
+			t.Errorf("cert.pem holds %s, not the leaf", certs[0].Subject.CommonName)
+		}
+	}
+}
+
+func TestWriteRefusesBundleWithoutKey(t *testing.T) {
+	b := testBundle(t)
+	b.PrivateKey = ""
+	dir := filepath.Join(t.TempDir(), "out")
+
+	if _, err := Write(dir, b); err == nil {
+		t.Fatal("bundle without a key written")
+	}
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Error("directory created for a rejected bundle")
+	}
+}
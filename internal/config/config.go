@@ -11,33 +11,132 @@ import (
 type Config struct {
 	APIKey    string `yaml:"api_key"`
 	SecretKey string `yaml:"secret_key"`
+
+	// MonthlyBudget highlights calendar months whose auto-renew charges
+	// exceed it. Zero disables the check.
+	MonthlyBudget float64 `yaml:"monthly_budget"`
+
+	// CalendarReminders are the alarm offsets, in days before expiration,
+	// of exported calendar events. Unset uses the exporter's defaults; an
+	// empty list exports no alarms.
+	CalendarReminders []int `yaml:"calendar_reminders"`
+
+	// Alerts are expiry alert rules, highlighted in the domain list and
+	// checked by the expiring command. The alerts package compiles and
+	// validates them.
+	Alerts []AlertRule `yaml:"alerts"`
+
+	// Watch configures the watch daemon.
+	Watch WatchConfig `yaml:"watch"`
+
+	// Digest configures the email digest.
+	Digest DigestConfig `yaml:"digest"`
+
+	// Serve configures the web dashboard.
+	Serve ServeConfig `yaml:"serve"`
+
+	// DNSTemplates are named record sets the domain list can apply to
+	// marked domains, next to the built-in ones. The dnstemplate package
+	// validates them.
+	DNSTemplates []DNSTemplate `yaml:"dns_templates"`
+
+	// NSPresets are named nameserver sets offered when editing or
+	// migrating nameservers, after the built-in ones; per-account pairs
+	// like Cloudflare's go here. The nspreset package validates them.
+	NSPresets []NSPreset `yaml:"ns_presets"`
+
+	// DNSProviders name the DNS hosts behind nameserver patterns, checked
+	// before the built-in table. The dnsprovider package validates them.
+	DNSProviders []DNSProvider `yaml:"dns_providers"`
 }
 
-func Load() (*Config, error) {
-	cfg := &Config{}
+// DNSProvider is a DNS provider rule as written in config.yaml: a
+// nameserver containing any of the patterns belongs to the provider.
+type DNSProvider struct {
+	Name     string   `yaml:"name"`
+	Patterns []string `yaml:"patterns"`
+}
+
+// NSPreset is a nameserver preset as written in config.yaml.
+type NSPreset struct {
+	Name        string   `yaml:"name"`
+	Nameservers []string `yaml:"nameservers"`
+}
 
-	// Try environment variables first (highest priority)
-	cfg.APIKey = os.Getenv("PORKBUN_API_KEY")
-	cfg.SecretKey = os.Getenv("PORKBUN_SECRET_KEY")
+// DNSTemplate is a DNS template as written in config.yaml.
+type DNSTemplate struct {
+	Name    string              `yaml:"name"`
+	Records []DNSTemplateRecord `yaml:"records"`
+}
 
-	// If env vars are set, return early
-	if cfg.APIKey != "" && cfg.SecretKey != "" {
-		return cfg, nil
-	}
+// DNSTemplateRecord is one record of a template. Name is relative to the
+// domain ("" for the apex); {domain} in Content becomes the domain name.
+type DNSTemplateRecord struct {
+	Type     string `yaml:"type"`
+	Name     string `yaml:"name"`
+	Content  string `yaml:"content"`
+	TTL      string `yaml:"ttl"`
+	Priority string `yaml:"priority"`
+}
 
-	// Try config file as fallback
-	configPath := getConfigPath()
-	if configPath != "" {
-		fileCfg, err := loadFromFile(configPath)
-		if err == nil {
-			// Only override if not set by env vars
-			if cfg.APIKey == "" {
-				cfg.APIKey = fileCfg.APIKey
-			}
-			if cfg.SecretKey == "" {
-				cfg.SecretKey = fileCfg.SecretKey
-			}
-		}
+// ServeConfig is the web dashboard's address and optional basic auth.
+// APIToken enables the JSON API's nameserver and DNS changes.
+// PORKBUN_SERVE_PASSWORD and PORKBUN_SERVE_TOKEN override the secrets.
+type ServeConfig struct {
+	Listen   string `yaml:"listen"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+	APIToken string `yaml:"api_token"`
+}
+
+// DigestConfig is who the email digest goes to and how it is sent.
+type DigestConfig struct {
+	Within  string     `yaml:"within"` // expiry window covered, e.g. 60d
+	From    string     `yaml:"from"`
+	To      []string   `yaml:"to"`
+	Subject string     `yaml:"subject"`
+	SMTP    SMTPConfig `yaml:"smtp"`
+}
+
+// SMTPConfig is an outgoing mail server. TLS is starttls (the default),
+// tls for implicit TLS (usually port 465), or none for a local relay.
+// PORKBUN_SMTP_PASSWORD overrides Password.
+type SMTPConfig struct {
+	Host     string `yaml:"host"`
+	Port     int    `yaml:"port"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+	TLS      string `yaml:"tls"`
+}
+
+// WatchConfig is the watch daemon's schedule and delivery settings.
+// Durations use the alert syntax (30d, 2w, 12h); empty values take the
+// daemon's defaults.
+type WatchConfig struct {
+	Interval string   `yaml:"interval"`
+	DNS      bool     `yaml:"dns"` // also snapshot and diff DNS records
+	Webhooks []string `yaml:"webhooks"`
+	Dedupe   string   `yaml:"dedupe"`  // suppress repeats of an event for this long
+	Retries  *int     `yaml:"retries"` // per webhook delivery
+}
+
+// AlertRule is an alert rule as written in config.yaml. Every set
+// condition must hold for a domain to match.
+type AlertRule struct {
+	Name         string `yaml:"name"`
+	Severity     string `yaml:"severity"` // warning (default) or critical
+	Within       string `yaml:"within"`   // e.g. 30d, 2w, 12h
+	AutoRenew    *bool  `yaml:"auto_renew"`
+	SecurityLock *bool  `yaml:"security_lock"`
+	WhoisPrivacy *bool  `yaml:"whois_privacy"`
+}
+
+// Load returns the configuration with API credentials, failing when they
+// are missing. Environment variables take priority over the config file.
+func Load() (*Config, error) {
+	cfg, err := LoadSettings()
+	if err != nil {
+		return nil, err
 	}
 
 	// Validate
@@ -48,6 +147,48 @@ func Load() (*Config, error) {
 	return cfg, nil
 }
 
+// LoadSettings is Load without the credential check, for modes that make no
+// API calls (demo) but still honor the rest of config.yaml. A missing file
+// is not an error; a malformed one is.
+func LoadSettings() (*Config, error) {
+	cfg := &Config{}
+
+	// The config file is read even when credentials come from the
+	// environment: it also carries non-credential settings.
+	if configPath := getConfigPath(); configPath != "" {
+		fileCfg, err := loadFromFile(configPath)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", configPath, err)
+		}
+		cfg = fileCfg
+	}
+
+	for _, days := range cfg.CalendarReminders {
+		if days < 1 {
+			return nil, fmt.Errorf("calendar_reminders: %d is not a positive number of days", days)
+		}
+	}
+
+	// Environment variables have the highest priority
+	if v := os.Getenv("PORKBUN_API_KEY"); v != "" {
+		cfg.APIKey = v
+	}
+	if v := os.Getenv("PORKBUN_SECRET_KEY"); v != "" {
+		cfg.SecretKey = v
+	}
+	if v := os.Getenv("PORKBUN_SMTP_PASSWORD"); v != "" {
+		cfg.Digest.SMTP.Password = v
+	}
+	if v := os.Getenv("PORKBUN_SERVE_PASSWORD"); v != "" {
+		cfg.Serve.Password = v
+	}
+	if v := os.Getenv("PORKBUN_SERVE_TOKEN"); v != "" {
+		cfg.Serve.APIToken = v
+	}
+
+	return cfg, nil
+}
+
 func getConfigPath() string {
 	// Per the XDG spec, XDG_CONFIG_HOME replaces ~/.config entirely when set
 	configHome := os.Getenv("XDG_CONFIG_HOME")
@@ -138,3 +138,127 @@ secret_key: sk1_from_file
 		t.Errorf("expected SecretKey 'sk1_from_env', got '%s'", cfg.SecretKey)
 	}
 }
+
+func writeTestConfig(t *testing.T, content string) {
+	t.Helper()
+	tmpDir := t.TempDir()
+	configDir := filepath.Join(tmpDir, "porkbun-tui")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		t.Fatalf("failed to create config dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(configDir, "config.yaml"), []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	t.Setenv("XDG_CONFIG_HOME", tmpDir)
+}
+
+func TestLoad_SettingsReadWithEnvCredentials(t *testing.T) {
+	writeTestConfig(t, "monthly_budget: 75.50\n")
+	t.Setenv("PORKBUN_API_KEY", "pk1_from_env")
+	t.Setenv("PORKBUN_SECRET_KEY", "sk1_from_env")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.MonthlyBudget != 75.50 {
+		t.Errorf("MonthlyBudget = %.2f, want 75.50 from the file despite env credentials", cfg.MonthlyBudget)
+	}
+}
+
+func TestLoadSettings_NoCredentialsRequired(t *testing.T) {
+	writeTestConfig(t, "monthly_budget: 20\n")
+	t.Setenv("PORKBUN_API_KEY", "")
+	t.Setenv("PORKBUN_SECRET_KEY", "")
+
+	cfg, err := LoadSettings()
+	if err != nil {
+		t.Fatalf("LoadSettings without credentials: %v", err)
+	}
+	if cfg.MonthlyBudget != 20 {
+		t.Errorf("MonthlyBudget = %.2f, want 20", cfg.MonthlyBudget)
+	}
+}
+
+func TestLoad_MalformedFileIsAnError(t *testing.T) {
+	writeTestConfig(t, "api_key: [unterminated\n")
+	t.Setenv("PORKBUN_API_KEY", "pk1_from_env")
+	t.Setenv("PORKBUN_SECRET_KEY", "sk1_from_env")
+
+	if _, err := Load(); err == nil {
+		t.Fatal("malformed config.yaml was silently ignored")
+	}
+}
+
+func TestLoadSettings_CalendarReminders(t *testing.T) {
+	writeTestConfig(t, "calendar_reminders: [14, 3]\n")
+
+	cfg, err := LoadSettings()
+	if err != nil {
+		t.Fatalf("LoadSettings: %v", err)
+	}
+	if len(cfg.CalendarReminders) != 2 || cfg.CalendarReminders[0] != 14 || cfg.CalendarReminders[1] != 3 {
+		t.Errorf("CalendarReminders = %v, want [14 3]", cfg.CalendarReminders)
+	}
+
+	// An explicit empty list (no alarms) must stay distinguishable from unset.
+	writeTestConfig(t, "calendar_reminders: []\n")
+	if cfg, err = LoadSettings(); err != nil || cfg.CalendarReminders == nil {
+		t.Errorf("empty calendar_reminders = %v, %v; want non-nil empty", cfg.CalendarReminders, err)
+	}
+
+	writeTestConfig(t, "calendar_reminders: [7, 0]\n")
+	if _, err := LoadSettings(); err == nil {
+		t.Error("a zero-day reminder was accepted")
+	}
+}
+
+func TestLoadSettings_AlertRules(t *testing.T) {
+	writeTestConfig(t, `alerts:
+  - name: lapsing
+    within: 30d
+    auto_renew: false
+    severity: critical
+  - security_lock: false
+`)
+
+	cfg, err := LoadSettings()
+	if err != nil {
+		t.Fatalf("LoadSettings: %v", err)
+	}
+	if len(cfg.Alerts) != 2 {
+		t.Fatalf("got %d alert rules, want 2", len(cfg.Alerts))
+	}
+	lapsing := cfg.Alerts[0]
+	if lapsing.Within != "30d" || lapsing.AutoRenew == nil || *lapsing.AutoRenew || lapsing.Severity != "critical" {
+		t.Errorf("first rule = %+v", lapsing)
+	}
+	// Unset conditions stay nil, distinct from an explicit false.
+	if r := cfg.Alerts[1]; r.SecurityLock == nil || *r.SecurityLock || r.AutoRenew != nil {
+		t.Errorf("second rule = %+v", r)
+	}
+}
+
+func TestLoadSettings_DigestPasswordFromEnv(t *testing.T) {
+	writeTestConfig(t, `digest:
+  from: registrar@example.com
+  to: [admin@example.com]
+  smtp:
+    host: mail.example.com
+    port: 587
+    password: from-file
+`)
+	t.Setenv("PORKBUN_SMTP_PASSWORD", "from-env")
+
+	cfg, err := LoadSettings()
+	if err != nil {
+		t.Fatalf("LoadSettings: %v", err)
+	}
+	d := cfg.Digest
+	if d.From != "registrar@example.com" || len(d.To) != 1 || d.SMTP.Host != "mail.example.com" || d.SMTP.Port != 587 {
+		t.Errorf("digest = %+v", d)
+	}
+	if d.SMTP.Password != "from-env" {
+		t.Errorf("SMTP password = %q, want the environment's", d.SMTP.Password)
+	}
+}
@@ -0,0 +1,138 @@
+// Package ddns keeps one A or AAAA record pointed at the current public
+// address, writing only when the address changed.
+package ddns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/bc/porkbun-tui/internal/api"
+)
+
+// Records reads and writes DNS records; *api.Client satisfies it.
+type Records interface {
+	GetDNSRecords(ctx context.Context, domain string) ([]api.DNSRecord, error)
+	CreateDNSRecord(ctx context.Context, domain string, r api.DNSRecord) (string, error)
+	EditDNSRecord(ctx context.Context, domain, id string, r api.DNSRecord) error
+}
+
+// Updater syncs the Type record for Name (relative to the Zone domain,
+// empty for the apex) with the address PublicIP returns.
+type Updater struct {
+	Records  Records
+	PublicIP func(ctx context.Context) (string, error)
+	Zone     string
+	Name     string
+	Type     string // A or AAAA
+	TTL      string // for a created record; empty is Porkbun's default
+	Log      *log.Logger
+}
+
+// Result is what one Sync did.
+type Result struct {
+	IP       string
+	Previous string // the record's content before; empty when created
+	Changed  bool
+	Created  bool
+}
+
+// FQDN is the record's full name.
+func (u *Updater) FQDN() string {
+	if u.Name == "" {
+		return u.Zone
+	}
+	return u.Name + "." + u.Zone
+}
+
+// Sync looks up the public address and the record, and edits or creates
+// the record if they differ. Several records of the type under the name
+// are an error: which one to update is a guess.
+func (u *Updater) Sync(ctx context.Context) (Result, error) {
+	ip, err := u.PublicIP(ctx)
+	if err != nil {
+		return Result{}, fmt.Errorf("looking up the public address: %w", err)
+	}
+	res := Result{IP: ip}
+
+	all, err := u.Records.GetDNSRecords(ctx, u.Zone)
+	if err != nil {
+		return res, fmt.Errorf("reading records for %s: %w", u.Zone, err)
+	}
+	var matching []api.DNSRecord
+	for _, r := range all {
+		if r.Type == u.Type && api.Subdomain(r.Name, u.Zone) == u.Name {
+			matching = append(matching, r)
+		}
+	}
+
+	switch len(matching) {
+	case 0:
+		rec := api.DNSRecord{Name: u.Name, Type: u.Type, Content: ip, TTL: u.TTL}
+		if err := api.ValidateDNSRecord(rec); err != nil {
+			return res, err
+		}
+		if _, err := u.Records.CreateDNSRecord(ctx, u.Zone, rec); err != nil {
+			return res, fmt.Errorf("creating %s %s: %w", u.FQDN(), u.Type, err)
+		}
+		res.Changed, res.Created = true, true
+	case 1:
+		rec := matching[0]
+		res.Previous = rec.Content
+		if strings.EqualFold(rec.Content, ip) {
+			return res, nil
+		}
+		rec.Name, rec.Content = u.Name, ip
+		if err := api.ValidateDNSRecord(rec); err != nil {
+			return res, err
+		}
+		if err := u.Records.EditDNSRecord(ctx, u.Zone, rec.ID, rec); err != nil {
+			return res, fmt.Errorf("updating %s %s: %w", u.FQDN(), u.Type, err)
+		}
+		res.Changed = true
+	default:
+		return res, fmt.Errorf("%s has %d %s records; ddns manages exactly one", u.FQDN(), len(matching), u.Type)
+	}
+	return res, nil
+}
+
+// Run syncs now and then every interval until ctx is done. Failures are
+// logged and retried on the next tick.
+func (u *Updater) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		res, err := u.Sync(ctx)
+		if err != nil && !errors.Is(err, context.Canceled) {
+			u.logf("sync failed: %v", err)
+		} else if err == nil {
+			u.LogResult(res)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// LogResult logs what a Sync did.
+func (u *Updater) LogResult(res Result) {
+	switch {
+	case res.Created:
+		u.logf("created %s %s %s", u.FQDN(), u.Type, res.IP)
+	case res.Changed:
+		u.logf("updated %s %s %s -> %s", u.FQDN(), u.Type, res.Previous, res.IP)
+	default:
+		u.logf("%s %s is current (%s)", u.FQDN(), u.Type, res.IP)
+	}
+}
+
+func (u *Updater) logf(format string, args ...any) {
+	if u.Log != nil {
+		u.Log.Printf(format, args...)
+	}
+}
@@ -0,0 +1,112 @@
+package ddns
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/bc/porkbun-tui/internal/api"
+)
+
+type fakeRecords struct {
+	records []api.DNSRecord
+	writes  []string
+}
+
+func (f *fakeRecords) GetDNSRecords(context.Context, string) ([]api.DNSRecord, error) {
+	return f.records, nil
+}
+
+func (f *fakeRecords) CreateDNSRecord(_ context.Context, domain string, r api.DNSRecord) (string, error) {
+	f.writes = append(f.writes, "create "+r.Name+" "+r.Type+" "+r.Content)
+	return "2", nil
+}
+
+func (f *fakeRecords) EditDNSRecord(_ context.Context, domain, id string, r api.DNSRecord) error {
+	f.writes = append(f.writes, "edit "+id+" "+r.Name+" "+r.Content)
+	return nil
+}
+
+func updater(records *fakeRecords, ip string) *Updater {
+	return &Updater{
+		Records:  records,
+		PublicIP: func(context.Context) (string, error) { return ip, nil },
+		Zone:     "example.com",
+		Name:     "home",
+		Type:     "A",
+	}
+}
+
+func TestSyncLeavesCurrentRecordAlone(t *testing.T) {
+	records := &fakeRecords{records: []api.DNSRecord{
+		{ID: "1", Name: "home.example.com", Type: "A", Content: "198.51.100.7", TTL: "600"},
+		{ID: "3", Name: "home.example.com", Type: "AAAA", Content: "2001:db8::1"},
+	}}
+
+	res, err := updater(records, "198.51.100.7").Sync(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Changed || len(records.writes) != 0 {
+		t.Errorf("unchanged address rewrote the record: %v", records.writes)
+	}
+}
+
+func TestSyncEditsChangedRecord(t *testing.T) {
+	records := &fakeRecords{records: []api.DNSRecord{
+		{ID: "1", Name: "home.example.com", Type: "A", Content: "198.51.100.7", TTL: "600"},
+		{ID: "4", Name: "example.com", Type: "A", Content: "192.0.2.1"},
+	}}
+
+	res, err := updater(records, "203.0.113.9").Sync(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.Changed || res.Previous != "198.51.100.7" {
+		t.Errorf("result = %+v", res)
+	}
+	// The relative name goes back to the API, not the listed full name.
+	if strings.Join(records.writes, ";") != "edit 1 home 203.0.113.9" {
+		t.Errorf("writes = %v", records.writes)
+	}
+}
+
+func TestSyncCreatesMissingRecord(t *testing.T) {
+	records := &fakeRecords{}
+
+	res, err := updater(records, "203.0.113.9").Sync(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.Created || strings.Join(records.writes, ";") != "create home A 203.0.113.9" {
+		t.Errorf("result = %+v, writes = %v", res, records.writes)
+	}
+}
+
+func TestSyncRefusesAmbiguousRecords(t *testing.T) {
+	records := &fakeRecords{records: []api.DNSRecord{
+		{ID: "1", Name: "home.example.com", Type: "A", Content: "198.51.100.7"},
+		{ID: "2", Name: "home.example.com", Type: "A", Content: "198.51.100.8"},
+	}}
+
+	if _, err := updater(records, "203.0.113.9").Sync(context.Background()); err == nil {
+		t.Fatal("two A records accepted")
+	}
+	if len(records.writes) != 0 {
+		t.Errorf("writes = %v", records.writes)
+	}
+}
+
+func TestSyncIPLookupFailureWritesNothing(t *testing.T) {
+	records := &fakeRecords{}
+	u := updater(records, "")
+	u.PublicIP = func(context.Context) (string, error) { return "", errors.New("offline") }
+
+	if _, err := u.Sync(context.Background()); err == nil {
+		t.Fatal("lookup failure not reported")
+	}
+	if len(records.writes) != 0 {
+		t.Errorf("writes = %v", records.writes)
+	}
+}
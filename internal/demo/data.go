@@ -29,6 +29,36 @@ func Domains() []api.Domain {
 	}
 }
 
+// DNSSEC returns the DNSSEC status of the demo domains: a few are signed
+// so the badge and the list filter show both states.
+func DNSSEC() map[string]bool {
+	status := make(map[string]bool)
+	for _, d := range Domains() {
+		status[d.Name] = false
+	}
+	for _, name := range []string{"acmecorp.com", "cloudnative.app", "techbytes.net", "mailservice.email"} {
+		status[name] = true
+	}
+	return status
+}
+
+// Nameservers returns the nameservers of the demo domains, spread over a
+// few providers so the provider column and view have something to group.
+func Nameservers() map[string][]string {
+	porkbun := []string{"maceio.ns.porkbun.com", "curitiba.ns.porkbun.com", "salvador.ns.porkbun.com", "fortaleza.ns.porkbun.com"}
+	ns := make(map[string][]string)
+	for _, d := range Domains() {
+		ns[d.Name] = porkbun
+	}
+	for _, name := range []string{"acmecorp.com", "cloudnative.app", "aistartup.ai"} {
+		ns[name] = []string{"ada.ns.cloudflare.com", "bob.ns.cloudflare.com"}
+	}
+	ns["startupkit.io"] = []string{"ns-1204.awsdns-22.org", "ns-371.awsdns-46.com", "ns-1843.awsdns-38.co.uk", "ns-904.awsdns-49.net"}
+	ns["techbytes.net"] = []string{"ns1.digitalocean.com", "ns2.digitalocean.com", "ns3.digitalocean.com"}
+	ns["mailservice.email"] = []string{"ns1.mailservice.email", "ns2.mailservice.email"}
+	return ns
+}
+
 // takenNames read as registered in demo mode so checks show both outcomes.
 var takenNames = map[string]bool{
 	"google": true, "porkbun": true, "github": true, "apple": true,
@@ -0,0 +1,114 @@
+// Package digest builds the periodic email summary of the portfolio:
+// upcoming expirations, renewal costs by month and TLD, and domains with
+// security flags off. It renders the summary as text and HTML and sends
+// it over SMTP.
+package digest
+
+import (
+	"sort"
+	"time"
+
+	"github.com/bc/porkbun-tui/internal/api"
+	"github.com/bc/porkbun-tui/internal/portfolio"
+)
+
+// MonthsAhead is how many months of renewal costs a digest covers,
+// starting with the current one.
+const MonthsAhead = 12
+
+// Report is everything a digest shows. Text and HTML are both rendered
+// from it.
+type Report struct {
+	Generated time.Time
+	Within    time.Duration
+	Domains   int // in the portfolio
+
+	Expiring []Expiring // within the window, or already expired
+	Months   []portfolio.MonthTotal
+	TLDs     []portfolio.TLDTotal
+	Issues   []Issue
+
+	// YearCost is what renewing the whole portfolio once costs.
+	YearCost float64
+	Unpriced int // domains whose TLD has no pricing
+
+	// Budget flags months whose auto-renew charges exceed it; zero
+	// disables the check.
+	Budget float64
+}
+
+// Expiring is a domain expiring within the report window.
+type Expiring struct {
+	Domain api.Domain
+	Days   int // until expiry; negative once expired
+	Price  float64
+}
+
+// Issue is a domain with at least one security flag off, or one that
+// will lapse in the window because auto-renew is off.
+type Issue struct {
+	Domain   string
+	Problems []string
+}
+
+// Build summarizes domains as of now. within is the expiry window; the
+// lapse warning uses the same window.
+func Build(domains []api.Domain, pricing map[string]api.TLDPricing, now time.Time, within time.Duration) Report {
+	r := Report{Generated: now, Within: within, Domains: len(domains)}
+	horizon := now.Add(within)
+
+	for _, d := range domains {
+		if !d.ExpireDate.After(horizon) {
+			r.Expiring = append(r.Expiring, Expiring{
+				Domain: d,
+				Days:   int(d.ExpireDate.Sub(now).Hours() / 24),
+				Price:  portfolio.RenewalPrice(pricing, d.TLD),
+			})
+		}
+		if problems := issues(d, horizon); len(problems) > 0 {
+			r.Issues = append(r.Issues, Issue{Domain: d.Name, Problems: problems})
+		}
+	}
+	sort.Slice(r.Expiring, func(i, j int) bool {
+		a, b := r.Expiring[i].Domain, r.Expiring[j].Domain
+		if !a.ExpireDate.Equal(b.ExpireDate) {
+			return a.ExpireDate.Before(b.ExpireDate)
+		}
+		return a.Name < b.Name
+	})
+	sort.Slice(r.Issues, func(i, j int) bool {
+		return r.Issues[i].Domain < r.Issues[j].Domain
+	})
+
+	first := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	last := first.AddDate(0, MonthsAhead, 0)
+	for _, m := range portfolio.ExpiringByMonth(domains, pricing) {
+		start := time.Date(m.Year, m.Month, 1, 0, 0, 0, 0, now.Location())
+		if !start.Before(first) && start.Before(last) {
+			r.Months = append(r.Months, m)
+		}
+	}
+
+	r.TLDs = portfolio.CostByTLD(domains, pricing)
+	for _, t := range r.TLDs {
+		r.YearCost += t.TotalCost
+		if t.RenewalPrice == 0 {
+			r.Unpriced += len(t.Domains)
+		}
+	}
+	return r
+}
+
+func issues(d api.Domain, horizon time.Time) []string {
+	var problems []string
+	if !d.AutoRenew && !d.ExpireDate.After(horizon) {
+		problems = append(problems, "auto-renew off, lapses "+d.ExpireDate.Format("2006-01-02"))
+	}
+	if !d.SecurityLock {
+		problems = append(problems, "security lock off")
+	}
+	if !d.WhoisPrivacy {
+		problems = append(problems, "WHOIS privacy off")
+	}
+	return problems
+}
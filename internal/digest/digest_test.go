@@ -0,0 +1,107 @@
+package digest
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bc/porkbun-tui/internal/api"
+)
+
+var now = time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+
+func fixture() ([]api.Domain, map[string]api.TLDPricing) {
+	domains := []api.Domain{
+		{Name: "soon.com", TLD: "com", ExpireDate: now.AddDate(0, 0, 10), AutoRenew: true, SecurityLock: true, WhoisPrivacy: true},
+		{Name: "lapsing.io", TLD: "io", ExpireDate: now.AddDate(0, 0, 40), SecurityLock: true, WhoisPrivacy: true},
+		{Name: "open<b>.com", TLD: "com", ExpireDate: now.AddDate(0, 6, 0), AutoRenew: true},
+		{Name: "far.xyz", TLD: "xyz", ExpireDate: now.AddDate(2, 0, 0), AutoRenew: true, SecurityLock: true, WhoisPrivacy: true},
+	}
+	pricing := map[string]api.TLDPricing{
+		"com": {Renewal: "10.00"},
+		"io":  {Renewal: "30.00"},
+	}
+	return domains, pricing
+}
+
+func TestBuild(t *testing.T) {
+	domains, pricing := fixture()
+
+	r := Build(domains, pricing, now, 60*24*time.Hour)
+
+	if len(r.Expiring) != 2 || r.Expiring[0].Domain.Name != "soon.com" || r.Expiring[1].Domain.Name != "lapsing.io" {
+		t.Fatalf("expiring = %+v", r.Expiring)
+	}
+	if r.Expiring[0].Days != 10 || r.Expiring[1].Price != 30 {
+		t.Errorf("expiring details = %+v", r.Expiring)
+	}
+
+	// far.xyz renews after the 12-month horizon.
+	if len(r.Months) != 3 {
+		t.Errorf("got %d months, want 3 within the horizon", len(r.Months))
+	}
+	if r.YearCost != 50 || r.Unpriced != 1 {
+		t.Errorf("YearCost = %.2f, Unpriced = %d", r.YearCost, r.Unpriced)
+	}
+
+	if len(r.Issues) != 2 {
+		t.Fatalf("issues = %+v", r.Issues)
+	}
+	if r.Issues[0].Domain != "lapsing.io" || !strings.HasPrefix(r.Issues[0].Problems[0], "auto-renew off") {
+		t.Errorf("first issue = %+v", r.Issues[0])
+	}
+	if got := strings.Join(r.Issues[1].Problems, ", "); got != "security lock off, WHOIS privacy off" {
+		t.Errorf("open<b>.com problems = %q", got)
+	}
+}
+
+func TestRenderText(t *testing.T) {
+	domains, pricing := fixture()
+	r := Build(domains, pricing, now, 60*24*time.Hour)
+	r.Budget = 5
+
+	var buf bytes.Buffer
+	if err := RenderText(&buf, r); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"EXPIRING IN THE NEXT 60 DAYS",
+		"soon.com",
+		"in 10d",
+		"1 lapse ($30.00)",
+		"OVER BUDGET ($5.00)",
+		".io",
+		"lapsing.io",
+		"security lock off, WHOIS privacy off",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("text digest missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestRenderHTMLEscapes(t *testing.T) {
+	domains, pricing := fixture()
+	r := Build(domains, pricing, now, 60*24*time.Hour)
+
+	var buf bytes.Buffer
+	if err := RenderHTML(&buf, r); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	if strings.Contains(out, "open<b>.com") || !strings.Contains(out, "open&lt;b&gt;.com") {
+		t.Error("domain name not HTML-escaped")
+	}
+	if !strings.Contains(out, "<title>Domain digest: 2 domains expiring in the next 60 days</title>") {
+		t.Errorf("title missing or wrong:\n%s", out)
+	}
+}
+
+func TestSubject(t *testing.T) {
+	r := Report{Within: 30 * 24 * time.Hour}
+	if got := r.Subject(); got != "Domain digest: nothing expiring in the next 30 days" {
+		t.Errorf("Subject = %q", got)
+	}
+}
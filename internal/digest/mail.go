@@ -0,0 +1,214 @@
+package digest
+
+import (
+	"bytes"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TLS modes for Mailer.TLS.
+const (
+	TLSStartTLS = "starttls" // plain connect, then STARTTLS; required
+	TLSImplicit = "tls"      // TLS from the first byte (SMTPS)
+	TLSNone     = "none"     // no encryption, for a relay on localhost
+)
+
+// Message is a digest email with text and HTML alternatives.
+type Message struct {
+	From    string
+	To      []string
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// Bytes formats the message as multipart/alternative MIME, dated now.
+func (m Message) Bytes(now time.Time) ([]byte, error) {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	for _, part := range []struct{ contentType, content string }{
+		{"text/plain; charset=utf-8", m.Text},
+		{"text/html; charset=utf-8", m.HTML},
+	} {
+		pw, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		qp := quotedprintable.NewWriter(pw)
+		if _, err := qp.Write([]byte(part.content)); err != nil {
+			return nil, err
+		}
+		if err := qp.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+
+	var msg bytes.Buffer
+	header := func(k, v string) { fmt.Fprintf(&msg, "%s: %s\r\n", k, v) }
+	header("From", m.From)
+	header("To", strings.Join(m.To, ", "))
+	header("Subject", mime.QEncoding.Encode("utf-8", m.Subject))
+	header("Date", now.Format(time.RFC1123Z))
+	header("Message-ID", fmt.Sprintf("<digest-%d@porkbun-tui>", now.UnixNano()))
+	header("MIME-Version", "1.0")
+	header("Content-Type", "multipart/alternative; boundary="+mw.Boundary())
+	msg.WriteString("\r\n")
+	msg.Write(body.Bytes())
+	return msg.Bytes(), nil
+}
+
+// Mailer sends messages through an SMTP server, authenticating with PLAIN
+// when Username is set. net/smtp only sends credentials over TLS or to
+// localhost.
+type Mailer struct {
+	Host     string
+	Port     int // 0: 587 for starttls, 465 for tls, 25 for none
+	Username string
+	Password string
+	TLS      string // TLSStartTLS (the default), TLSImplicit or TLSNone
+
+	// TLSConfig overrides the default of verifying Host's certificate.
+	TLSConfig *tls.Config
+	Timeout   time.Duration // dial timeout; 0 is 30s
+}
+
+// Validate checks the mode and that a host is set.
+func (m *Mailer) Validate() error {
+	if m.Host == "" {
+		return errors.New("no SMTP host configured")
+	}
+	switch m.TLS {
+	case "", TLSStartTLS, TLSImplicit, TLSNone:
+		return nil
+	}
+	return fmt.Errorf("unknown SMTP tls mode %q (want starttls, tls or none)", m.TLS)
+}
+
+func (m *Mailer) addr() string {
+	port := m.Port
+	if port == 0 {
+		switch m.TLS {
+		case TLSImplicit:
+			port = 465
+		case TLSNone:
+			port = 25
+		default:
+			port = 587
+		}
+	}
+	return net.JoinHostPort(m.Host, strconv.Itoa(port))
+}
+
+func (m *Mailer) tlsConfig() *tls.Config {
+	if m.TLSConfig != nil {
+		return m.TLSConfig
+	}
+	return &tls.Config{ServerName: m.Host}
+}
+
+// Send delivers msg to every recipient in msg.To.
+func (m *Mailer) Send(msg Message, now time.Time) error {
+	if err := m.Validate(); err != nil {
+		return err
+	}
+	if msg.From == "" || len(msg.To) == 0 {
+		return errors.New("digest needs a from address and at least one recipient")
+	}
+	from, err := envelope(msg.From)
+	if err != nil {
+		return err
+	}
+	rcpts := make([]string, len(msg.To))
+	for i, to := range msg.To {
+		if rcpts[i], err = envelope(to); err != nil {
+			return err
+		}
+	}
+	data, err := msg.Bytes(now)
+	if err != nil {
+		return err
+	}
+
+	timeout := m.Timeout
+	if timeout == 0 {
+		timeout = 30 * time.Second
+	}
+	dialer := &net.Dialer{Timeout: timeout}
+	var conn net.Conn
+	if m.TLS == TLSImplicit {
+		conn, err = tls.DialWithDialer(dialer, "tcp", m.addr(), m.tlsConfig())
+	} else {
+		conn, err = dialer.Dial("tcp", m.addr())
+	}
+	if err != nil {
+		return err
+	}
+
+	c, err := smtp.NewClient(conn, m.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer c.Close()
+
+	if m.TLS == "" || m.TLS == TLSStartTLS {
+		if ok, _ := c.Extension("STARTTLS"); !ok {
+			return fmt.Errorf("%s does not offer STARTTLS; set smtp.tls to tls or none", m.Host)
+		}
+		if err := c.StartTLS(m.tlsConfig()); err != nil {
+			return fmt.Errorf("STARTTLS: %w", err)
+		}
+	}
+	if m.Username != "" {
+		if err := c.Auth(smtp.PlainAuth("", m.Username, m.Password, m.Host)); err != nil {
+			return fmt.Errorf("SMTP auth: %w", err)
+		}
+	}
+
+	if err := c.Mail(from); err != nil {
+		return err
+	}
+	for _, to := range rcpts {
+		if err := c.Rcpt(to); err != nil {
+			return fmt.Errorf("recipient %s: %w", to, err)
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(data); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+// envelope is the bare address of a header address such as
+// "Registrar <admin@example.com>".
+func envelope(addr string) (string, error) {
+	a, err := mail.ParseAddress(addr)
+	if err != nil {
+		return "", fmt.Errorf("address %q: %w", addr, err)
+	}
+	return a.Address, nil
+}
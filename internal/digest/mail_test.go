@@ -0,0 +1,275 @@
+package digest
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"io"
+	"math/big"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// smtpStandIn is a minimal SMTP server for one session. It offers
+// STARTTLS when tls is set and records what the client sent.
+type smtpStandIn struct {
+	ln  net.Listener
+	tls *tls.Config
+
+	done   chan struct{}
+	secure bool
+	auth   string // decoded AUTH PLAIN response
+	from   string
+	rcpts  []string
+	data   []byte
+	cmds   []string
+}
+
+func newSMTPStandIn(t *testing.T, tlsConfig *tls.Config) *smtpStandIn {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &smtpStandIn{ln: ln, tls: tlsConfig, done: make(chan struct{})}
+	t.Cleanup(func() { ln.Close() })
+	go s.serve()
+	return s
+}
+
+func (s *smtpStandIn) port() int {
+	return s.ln.Addr().(*net.TCPAddr).Port
+}
+
+// wait blocks until the session ends.
+func (s *smtpStandIn) wait(t *testing.T) {
+	t.Helper()
+	select {
+	case <-s.done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("SMTP session did not finish")
+	}
+}
+
+func (s *smtpStandIn) serve() {
+	defer close(s.done)
+	conn, err := s.ln.Accept()
+	if err != nil {
+		return
+	}
+	defer func() { conn.Close() }()
+
+	tp := textproto.NewConn(conn)
+	tp.PrintfLine("220 localhost ESMTP stand-in")
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+		verb = strings.ToUpper(verb)
+		s.cmds = append(s.cmds, verb)
+
+		switch verb {
+		case "EHLO", "HELO":
+			ext := []string{"localhost"}
+			if s.tls != nil && !s.secure {
+				ext = append(ext, "STARTTLS")
+			}
+			if s.secure {
+				ext = append(ext, "AUTH PLAIN")
+			}
+			ext = append(ext, "8BITMIME")
+			for i, e := range ext {
+				sep := "-"
+				if i == len(ext)-1 {
+					sep = " "
+				}
+				tp.PrintfLine("250%s%s", sep, e)
+			}
+		case "STARTTLS":
+			tp.PrintfLine("220 ready")
+			tlsConn := tls.Server(conn, s.tls)
+			if err := tlsConn.Handshake(); err != nil {
+				return
+			}
+			conn, s.secure = tlsConn, true
+			tp = textproto.NewConn(conn)
+		case "AUTH":
+			_, resp, _ := strings.Cut(arg, " ")
+			decoded, _ := base64.StdEncoding.DecodeString(resp)
+			s.auth = string(decoded)
+			tp.PrintfLine("235 accepted")
+		case "MAIL":
+			s.from = pathArg(arg)
+			tp.PrintfLine("250 ok")
+		case "RCPT":
+			s.rcpts = append(s.rcpts, pathArg(arg))
+			tp.PrintfLine("250 ok")
+		case "DATA":
+			tp.PrintfLine("354 go ahead")
+			s.data, _ = tp.ReadDotBytes()
+			tp.PrintfLine("250 queued")
+		case "QUIT":
+			tp.PrintfLine("221 bye")
+			return
+		default:
+			tp.PrintfLine("502 not implemented")
+		}
+	}
+}
+
+// pathArg is the address in "FROM:<a@b> BODY=8BITMIME".
+func pathArg(arg string) string {
+	_, path, _ := strings.Cut(arg, "<")
+	path, _, _ = strings.Cut(path, ">")
+	return path
+}
+
+// selfSigned returns server and client TLS configs for 127.0.0.1.
+func selfSigned(t *testing.T) (server, client *tls.Config) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+	server = &tls.Config{Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}}}
+	client = &tls.Config{RootCAs: pool, ServerName: "127.0.0.1"}
+	return server, client
+}
+
+func testMessage() Message {
+	return Message{
+		From:    "Registrar <registrar@example.com>",
+		To:      []string{"admin@example.com", "Ops <ops@example.com>"},
+		Subject: "Domain digest: 2 domains expiring — soon",
+		Text:    "plain body",
+		HTML:    "<p>html body</p>",
+	}
+}
+
+func TestSendStartTLSWithAuth(t *testing.T) {
+	serverTLS, clientTLS := selfSigned(t)
+	srv := newSMTPStandIn(t, serverTLS)
+
+	m := &Mailer{Host: "127.0.0.1", Port: srv.port(), Username: "user", Password: "hunter2", TLSConfig: clientTLS}
+	if err := m.Send(testMessage(), now); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	srv.wait(t)
+
+	if !srv.secure {
+		t.Error("message sent without STARTTLS")
+	}
+	if srv.auth != "\x00user\x00hunter2" {
+		t.Errorf("AUTH PLAIN = %q", srv.auth)
+	}
+	if srv.from != "registrar@example.com" {
+		t.Errorf("MAIL FROM = %q, want the bare address", srv.from)
+	}
+	if strings.Join(srv.rcpts, ",") != "admin@example.com,ops@example.com" {
+		t.Errorf("RCPT TO = %v", srv.rcpts)
+	}
+
+	msg, err := mail.ReadMessage(strings.NewReader(string(srv.data)))
+	if err != nil {
+		t.Fatalf("parsing delivered message: %v", err)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil || subject != testMessage().Subject {
+		t.Errorf("Subject = %q, %v", subject, err)
+	}
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/alternative" {
+		t.Fatalf("Content-Type = %q, %v", mediaType, err)
+	}
+	mr := multipart.NewReader(msg.Body, params["boundary"])
+	var parts []string
+	for {
+		p, err := mr.NextPart() // decodes quoted-printable
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(p)
+		parts = append(parts, p.Header.Get("Content-Type")+": "+string(body))
+	}
+	want := []string{"text/plain; charset=utf-8: plain body", "text/html; charset=utf-8: <p>html body</p>"}
+	if strings.Join(parts, "|") != strings.Join(want, "|") {
+		t.Errorf("parts = %q", parts)
+	}
+}
+
+func TestSendRequiresStartTLS(t *testing.T) {
+	srv := newSMTPStandIn(t, nil) // offers no STARTTLS
+
+	m := &Mailer{Host: "127.0.0.1", Port: srv.port(), Username: "user", Password: "hunter2"}
+	err := m.Send(testMessage(), now)
+	if err == nil || !strings.Contains(err.Error(), "STARTTLS") {
+		t.Fatalf("Send = %v, want a STARTTLS error", err)
+	}
+	srv.ln.Close()
+	srv.wait(t)
+	for _, cmd := range srv.cmds {
+		if cmd == "AUTH" || cmd == "MAIL" {
+			t.Errorf("client sent %s over plaintext", cmd)
+		}
+	}
+}
+
+func TestSendPlainRelay(t *testing.T) {
+	srv := newSMTPStandIn(t, nil)
+
+	m := &Mailer{Host: "127.0.0.1", Port: srv.port(), TLS: TLSNone}
+	if err := m.Send(testMessage(), now); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	srv.wait(t)
+	if srv.auth != "" || len(srv.data) == 0 {
+		t.Errorf("auth = %q, %d bytes of data", srv.auth, len(srv.data))
+	}
+}
+
+func TestMailerValidate(t *testing.T) {
+	if err := (&Mailer{}).Validate(); err == nil {
+		t.Error("missing host accepted")
+	}
+	if err := (&Mailer{Host: "h", TLS: "ssl"}).Validate(); err == nil {
+		t.Error("unknown TLS mode accepted")
+	}
+	for mode, port := range map[string]int{"": 587, TLSStartTLS: 587, TLSImplicit: 465, TLSNone: 25} {
+		if got := (&Mailer{Host: "h", TLS: mode}).addr(); got != "h:"+strconv.Itoa(port) {
+			t.Errorf("default addr for %q = %s", mode, got)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package digest
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// Subject is the default subject line.
+func (r Report) Subject() string {
+	switch n := len(r.Expiring); n {
+	case 0:
+		return fmt.Sprintf("Domain digest: nothing expiring in the next %d days", r.WithinDays())
+	case 1:
+		return fmt.Sprintf("Domain digest: 1 domain expiring in the next %d days", r.WithinDays())
+	default:
+		return fmt.Sprintf("Domain digest: %d domains expiring in the next %d days", n, r.WithinDays())
+	}
+}
+
+// WithinDays is the expiry window in whole days.
+func (r Report) WithinDays() int {
+	return int(r.Within.Hours() / 24)
+}
+
+func money(v float64) string {
+	if v == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("$%.2f", v)
+}
+
+func renewal(autoRenew bool) string {
+	if autoRenew {
+		return "auto-renew"
+	}
+	return "manual"
+}
+
+// RenderText writes the plain-text digest.
+func RenderText(w io.Writer, r Report) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Domain digest for %s\n", r.Generated.Format("Monday, 2 January 2006"))
+	fmt.Fprintf(&b, "%d domains, renewing all of them costs %s a year", r.Domains, money(r.YearCost))
+	if r.Unpriced > 0 {
+		fmt.Fprintf(&b, " (%d unpriced)", r.Unpriced)
+	}
+	b.WriteString("\n\n")
+
+	tw := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(&b, "EXPIRING IN THE NEXT %d DAYS\n", r.WithinDays())
+	if len(r.Expiring) == 0 {
+		b.WriteString("  Nothing.\n")
+	}
+	for _, e := range r.Expiring {
+		fmt.Fprintf(tw, "  %s\t%s\t%s\t%s\t%s\n", e.Domain.ExpireDate.Format("2006-01-02"), e.Domain.Name,
+			days(e.Days), renewal(e.Domain.AutoRenew), money(e.Price))
+	}
+	tw.Flush()
+
+	b.WriteString("\nRENEWAL COSTS BY MONTH\n")
+	if len(r.Months) == 0 {
+		fmt.Fprintf(&b, "  No renewals in the next %d months.\n", MonthsAhead)
+	}
+	for _, m := range r.Months {
+		fmt.Fprintf(tw, "  %s %d\t%s\t$%.2f charged", m.Month.String()[:3], m.Year, plural(len(m.Domains), "domain"), m.ChargeCost)
+		if m.LapseCount > 0 {
+			fmt.Fprintf(tw, "\t%d lapse (%s)", m.LapseCount, money(m.LapseCost))
+		} else {
+			fmt.Fprint(tw, "\t")
+		}
+		if m.OverBudget(r.Budget) {
+			fmt.Fprintf(tw, "\tOVER BUDGET (%s)", money(r.Budget))
+		}
+		fmt.Fprintln(tw)
+	}
+	tw.Flush()
+
+	b.WriteString("\nYEARLY RENEWAL COST BY TLD\n")
+	for _, t := range r.TLDs {
+		fmt.Fprintf(tw, "  .%s\t%d ×\t%s\t= %s\n", t.TLD, len(t.Domains), money(t.RenewalPrice), money(t.TotalCost))
+	}
+	tw.Flush()
+
+	b.WriteString("\nSECURITY ISSUES\n")
+	if len(r.Issues) == 0 {
+		b.WriteString("  None.\n")
+	}
+	for _, is := range r.Issues {
+		fmt.Fprintf(tw, "  %s\t%s\n", is.Domain, strings.Join(is.Problems, ", "))
+	}
+	tw.Flush()
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func plural(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
+func days(n int) string {
+	switch {
+	case n < 0:
+		return fmt.Sprintf("expired %dd ago", -n)
+	case n == 0:
+		return "today"
+	}
+	return fmt.Sprintf("in %dd", n)
+}
+
+var htmlTemplate = template.Must(template.New("digest").Funcs(template.FuncMap{
+	"money":   money,
+	"days":    days,
+	"renewal": renewal,
+	"join":    strings.Join,
+	"month":   func(m interface{ String() string }) string { return m.String()[:3] },
+}).Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>{{.Subject}}</title></head>
+<body style="font-family: sans-serif; color: #222;">
+<h2 style="margin-bottom: 0;">Domain digest</h2>
+<p style="color: #666; margin-top: 4px;">{{.Generated.Format "Monday, 2 January 2006"}} &middot;
+{{.Domains}} domains &middot; {{money .YearCost}} a year to renew{{if .Unpriced}} ({{.Unpriced}} unpriced){{end}}</p>
+
+<h3>Expiring in the next {{.WithinDays}} days</h3>
+{{- if .Expiring}}
+<table cellpadding="4" cellspacing="0" border="1" style="border-collapse: collapse;">
+<tr><th align="left">Expires</th><th align="left">Domain</th><th align="left">When</th><th align="left">Renewal</th><th align="right">Price</th></tr>
+{{- range .Expiring}}
+<tr{{if not .Domain.AutoRenew}} style="background: #fde8e8;"{{end}}><td>{{.Domain.ExpireDate.Format "2006-01-02"}}</td><td>{{.Domain.Name}}</td><td>{{days .Days}}</td><td>{{renewal .Domain.AutoRenew}}</td><td align="right">{{money .Price}}</td></tr>
+{{- end}}
+</table>
+{{- else}}
+<p>Nothing.</p>
+{{- end}}
+
+<h3>Renewal costs by month</h3>
+{{- if .Months}}
+<table cellpadding="4" cellspacing="0" border="1" style="border-collapse: collapse;">
+<tr><th align="left">Month</th><th align="right">Domains</th><th align="right">Charged</th><th align="right">Lapsing</th></tr>
+{{- $budget := .Budget}}
+{{- range .Months}}
+<tr{{if .OverBudget $budget}} style="background: #fde8e8;"{{end}}><td>{{month .Month}} {{.Year}}</td><td align="right">{{len .Domains}}</td><td align="right">{{money .ChargeCost}}</td><td align="right">{{if .LapseCount}}{{.LapseCount}} ({{money .LapseCost}}){{else}}-{{end}}</td></tr>
+{{- end}}
+</table>
+{{- else}}
+<p>No renewals in the next 12 months.</p>
+{{- end}}
+
+<h3>Yearly renewal cost by TLD</h3>
+<table cellpadding="4" cellspacing="0" border="1" style="border-collapse: collapse;">
+<tr><th align="left">TLD</th><th align="right">Domains</th><th align="right">Price</th><th align="right">Total</th></tr>
+{{- range .TLDs}}
+<tr><td>.{{.TLD}}</td><td align="right">{{len .Domains}}</td><td align="right">{{money .RenewalPrice}}</td><td align="right">{{money .TotalCost}}</td></tr>
+{{- end}}
+</table>
+
+<h3>Security issues</h3>
+{{- if .Issues}}
+<ul>
+{{- range .Issues}}
+<li><b>{{.Domain}}</b>: {{join .Problems ", "}}</li>
+{{- end}}
+</ul>
+{{- else}}
+<p>None.</p>
+{{- end}}
+</body>
+</html>
+`))
+
+// RenderHTML writes the HTML digest.
+func RenderHTML(w io.Writer, r Report) error {
+	return htmlTemplate.Execute(w, r)
+}
@@ -0,0 +1,102 @@
+// Package dnsprovider tells which DNS provider hosts a domain from its
+// nameservers, using a built-in pattern table and the rules in config.yaml.
+package dnsprovider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bc/porkbun-tui/internal/config"
+)
+
+// Names for domains no single rule covers.
+const (
+	// SelfHosted is a domain served by nameservers inside itself, like
+	// ns1.example.com for example.com.
+	SelfHosted = "Self-hosted"
+	// Mixed is a domain whose nameservers belong to different providers.
+	Mixed = "Mixed"
+	// Other is a domain whose nameservers match no rule.
+	Other = "Other"
+)
+
+// Rule names the provider of every nameserver containing one of Patterns.
+// Patterns are lower case.
+type Rule struct {
+	Name     string
+	Patterns []string
+}
+
+// Builtin are the rules available without any configuration.
+var Builtin = []Rule{
+	{Name: "Porkbun", Patterns: []string{"porkbun.com"}},
+	{Name: "Cloudflare", Patterns: []string{"ns.cloudflare.com"}},
+	{Name: "Route 53", Patterns: []string{"awsdns"}},
+	{Name: "Google Cloud DNS", Patterns: []string{"googledomains.com"}},
+	{Name: "Azure", Patterns: []string{"azure-dns."}},
+	{Name: "NS1", Patterns: []string{"nsone.net"}},
+	{Name: "DigitalOcean", Patterns: []string{"digitalocean.com"}},
+}
+
+// Detect names the provider hosting domain's DNS, trying rules in order
+// for each nameserver. It returns "" when there are no nameservers.
+func Detect(rules []Rule, domain string, nameservers []string) string {
+	domain = normalize(domain)
+	provider := ""
+	for _, ns := range nameservers {
+		ns = normalize(ns)
+		if ns == "" {
+			continue
+		}
+		name := classify(rules, domain, ns)
+		switch provider {
+		case "":
+			provider = name
+		case name:
+		default:
+			return Mixed
+		}
+	}
+	return provider
+}
+
+func classify(rules []Rule, domain, ns string) string {
+	if domain != "" && (ns == domain || strings.HasSuffix(ns, "."+domain)) {
+		return SelfHosted
+	}
+	for _, r := range rules {
+		for _, p := range r.Patterns {
+			if strings.Contains(ns, p) {
+				return r.Name
+			}
+		}
+	}
+	return Other
+}
+
+func normalize(host string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(host)), ".")
+}
+
+// Compile validates the config rules, naming the first bad one, and
+// returns them ahead of the built-in ones so they can override them.
+func Compile(raw []config.DNSProvider) ([]Rule, error) {
+	var rules []Rule
+	for i, p := range raw {
+		name := strings.TrimSpace(p.Name)
+		if name == "" {
+			return nil, fmt.Errorf("dns provider %d: a provider needs a name", i+1)
+		}
+		var patterns []string
+		for _, pat := range p.Patterns {
+			if pat = strings.ToLower(strings.TrimSpace(pat)); pat != "" {
+				patterns = append(patterns, pat)
+			}
+		}
+		if len(patterns) == 0 {
+			return nil, fmt.Errorf("dns provider %d: %s: needs at least one pattern", i+1, name)
+		}
+		rules = append(rules, Rule{Name: name, Patterns: patterns})
+	}
+	return append(rules, Builtin...), nil
+}
@@ -0,0 +1,83 @@
+package dnsprovider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bc/porkbun-tui/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+func TestDetectBuiltinProviders(t *testing.T) {
+	for _, tc := range []struct {
+		domain string
+		ns     []string
+		want   string
+	}{
+		{"example.com", []string{"maceio.ns.porkbun.com", "curitiba.ns.porkbun.com"}, "Porkbun"},
+		{"example.com", []string{"Ada.NS.Cloudflare.com.", "bob.ns.cloudflare.com"}, "Cloudflare"},
+		{"example.com", []string{"ns-1234.awsdns-12.org", "ns-567.awsdns-34.com"}, "Route 53"},
+		{"example.com", []string{"ns-cloud-a1.googledomains.com"}, "Google Cloud DNS"},
+		{"example.com", []string{"ns1-01.azure-dns.com", "ns2-01.azure-dns.net"}, "Azure"},
+		{"example.com", []string{"dns1.p01.nsone.net"}, "NS1"},
+		{"example.com", []string{"ns1.digitalocean.com"}, "DigitalOcean"},
+		{"example.com", []string{"ns1.example.com", "ns2.example.com"}, SelfHosted},
+		{"example.com", []string{"ns1.hoster.example"}, Other},
+		{"example.com", []string{"ns1.example.com", "ada.ns.cloudflare.com"}, Mixed},
+		{"example.com", nil, ""},
+	} {
+		if got := Detect(Builtin, tc.domain, tc.ns); got != tc.want {
+			t.Errorf("Detect(%s, %v) = %q, want %q", tc.domain, tc.ns, got, tc.want)
+		}
+	}
+}
+
+func TestDetectSelfHostedNeedsTheDomainItself(t *testing.T) {
+	// notexample.com's nameservers aren't inside example.com.
+	if got := Detect(Builtin, "example.com", []string{"ns1.notexample.com"}); got != Other {
+		t.Errorf("Detect = %q, want %q", got, Other)
+	}
+}
+
+func TestCompilePutsConfigRulesFirst(t *testing.T) {
+	var cfg config.Config
+	err := yaml.Unmarshal([]byte(`
+dns_providers:
+  - name: Hetzner
+    patterns: [ns.hetzner.com, " Hydrogen.NS.Hetzner.com "]
+  - name: Our Cloudflare
+    patterns: [ns.cloudflare.com]
+`), &cfg)
+	if err != nil {
+		t.Fatalf("yaml: %v", err)
+	}
+
+	rules, err := Compile(cfg.DNSProviders)
+	if err != nil {
+		t.Fatalf("Compile: %v", err)
+	}
+	if len(rules) != len(Builtin)+2 {
+		t.Fatalf("got %d rules, want the two config ones and the built-in ones", len(rules))
+	}
+	if got := strings.Join(rules[0].Patterns, ","); got != "ns.hetzner.com,hydrogen.ns.hetzner.com" {
+		t.Errorf("patterns = %s, want them trimmed and lower case", got)
+	}
+	if got := Detect(rules, "example.com", []string{"ada.ns.cloudflare.com"}); got != "Our Cloudflare" {
+		t.Errorf("Detect = %q, want the config rule to win", got)
+	}
+}
+
+func TestCompileRejectsBadRules(t *testing.T) {
+	for _, tc := range []struct {
+		raw  config.DNSProvider
+		want string
+	}{
+		{config.DNSProvider{Patterns: []string{"ns.example.net"}}, "needs a name"},
+		{config.DNSProvider{Name: "empty", Patterns: []string{" "}}, "at least one pattern"},
+	} {
+		_, err := Compile([]config.DNSProvider{tc.raw})
+		if err == nil || !strings.Contains(err.Error(), tc.want) || !strings.Contains(err.Error(), "dns provider 1") {
+			t.Errorf("Compile(%+v) = %v, want an error about %q", tc.raw, err, tc.want)
+		}
+	}
+}
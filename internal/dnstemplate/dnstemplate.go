@@ -0,0 +1,93 @@
+// Package dnstemplate holds named sets of DNS records that can be applied
+// to many domains at once: the built-in ones and those in config.yaml.
+package dnstemplate
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/bc/porkbun-tui/internal/api"
+	"github.com/bc/porkbun-tui/internal/config"
+)
+
+// Template is a named set of records. Record names are relative to the
+// domain; {domain} in content is replaced by For.
+type Template struct {
+	Name    string
+	Records []api.DNSRecord
+}
+
+// Builtin are the templates available without any configuration.
+var Builtin = []Template{
+	{
+		// Parked domains are a favourite for spoofing; this tells
+		// receivers that no mail ever comes from them.
+		Name: "No mail (SPF -all, DMARC reject)",
+		Records: []api.DNSRecord{
+			{Type: "TXT", Content: "v=spf1 -all"},
+			{Type: "TXT", Name: "_dmarc", Content: "v=DMARC1; p=reject;"},
+		},
+	},
+	{
+		Name: "Porkbun parking",
+		Records: []api.DNSRecord{
+			{Type: "ALIAS", Content: "pixie.porkbun.com"},
+			{Type: "CNAME", Name: "*", Content: "pixie.porkbun.com"},
+		},
+	},
+}
+
+// Compile validates the config templates, naming the first bad one, and
+// returns them after the built-in ones.
+func Compile(raw []config.DNSTemplate) ([]Template, error) {
+	templates := append([]Template(nil), Builtin...)
+	for i, t := range raw {
+		tmpl, err := compile(t)
+		if err != nil {
+			return nil, fmt.Errorf("dns template %d: %w", i+1, err)
+		}
+		templates = append(templates, tmpl)
+	}
+	return templates, nil
+}
+
+func compile(t config.DNSTemplate) (Template, error) {
+	if strings.TrimSpace(t.Name) == "" {
+		return Template{}, errors.New("a template needs a name")
+	}
+	if len(t.Records) == 0 {
+		return Template{}, fmt.Errorf("%s: a template needs at least one record", t.Name)
+	}
+	tmpl := Template{Name: t.Name}
+	for _, r := range t.Records {
+		rec := api.DNSRecord{
+			Type:     strings.ToUpper(r.Type),
+			Name:     r.Name,
+			Content:  r.Content,
+			TTL:      r.TTL,
+			Priority: r.Priority,
+		}
+		// Validate with a stand-in domain: {domain} has to expand to
+		// something the record type accepts.
+		if err := api.ValidateDNSRecord(expand(rec, "example.com")); err != nil {
+			return Template{}, fmt.Errorf("%s: %w", t.Name, err)
+		}
+		tmpl.Records = append(tmpl.Records, rec)
+	}
+	return tmpl, nil
+}
+
+// For returns the template's records for domain.
+func (t Template) For(domain string) []api.DNSRecord {
+	records := make([]api.DNSRecord, len(t.Records))
+	for i, r := range t.Records {
+		records[i] = expand(r, domain)
+	}
+	return records
+}
+
+func expand(r api.DNSRecord, domain string) api.DNSRecord {
+	r.Content = strings.ReplaceAll(r.Content, "{domain}", domain)
+	return r
+}
@@ -0,0 +1,61 @@
+package dnstemplate
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bc/porkbun-tui/internal/api"
+	"github.com/bc/porkbun-tui/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+func TestBuiltinTemplatesAreValid(t *testing.T) {
+	for _, tmpl := range Builtin {
+		for _, r := range tmpl.For("example.com") {
+			if err := api.ValidateDNSRecord(r); err != nil {
+				t.Errorf("%s: %v", tmpl.Name, err)
+			}
+		}
+	}
+}
+
+func TestCompileExpandsDomain(t *testing.T) {
+	var cfg config.Config
+	err := yaml.Unmarshal([]byte(`
+dns_templates:
+  - name: mail
+    records:
+      - type: mx
+        content: mail.{domain}
+        priority: 10
+        ttl: 600
+`), &cfg)
+	if err != nil {
+		t.Fatalf("yaml: %v", err)
+	}
+
+	templates, err := Compile(cfg.DNSTemplates)
+	if err != nil {
+		t.Fatalf("Compile: %v", err)
+	}
+	if len(templates) != len(Builtin)+1 {
+		t.Fatalf("got %d templates, want the built-ins and mail", len(templates))
+	}
+	got := templates[len(templates)-1].For("parked.com")
+	want := api.DNSRecord{Type: "MX", Content: "mail.parked.com", TTL: "600", Priority: "10"}
+	if len(got) != 1 || got[0] != want {
+		t.Errorf("records = %+v, want [%+v]", got, want)
+	}
+}
+
+func TestCompileRejectsBadRecords(t *testing.T) {
+	for name, raw := range map[string]config.DNSTemplate{
+		"no name":    {Records: []config.DNSTemplateRecord{{Type: "TXT", Content: "x"}}},
+		"no records": {Name: "empty"},
+		"bad record": {Name: "bad", Records: []config.DNSTemplateRecord{{Type: "A", Content: "not-an-ip"}}},
+	} {
+		if _, err := Compile([]config.DNSTemplate{raw}); err == nil || !strings.Contains(err.Error(), "dns template 1") {
+			t.Errorf("%s: err = %v, want a dns template 1 error", name, err)
+		}
+	}
+}
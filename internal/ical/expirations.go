@@ -0,0 +1,66 @@
+package ical
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/bc/porkbun-tui/internal/api"
+	"github.com/bc/porkbun-tui/internal/portfolio"
+)
+
+// DefaultReminders are the alarm offsets, in days, used when none are
+// configured.
+var DefaultReminders = []int{30, 7, 1}
+
+// ExpirationUID is the event UID for a domain's expiration. It depends on
+// the domain name only, so a renewal moves the existing event on re-import
+// instead of adding a second one.
+func ExpirationUID(domain string) string {
+	return "expire-" + domain + "@porkbun-tui"
+}
+
+// Expirations builds one all-day event per domain on its expiration date.
+// A nil reminders slice means DefaultReminders; an empty one means no
+// alarms.
+func Expirations(domains []api.Domain, pricing map[string]api.TLDPricing, reminders []int) Calendar {
+	if reminders == nil {
+		reminders = DefaultReminders
+	}
+
+	sorted := make([]api.Domain, len(domains))
+	copy(sorted, domains)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if !sorted[i].ExpireDate.Equal(sorted[j].ExpireDate) {
+			return sorted[i].ExpireDate.Before(sorted[j].ExpireDate)
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	cal := Calendar{Name: "Domain expirations"}
+	for _, d := range sorted {
+		summary := d.Name + " expires"
+		if d.AutoRenew {
+			summary = d.Name + " auto-renews"
+		}
+		cal.Events = append(cal.Events, Event{
+			UID:         ExpirationUID(d.Name),
+			Date:        d.ExpireDate,
+			Summary:     summary,
+			Description: expirationDescription(d, portfolio.RenewalPrice(pricing, d.TLD)),
+			Reminders:   reminders,
+		})
+	}
+	return cal
+}
+
+func expirationDescription(d api.Domain, price float64) string {
+	status := "Auto-renew: off (the domain lapses unless renewed by hand)"
+	if d.AutoRenew {
+		status = "Auto-renew: on"
+	}
+	renewal := "Renewal price: unknown"
+	if price > 0 {
+		renewal = fmt.Sprintf("Renewal price: $%.2f", price)
+	}
+	return fmt.Sprintf("%s\n%s\nExpires: %s", status, renewal, d.ExpireDate.Format("Jan 2, 2006"))
+}
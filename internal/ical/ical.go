@@ -0,0 +1,110 @@
+// Package ical writes RFC 5545 iCalendar files of all-day events.
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ProdID identifies the producer in every calendar written.
+const ProdID = "-//porkbun-tui//Domain Expirations//EN"
+
+// maxLineOctets is the RFC 5545 content line limit, CRLF excluded.
+const maxLineOctets = 75
+
+// Event is one all-day event. Reminders are display alarms, in whole days
+// before the event.
+type Event struct {
+	UID         string
+	Date        time.Time
+	Summary     string
+	Description string
+	Reminders   []int
+}
+
+// Calendar is a VCALENDAR with a display name.
+type Calendar struct {
+	Name   string
+	Events []Event
+}
+
+// Write serializes cal. stamp is the DTSTAMP of every event, normally the
+// time of the export.
+func Write(w io.Writer, cal Calendar, stamp time.Time) error {
+	bw := bufio.NewWriter(w)
+	line := func(s string) {
+		writeFolded(bw, s)
+	}
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:" + ProdID)
+	line("CALSCALE:GREGORIAN")
+	line("METHOD:PUBLISH")
+	if cal.Name != "" {
+		line("X-WR-CALNAME:" + escapeText(cal.Name))
+	}
+
+	dtstamp := stamp.UTC().Format("20060102T150405Z")
+	for _, e := range cal.Events {
+		// All-day events: DTEND is exclusive, so the next day.
+		start := time.Date(e.Date.Year(), e.Date.Month(), e.Date.Day(), 0, 0, 0, 0, time.UTC)
+		end := start.AddDate(0, 0, 1)
+
+		line("BEGIN:VEVENT")
+		line("UID:" + e.UID)
+		line("DTSTAMP:" + dtstamp)
+		line("DTSTART;VALUE=DATE:" + start.Format("20060102"))
+		line("DTEND;VALUE=DATE:" + end.Format("20060102"))
+		line("SUMMARY:" + escapeText(e.Summary))
+		if e.Description != "" {
+			line("DESCRIPTION:" + escapeText(e.Description))
+		}
+		line("TRANSP:TRANSPARENT")
+		for _, days := range e.Reminders {
+			line("BEGIN:VALARM")
+			line("ACTION:DISPLAY")
+			line("DESCRIPTION:" + escapeText(e.Summary))
+			line(fmt.Sprintf("TRIGGER:-P%dD", days))
+			line("END:VALARM")
+		}
+		line("END:VEVENT")
+	}
+
+	line("END:VCALENDAR")
+	return bw.Flush()
+}
+
+// escapeText escapes a TEXT property value (RFC 5545 section 3.3.11).
+func escapeText(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(s)
+}
+
+// writeFolded writes one content line, folding it at 75 octets without
+// splitting a UTF-8 sequence. Continuation lines start with a space, which
+// counts toward their limit.
+func writeFolded(w *bufio.Writer, s string) {
+	limit := maxLineOctets
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		w.WriteString(s[:cut])
+		w.WriteString("\r\n ")
+		s = s[cut:]
+		limit = maxLineOctets - 1
+	}
+	w.WriteString(s)
+	w.WriteString("\r\n")
+}
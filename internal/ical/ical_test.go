@@ -0,0 +1,118 @@
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bc/porkbun-tui/internal/api"
+)
+
+var stamp = time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+func write(t *testing.T, cal Calendar) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := Write(&buf, cal, stamp); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	return buf.String()
+}
+
+func TestWriteAllDayEventWithAlarms(t *testing.T) {
+	out := write(t, Calendar{Events: []Event{{
+		UID:       "expire-a.com@porkbun-tui",
+		Date:      time.Date(2025, 12, 31, 18, 0, 0, 0, time.UTC),
+		Summary:   "a.com expires",
+		Reminders: []int{30, 1},
+	}}})
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"VERSION:2.0\r\n",
+		"DTSTAMP:20250102T030405Z\r\n",
+		"DTSTART;VALUE=DATE:20251231\r\n",
+		"DTEND;VALUE=DATE:20260101\r\n", // exclusive, across the year boundary
+		"TRIGGER:-P30D\r\n",
+		"TRIGGER:-P1D\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q", want)
+		}
+	}
+	if n := strings.Count(out, "BEGIN:VALARM"); n != 2 {
+		t.Errorf("%d alarms, want 2", n)
+	}
+	if strings.Contains(strings.ReplaceAll(out, "\r\n", ""), "\n") {
+		t.Error("bare LF line ending; RFC 5545 requires CRLF")
+	}
+}
+
+func TestWriteEscapesText(t *testing.T) {
+	out := write(t, Calendar{Events: []Event{{
+		UID:         "x",
+		Summary:     `a, b; c\d`,
+		Description: "line one\nline two",
+	}}})
+
+	if !strings.Contains(out, `SUMMARY:a\, b\; c\\d`) {
+		t.Errorf("summary not escaped:\n%s", out)
+	}
+	if !strings.Contains(out, `DESCRIPTION:line one\nline two`) {
+		t.Errorf("description newline not escaped:\n%s", out)
+	}
+}
+
+func TestWriteFoldsLongLines(t *testing.T) {
+	long := strings.Repeat("é", 100) // 200 octets
+	out := write(t, Calendar{Events: []Event{{UID: "x", Summary: long}}})
+
+	for _, line := range strings.Split(out, "\r\n") {
+		if len(line) > maxLineOctets {
+			t.Errorf("line of %d octets exceeds %d: %q", len(line), maxLineOctets, line)
+		}
+	}
+	unfolded := strings.ReplaceAll(out, "\r\n ", "")
+	if !strings.Contains(unfolded, "SUMMARY:"+long) {
+		t.Error("folding lost or split characters")
+	}
+}
+
+func TestExpirations(t *testing.T) {
+	domains := []api.Domain{
+		{Name: "later.io", TLD: "io", AutoRenew: false, ExpireDate: time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)},
+		{Name: "soon.com", TLD: "com", AutoRenew: true, ExpireDate: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	pricing := map[string]api.TLDPricing{"com": {Renewal: "10.37"}}
+
+	cal := Expirations(domains, pricing, nil)
+
+	if len(cal.Events) != 2 || cal.Events[0].UID != ExpirationUID("soon.com") {
+		t.Fatalf("events not sorted by date: %+v", cal.Events)
+	}
+	soon, later := cal.Events[0], cal.Events[1]
+	if !strings.Contains(soon.Description, "Auto-renew: on") || !strings.Contains(soon.Description, "$10.37") {
+		t.Errorf("soon.com description = %q", soon.Description)
+	}
+	if !strings.Contains(later.Description, "Auto-renew: off") || !strings.Contains(later.Description, "unknown") {
+		t.Errorf("later.io description = %q", later.Description)
+	}
+	if len(soon.Reminders) != len(DefaultReminders) {
+		t.Errorf("nil reminders gave %v, want defaults %v", soon.Reminders, DefaultReminders)
+	}
+
+	if cal := Expirations(domains, pricing, []int{}); len(cal.Events[0].Reminders) != 0 {
+		t.Error("an empty reminder list still produced alarms")
+	}
+}
+
+func TestExpirationUIDStableAcrossRenewal(t *testing.T) {
+	before := Expirations([]api.Domain{{Name: "a.com", ExpireDate: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)}}, nil, nil)
+	after := Expirations([]api.Domain{{Name: "a.com", ExpireDate: time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)}}, nil, nil)
+
+	if before.Events[0].UID != after.Events[0].UID {
+		t.Error("UID changed when the expiration moved; re-import would duplicate the event")
+	}
+}
@@ -3,22 +3,31 @@ package keys
 import "github.com/charmbracelet/bubbles/key"
 
 type KeyMap struct {
-	Up       key.Binding
-	Down     key.Binding
-	Enter    key.Binding
-	Back     key.Binding
-	Search   key.Binding
-	Refresh  key.Binding
-	Help     key.Binding
-	Quit     key.Binding
-	DNS      key.Binding
-	NS       key.Binding
-	Avail    key.Binding
-	TLD      key.Binding
-	Calendar key.Binding
-	SortName key.Binding
-	SortExp  key.Binding
-	Tab      key.Binding
+	Up        key.Binding
+	Down      key.Binding
+	Enter     key.Binding
+	Back      key.Binding
+	Search    key.Binding
+	Refresh   key.Binding
+	Help      key.Binding
+	Quit      key.Binding
+	DNS       key.Binding
+	NS        key.Binding
+	Avail     key.Binding
+	TLD       key.Binding
+	Providers key.Binding
+	Calendar  key.Binding
+	SSL       key.Binding
+	Forwards  key.Binding
+	DNSSEC    key.Binding
+	AutoRenew key.Binding
+	Mark      key.Binding
+	MarkAll   key.Binding
+	Invert    key.Binding
+	Bulk      key.Binding
+	SortName  key.Binding
+	SortExp   key.Binding
+	Tab       key.Binding
 }
 
 var Keys = KeyMap{
@@ -70,10 +79,46 @@ var Keys = KeyMap{
 		key.WithKeys("t"),
 		key.WithHelp("t", "TLD breakdown"),
 	),
+	Providers: key.NewBinding(
+		key.WithKeys("P"),
+		key.WithHelp("P", "dns providers"),
+	),
 	Calendar: key.NewBinding(
 		key.WithKeys("c"),
 		key.WithHelp("c", "calendar view"),
 	),
+	SSL: key.NewBinding(
+		key.WithKeys("s"),
+		key.WithHelp("s", "ssl certificate"),
+	),
+	Forwards: key.NewBinding(
+		key.WithKeys("f"),
+		key.WithHelp("f", "url forwards"),
+	),
+	DNSSEC: key.NewBinding(
+		key.WithKeys("D"),
+		key.WithHelp("D", "dnssec"),
+	),
+	AutoRenew: key.NewBinding(
+		key.WithKeys("A"),
+		key.WithHelp("A", "toggle auto-renew"),
+	),
+	Mark: key.NewBinding(
+		key.WithKeys(" "),
+		key.WithHelp("space", "mark"),
+	),
+	MarkAll: key.NewBinding(
+		key.WithKeys("*"),
+		key.WithHelp("*", "mark all shown"),
+	),
+	Invert: key.NewBinding(
+		key.WithKeys("i"),
+		key.WithHelp("i", "invert marks"),
+	),
+	Bulk: key.NewBinding(
+		key.WithKeys("b"),
+		key.WithHelp("b", "bulk actions"),
+	),
 	SortName: key.NewBinding(
 		key.WithKeys("1"),
 		key.WithHelp("1", "sort by name"),
@@ -96,7 +141,8 @@ func (k KeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
 		{k.Up, k.Down, k.Enter, k.Back},
 		{k.Search, k.Refresh, k.SortName, k.SortExp},
-		{k.DNS, k.NS, k.Avail, k.TLD, k.Calendar},
+		{k.Mark, k.MarkAll, k.Invert, k.Bulk},
+		{k.DNS, k.NS, k.SSL, k.Forwards, k.DNSSEC, k.AutoRenew, k.Avail, k.TLD, k.Providers, k.Calendar},
 		{k.Help, k.Quit},
 	}
 }
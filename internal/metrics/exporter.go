@@ -0,0 +1,111 @@
+package metrics
+
+import (
+	"bytes"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/bc/porkbun-tui/internal/api"
+)
+
+// ContentType is the text exposition format's media type.
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// Exporter holds the latest portfolio snapshot and renders it, with the
+// API client's live statistics, on every scrape. Expiry countdowns are
+// computed at scrape time, so they stay current between refreshes.
+type Exporter struct {
+	mu          sync.Mutex
+	domains     []api.Domain
+	pricing     map[string]api.TLDPricing
+	refreshed   time.Time
+	refreshedOK bool
+	stats       *api.Stats
+
+	now func() time.Time
+}
+
+// NewExporter returns an Exporter that reports stats (which may be nil).
+func NewExporter(stats *api.Stats) *Exporter {
+	return &Exporter{stats: stats, now: time.Now}
+}
+
+// SetPortfolio records a successful refresh.
+func (e *Exporter) SetPortfolio(domains []api.Domain, pricing map[string]api.TLDPricing) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.domains = domains
+	e.pricing = pricing
+	e.refreshed = e.now()
+	e.refreshedOK = true
+}
+
+// SetRefreshFailed records a failed refresh; the last good snapshot keeps
+// being served.
+func (e *Exporter) SetRefreshFailed() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.refreshedOK = false
+}
+
+// Families renders everything the exporter knows.
+func (e *Exporter) Families() []Family {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var families []Family
+	if !e.refreshed.IsZero() {
+		families = Portfolio(e.domains, e.pricing, e.now())
+	}
+
+	success := Family{Name: "porkbun_refresh_success", Type: Gauge,
+		Help: "1 if the last refresh from the Porkbun API succeeded."}
+	success.Add(boolValue(e.refreshedOK))
+	families = append(families, success)
+	if !e.refreshed.IsZero() {
+		last := Family{Name: "porkbun_last_refresh_timestamp_seconds", Type: Gauge,
+			Help: "Unix time of the last successful refresh."}
+		last.Add(float64(e.refreshed.Unix()))
+		families = append(families, last)
+	}
+
+	return append(families, APIStats(e.stats.Snapshot())...)
+}
+
+func (e *Exporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var buf bytes.Buffer
+	if err := Write(&buf, e.Families()); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", ContentType)
+	w.Write(buf.Bytes())
+}
+
+// WriteTextfile writes the metrics for node_exporter's textfile collector.
+// The file is replaced atomically: the collector must never read a
+// half-written file.
+func (e *Exporter) WriteTextfile(path string) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".porkbun-metrics-*")
+	if err != nil {
+		return err
+	}
+	if err := Write(tmp, e.Families()); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	// CreateTemp makes the file 0600; the collector may run as another user.
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
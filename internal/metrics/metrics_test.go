@@ -0,0 +1,160 @@
+package metrics
+
+import (
+	"bytes"
+	"math"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bc/porkbun-tui/internal/api"
+)
+
+var now = time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+func render(t *testing.T, families []Family) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := Write(&buf, families); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	return buf.String()
+}
+
+func TestWriteFormat(t *testing.T) {
+	f := Family{Name: "x_total", Help: "A help\nline.", Type: Counter}
+	f.Add(3, "path", `C:\dir "quoted"`)
+	f.Add(math.Inf(1))
+	empty := Family{Name: "skipped", Type: Gauge}
+
+	got := render(t, []Family{f, empty})
+
+	want := "# HELP x_total A help\\nline.\n" +
+		"# TYPE x_total counter\n" +
+		`x_total{path="C:\\dir \"quoted\""} 3` + "\n" +
+		"x_total +Inf\n"
+	if got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestPortfolioGauges(t *testing.T) {
+	domains := []api.Domain{
+		{Name: "a.com", TLD: "com", AutoRenew: true, SecurityLock: true, ExpireDate: now.Add(48 * time.Hour), Labels: []string{"work", "prod"}},
+		{Name: "b.com", TLD: "com", ExpireDate: now.Add(-time.Hour)},
+		{Name: "c.zzz", TLD: "zzz", WhoisPrivacy: true, ExpireDate: now.Add(time.Hour), Labels: []string{"work"}},
+	}
+	pricing := map[string]api.TLDPricing{"com": {Renewal: "10.50"}}
+
+	out := render(t, Portfolio(domains, pricing, now))
+
+	for _, want := range []string{
+		`porkbun_domain_expiry_seconds{domain="a.com",tld="com"} 172800`,
+		`porkbun_domain_expiry_seconds{domain="b.com",tld="com"} -3600`,
+		`porkbun_domain_auto_renew{domain="a.com"} 1`,
+		`porkbun_domain_auto_renew{domain="b.com"} 0`,
+		`porkbun_domain_security_lock{domain="a.com"} 1`,
+		`porkbun_domain_whois_privacy{domain="c.zzz"} 1`,
+		`porkbun_domain_renewal_price_dollars{domain="a.com",tld="com"} 10.5`,
+		`porkbun_tld_domains{tld="com"} 2`,
+		`porkbun_tld_renewal_cost_dollars{tld="com"} 21`,
+		`porkbun_label_domains{label="work"} 2`,
+		`porkbun_label_domains{label="prod"} 1`,
+		`porkbun_label_domains{label="(unlabeled)"} 1`,
+		`porkbun_domains 3`,
+	} {
+		if !strings.Contains(out, want+"\n") {
+			t.Errorf("missing %s", want)
+		}
+	}
+	if strings.Contains(out, `porkbun_domain_renewal_price_dollars{domain="c.zzz"`) {
+		t.Error("unpriced domain exported with a price")
+	}
+}
+
+func TestAPIStatsHistogram(t *testing.T) {
+	snap := api.StatsSnapshot{
+		Requests: []api.RequestCount{{Endpoint: "domain/listAll", Code: "200", Count: 4}},
+		Latencies: []api.Latency{{
+			Endpoint: "domain/listAll",
+			Buckets:  []uint64{1, 2, 3, 4, 4, 4, 4, 4},
+			Count:    4,
+			Sum:      1.5,
+		}},
+	}
+
+	out := render(t, APIStats(snap))
+
+	for _, want := range []string{
+		"# TYPE porkbun_api_request_duration_seconds histogram",
+		`porkbun_api_requests_total{endpoint="domain/listAll",code="200"} 4`,
+		`porkbun_api_request_duration_seconds_bucket{endpoint="domain/listAll",le="0.1"} 1`,
+		`porkbun_api_request_duration_seconds_bucket{endpoint="domain/listAll",le="+Inf"} 4`,
+		`porkbun_api_request_duration_seconds_sum{endpoint="domain/listAll"} 1.5`,
+		`porkbun_api_request_duration_seconds_count{endpoint="domain/listAll"} 4`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %s", want)
+		}
+	}
+}
+
+func TestExporterServesAndCountsDownAtScrapeTime(t *testing.T) {
+	e := NewExporter(nil)
+	clock := now
+	e.now = func() time.Time { return clock }
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	if !strings.Contains(rec.Body.String(), "porkbun_refresh_success 0") {
+		t.Error("no refresh yet, but not reported as failed")
+	}
+
+	e.SetPortfolio([]api.Domain{{Name: "a.com", TLD: "com", ExpireDate: now.Add(time.Hour)}}, nil)
+	clock = now.Add(10 * time.Minute)
+
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	if ct := rec.Header().Get("Content-Type"); ct != ContentType {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	body := rec.Body.String()
+	if !strings.Contains(body, `porkbun_domain_expiry_seconds{domain="a.com",tld="com"} 3000`) {
+		t.Errorf("expiry not computed at scrape time:\n%s", body)
+	}
+	if !strings.Contains(body, "porkbun_refresh_success 1") {
+		t.Error("successful refresh not reported")
+	}
+
+	e.SetRefreshFailed()
+	if out := render(t, e.Families()); !strings.Contains(out, "porkbun_refresh_success 0") || !strings.Contains(out, "a.com") {
+		t.Error("a failed refresh should flag failure but keep serving the last snapshot")
+	}
+}
+
+func TestWriteTextfile(t *testing.T) {
+	e := NewExporter(nil)
+	e.SetPortfolio([]api.Domain{{Name: "a.com", TLD: "com", ExpireDate: now}}, nil)
+	path := filepath.Join(t.TempDir(), "porkbun.prom")
+
+	if err := e.WriteTextfile(path); err != nil {
+		t.Fatalf("WriteTextfile: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `porkbun_domains 1`) {
+		t.Errorf("textfile = %s", data)
+	}
+	info, _ := os.Stat(path)
+	if info.Mode().Perm() != 0644 {
+		t.Errorf("mode = %v, want 0644 so the collector can read it", info.Mode().Perm())
+	}
+	if matches, _ := filepath.Glob(filepath.Join(filepath.Dir(path), ".porkbun-metrics-*")); len(matches) != 0 {
+		t.Errorf("temp files left behind: %v", matches)
+	}
+}
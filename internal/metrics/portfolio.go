@@ -0,0 +1,137 @@
+package metrics
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/bc/porkbun-tui/internal/api"
+	"github.com/bc/porkbun-tui/internal/portfolio"
+)
+
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// Portfolio returns per-domain gauges and per-TLD and per-label aggregates.
+// Domains without pricing get no price sample rather than a false zero.
+func Portfolio(domains []api.Domain, pricing map[string]api.TLDPricing, now time.Time) []Family {
+	expiry := Family{Name: "porkbun_domain_expiry_seconds", Type: Gauge,
+		Help: "Seconds until the domain expires; negative once expired."}
+	expiryTS := Family{Name: "porkbun_domain_expiry_timestamp_seconds", Type: Gauge,
+		Help: "Domain expiration as a Unix timestamp."}
+	autoRenew := Family{Name: "porkbun_domain_auto_renew", Type: Gauge,
+		Help: "1 if the domain auto-renews."}
+	lock := Family{Name: "porkbun_domain_security_lock", Type: Gauge,
+		Help: "1 if the domain's security lock is on."}
+	privacy := Family{Name: "porkbun_domain_whois_privacy", Type: Gauge,
+		Help: "1 if WHOIS privacy is on."}
+	price := Family{Name: "porkbun_domain_renewal_price_dollars", Type: Gauge,
+		Help: "Current one-year renewal price of the domain's TLD."}
+
+	type aggregate struct {
+		domains int
+		cost    float64
+	}
+	byTLD := make(map[string]*aggregate)
+	byLabel := make(map[string]*aggregate)
+	add := func(m map[string]*aggregate, key string, cost float64) {
+		a, ok := m[key]
+		if !ok {
+			a = &aggregate{}
+			m[key] = a
+		}
+		a.domains++
+		a.cost += cost
+	}
+
+	sorted := make([]api.Domain, len(domains))
+	copy(sorted, domains)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+	for _, d := range sorted {
+		expiry.Add(d.ExpireDate.Sub(now).Seconds(), "domain", d.Name, "tld", d.TLD)
+		expiryTS.Add(float64(d.ExpireDate.Unix()), "domain", d.Name, "tld", d.TLD)
+		autoRenew.Add(boolValue(d.AutoRenew), "domain", d.Name)
+		lock.Add(boolValue(d.SecurityLock), "domain", d.Name)
+		privacy.Add(boolValue(d.WhoisPrivacy), "domain", d.Name)
+
+		cost := portfolio.RenewalPrice(pricing, d.TLD)
+		if cost > 0 {
+			price.Add(cost, "domain", d.Name, "tld", d.TLD)
+		}
+
+		add(byTLD, d.TLD, cost)
+		if len(d.Labels) == 0 {
+			add(byLabel, portfolio.UnlabeledGroup, cost)
+		}
+		for _, l := range d.Labels {
+			add(byLabel, l, cost)
+		}
+	}
+
+	tldDomains := Family{Name: "porkbun_tld_domains", Type: Gauge,
+		Help: "Domains owned per TLD."}
+	tldCost := Family{Name: "porkbun_tld_renewal_cost_dollars", Type: Gauge,
+		Help: "Yearly renewal cost of the domains owned per TLD."}
+	for _, tld := range sortedKeys(byTLD) {
+		tldDomains.Add(float64(byTLD[tld].domains), "tld", tld)
+		tldCost.Add(byTLD[tld].cost, "tld", tld)
+	}
+
+	labelDomains := Family{Name: "porkbun_label_domains", Type: Gauge,
+		Help: "Domains per label; a domain with several labels counts in each."}
+	labelCost := Family{Name: "porkbun_label_renewal_cost_dollars", Type: Gauge,
+		Help: "Yearly renewal cost of the domains per label."}
+	for _, label := range sortedKeys(byLabel) {
+		labelDomains.Add(float64(byLabel[label].domains), "label", label)
+		labelCost.Add(byLabel[label].cost, "label", label)
+	}
+
+	total := Family{Name: "porkbun_domains", Type: Gauge, Help: "Domains in the account."}
+	total.Add(float64(len(domains)))
+
+	return []Family{expiry, expiryTS, autoRenew, lock, privacy, price,
+		tldDomains, tldCost, labelDomains, labelCost, total}
+}
+
+// APIStats returns the API client's request counters and latency
+// histograms.
+func APIStats(snap api.StatsSnapshot) []Family {
+	requests := Family{Name: "porkbun_api_requests_total", Type: Counter,
+		Help: "Porkbun API requests by endpoint and HTTP status (error: no response)."}
+	for _, r := range snap.Requests {
+		requests.Add(float64(r.Count), "endpoint", r.Endpoint, "code", r.Code)
+	}
+
+	duration := Family{Name: "porkbun_api_request_duration_seconds", Type: Histogram,
+		Help: "Porkbun API request latency by endpoint."}
+	for _, l := range snap.Latencies {
+		for i, bound := range api.LatencyBuckets {
+			duration.Samples = append(duration.Samples, Sample{
+				Suffix: "_bucket",
+				Labels: pairs([]string{"endpoint", l.Endpoint, "le", strconv.FormatFloat(bound, 'g', -1, 64)}),
+				Value:  float64(l.Buckets[i]),
+			})
+		}
+		duration.Samples = append(duration.Samples,
+			Sample{Suffix: "_bucket", Labels: pairs([]string{"endpoint", l.Endpoint, "le", "+Inf"}), Value: float64(l.Count)},
+			Sample{Suffix: "_sum", Labels: pairs([]string{"endpoint", l.Endpoint}), Value: l.Sum},
+			Sample{Suffix: "_count", Labels: pairs([]string{"endpoint", l.Endpoint}), Value: float64(l.Count)},
+		)
+	}
+
+	return []Family{requests, duration}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,151 @@
+// Package nsmigrate supports moving many domains to a new set of
+// nameservers: comparing each domain's current set with the target, and
+// the rollback file that records what the changed domains had before.
+package nsmigrate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Same reports whether two nameserver sets are the same hosts, ignoring
+// case, trailing dots and order.
+func Same(a, b []string) bool {
+	na, nb := normalized(a), normalized(b)
+	if len(na) != len(nb) {
+		return false
+	}
+	for i := range na {
+		if na[i] != nb[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal reports whether two nameserver lists are the same hosts in the
+// same order, ignoring case and trailing dots. Unlike Same, a reordering
+// counts as a change.
+func Equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if normalizeHost(a[i]) != normalizeHost(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func normalizeHost(n string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(n)), ".")
+}
+
+func normalized(ns []string) []string {
+	out := make([]string, 0, len(ns))
+	seen := make(map[string]bool)
+	for _, n := range ns {
+		n = normalizeHost(n)
+		if n != "" && !seen[n] {
+			seen[n] = true
+			out = append(out, n)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Rollback is what a migration changed: each domain's nameservers from
+// before it was moved to Target.
+type Rollback struct {
+	Created  time.Time           `json:"created"`
+	Target   []string            `json:"target"`
+	Previous map[string][]string `json:"previous"`
+	// Skipped are the domains ReadRollback found with no previous
+	// nameservers, which can't be put back; they aren't in Previous.
+	Skipped []string `json:"-"`
+}
+
+// Domains lists the domains in the rollback, sorted.
+func (r Rollback) Domains() []string {
+	names := make([]string, 0, len(r.Previous))
+	for name := range r.Previous {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// WriteRollback writes r to dir as porkbun-ns-rollback-<time>.json and
+// returns the path. An existing file is never replaced.
+func WriteRollback(dir string, r Rollback) (string, error) {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	name := fmt.Sprintf("porkbun-ns-rollback-%s.json", r.Created.Format("20060102-150405"))
+	path, err := filepath.Abs(filepath.Join(dir, name))
+	if err != nil {
+		return "", err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return "", err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return "", err
+	}
+	return path, f.Close()
+}
+
+// UpdateRollback replaces the rollback at path, written by WriteRollback,
+// with r. The new content is written beside it and renamed over it, so the
+// file is never left half-written.
+func UpdateRollback(path string, r Rollback) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+// ReadRollback reads a file written by WriteRollback. A domain with no
+// previous nameservers is moved to Skipped rather than failing the rest;
+// a file with nothing left to put back is an error.
+func ReadRollback(path string) (Rollback, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Rollback{}, err
+	}
+	var r Rollback
+	if err := json.Unmarshal(data, &r); err != nil {
+		return Rollback{}, fmt.Errorf("%s: %w", path, err)
+	}
+	for name, ns := range r.Previous {
+		if len(ns) == 0 {
+			delete(r.Previous, name)
+			r.Skipped = append(r.Skipped, name)
+		}
+	}
+	sort.Strings(r.Skipped)
+	if len(r.Previous) == 0 {
+		return Rollback{}, errors.New(path + ": no domains to roll back")
+	}
+	return r, nil
+}
@@ -0,0 +1,122 @@
+package nsmigrate
+
+import (
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSame(t *testing.T) {
+	tests := []struct {
+		a, b []string
+		want bool
+	}{
+		{[]string{"ns1.cloudflare.com", "ns2.cloudflare.com"}, []string{"NS2.cloudflare.com.", "ns1.cloudflare.com"}, true},
+		{[]string{"ns1.cloudflare.com"}, []string{"ns1.cloudflare.com", "ns2.cloudflare.com"}, false},
+		{nil, nil, true},
+		{[]string{"a.example"}, []string{"b.example"}, false},
+	}
+	for _, tt := range tests {
+		if got := Same(tt.a, tt.b); got != tt.want {
+			t.Errorf("Same(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestEqualIsOrderSensitive(t *testing.T) {
+	tests := []struct {
+		a, b []string
+		want bool
+	}{
+		{[]string{"ns1.cloudflare.com", "ns2.cloudflare.com"}, []string{"NS1.cloudflare.com.", "ns2.cloudflare.com"}, true},
+		{[]string{"ns1.cloudflare.com", "ns2.cloudflare.com"}, []string{"ns2.cloudflare.com", "ns1.cloudflare.com"}, false},
+		{[]string{"ns1.cloudflare.com"}, []string{"ns1.cloudflare.com", "ns2.cloudflare.com"}, false},
+		{nil, nil, true},
+	}
+	for _, tt := range tests {
+		if got := Equal(tt.a, tt.b); got != tt.want {
+			t.Errorf("Equal(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestRollbackRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	r := Rollback{
+		Created: time.Date(2026, 10, 18, 15, 4, 5, 0, time.UTC),
+		Target:  []string{"ns1.cloudflare.com", "ns2.cloudflare.com"},
+		Previous: map[string][]string{
+			"b.com": {"curitiba.ns.porkbun.com"},
+			"a.com": {"maceio.ns.porkbun.com"},
+		},
+	}
+	path, err := WriteRollback(dir, r)
+	if err != nil {
+		t.Fatalf("WriteRollback: %v", err)
+	}
+	if _, err := WriteRollback(dir, r); err == nil {
+		t.Error("a second write replaced the rollback file")
+	}
+
+	got, err := ReadRollback(path)
+	if err != nil {
+		t.Fatalf("ReadRollback: %v", err)
+	}
+	if !got.Created.Equal(r.Created) || !reflect.DeepEqual(got.Previous, r.Previous) {
+		t.Errorf("read %+v, want %+v", got, r)
+	}
+	if d := got.Domains(); !reflect.DeepEqual(d, []string{"a.com", "b.com"}) {
+		t.Errorf("Domains() = %v", d)
+	}
+}
+
+func TestUpdateRollbackReplacesInPlace(t *testing.T) {
+	dir := t.TempDir()
+	r := Rollback{
+		Created:  time.Date(2026, 10, 18, 15, 4, 5, 0, time.UTC),
+		Previous: map[string][]string{"a.com": {"maceio.ns.porkbun.com"}, "b.com": {"curitiba.ns.porkbun.com"}},
+	}
+	path, err := WriteRollback(dir, r)
+	if err != nil {
+		t.Fatalf("WriteRollback: %v", err)
+	}
+
+	delete(r.Previous, "b.com")
+	if err := UpdateRollback(path, r); err != nil {
+		t.Fatalf("UpdateRollback: %v", err)
+	}
+	got, err := ReadRollback(path)
+	if err != nil {
+		t.Fatalf("ReadRollback: %v", err)
+	}
+	if d := got.Domains(); !reflect.DeepEqual(d, []string{"a.com"}) {
+		t.Errorf("Domains() = %v after the update, want a.com alone", d)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("%d files in the directory, want the rollback file alone", len(entries))
+	}
+}
+
+func TestReadRollbackSkipsEmptyEntries(t *testing.T) {
+	path := t.TempDir() + "/partial.json"
+	os.WriteFile(path, []byte(`{"previous":{"a.com":[],"b.com":["maceio.ns.porkbun.com"],"c.com":null}}`), 0644)
+	r, err := ReadRollback(path)
+	if err != nil {
+		t.Fatalf("ReadRollback: %v", err)
+	}
+	if d := r.Domains(); !reflect.DeepEqual(d, []string{"b.com"}) {
+		t.Errorf("Domains() = %v, want b.com alone", d)
+	}
+	if !reflect.DeepEqual(r.Skipped, []string{"a.com", "c.com"}) {
+		t.Errorf("Skipped = %v, want a.com and c.com", r.Skipped)
+	}
+}
+
+func TestReadRollbackRejectsEmpty(t *testing.T) {
+	path := t.TempDir() + "/empty.json"
+	os.WriteFile(path, []byte(`{"previous":{"a.com":[]}}`), 0644)
+	if _, err := ReadRollback(path); err == nil {
+		t.Error("ReadRollback accepted a file with nothing to put back")
+	}
+}
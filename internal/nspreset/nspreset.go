@@ -0,0 +1,48 @@
+// Package nspreset holds named nameserver sets to pick from when editing
+// or migrating nameservers: the built-in ones and those in config.yaml.
+package nspreset
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bc/porkbun-tui/internal/api"
+	"github.com/bc/porkbun-tui/internal/config"
+)
+
+// Preset is a named set of nameservers.
+type Preset struct {
+	Name string
+	NS   []string
+}
+
+// Builtin are the presets available without any configuration. Providers
+// that assign each account its own pair, like Cloudflare, belong in
+// config.yaml instead.
+var Builtin = []Preset{
+	{
+		Name: "Porkbun Default",
+		NS:   []string{"maceio.ns.porkbun.com", "curitiba.ns.porkbun.com", "salvador.ns.porkbun.com", "fortaleza.ns.porkbun.com"},
+	},
+	{
+		Name: "Google Cloud DNS",
+		NS:   []string{"ns-cloud-a1.googledomains.com", "ns-cloud-a2.googledomains.com", "ns-cloud-a3.googledomains.com", "ns-cloud-a4.googledomains.com"},
+	},
+}
+
+// Compile validates the config presets, naming the first bad one, and
+// returns them after the built-in ones.
+func Compile(raw []config.NSPreset) ([]Preset, error) {
+	presets := append([]Preset(nil), Builtin...)
+	for i, p := range raw {
+		if strings.TrimSpace(p.Name) == "" {
+			return nil, fmt.Errorf("ns preset %d: a preset needs a name", i+1)
+		}
+		ns, err := api.NormalizeNameservers(p.Nameservers)
+		if err != nil {
+			return nil, fmt.Errorf("ns preset %d: %s: %w", i+1, p.Name, err)
+		}
+		presets = append(presets, Preset{Name: p.Name, NS: ns})
+	}
+	return presets, nil
+}
@@ -0,0 +1,64 @@
+package nspreset
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bc/porkbun-tui/internal/api"
+	"github.com/bc/porkbun-tui/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+func TestBuiltinPresetsAreValid(t *testing.T) {
+	for _, p := range Builtin {
+		if _, err := api.NormalizeNameservers(p.NS); err != nil {
+			t.Errorf("%s: %v", p.Name, err)
+		}
+	}
+}
+
+func TestCompileAppendsConfigPresets(t *testing.T) {
+	var cfg config.Config
+	err := yaml.Unmarshal([]byte(`
+ns_presets:
+  - name: Cloudflare (work)
+    nameservers: [Ada.NS.Cloudflare.com., bob.ns.cloudflare.com]
+`), &cfg)
+	if err != nil {
+		t.Fatalf("yaml: %v", err)
+	}
+
+	presets, err := Compile(cfg.NSPresets)
+	if err != nil {
+		t.Fatalf("Compile: %v", err)
+	}
+	if len(presets) != len(Builtin)+1 {
+		t.Fatalf("got %d presets, want the built-in ones and one more", len(presets))
+	}
+	got := presets[len(Builtin)]
+	if got.Name != "Cloudflare (work)" || strings.Join(got.NS, ",") != "ada.ns.cloudflare.com,bob.ns.cloudflare.com" {
+		t.Errorf("config preset = %+v", got)
+	}
+}
+
+func TestCompileRejectsBadPresets(t *testing.T) {
+	for _, tc := range []struct {
+		raw  config.NSPreset
+		want string
+	}{
+		{config.NSPreset{Nameservers: []string{"ns1.example.net"}}, "needs a name"},
+		{config.NSPreset{Name: "empty"}, "at least 2 nameservers"},
+		{config.NSPreset{Name: "single", Nameservers: []string{"ns1.example.net"}}, "at least 2 nameservers"},
+		{config.NSPreset{Name: "too many", Nameservers: []string{
+			"ns1.example.net", "ns2.example.net", "ns3.example.net", "ns4.example.net", "ns5.example.net",
+			"ns6.example.net", "ns7.example.net", "ns8.example.net", "ns9.example.net", "ns10.example.net",
+			"ns11.example.net", "ns12.example.net", "ns13.example.net", "ns14.example.net",
+		}}, "at most 13"},
+		{config.NSPreset{Name: "dup", Nameservers: []string{"ns1.example.net", "NS1.example.net"}}, "listed twice"},
+	} {
+		_, err := Compile([]config.NSPreset{tc.raw})
+		if err == nil || !strings.Contains(err.Error(), tc.want) || !strings.Contains(err.Error(), "ns preset 1") {
+			t.Errorf("Compile(%+v) = %v, want an error about %q", tc.raw, err, tc.want)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package portfolio
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+
+	"github.com/bc/porkbun-tui/internal/api"
+)
+
+// WriteDomainsCSV writes one row per domain with its dates, flags and
+// labels, in the order given.
+func WriteDomainsCSV(w io.Writer, domains []api.Domain) error {
+	cw := csv.NewWriter(w)
+	header := []string{"domain", "tld", "status", "created", "expires", "auto_renew", "security_lock", "whois_privacy", "labels"}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for _, d := range domains {
+		if err := cw.Write([]string{
+			d.Name,
+			d.TLD,
+			d.Status,
+			d.CreateDate.Format("2006-01-02"),
+			d.ExpireDate.Format("2006-01-02"),
+			yesNo(d.AutoRenew),
+			yesNo(d.SecurityLock),
+			yesNo(d.WhoisPrivacy),
+			strings.Join(d.Labels, ";"),
+		}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
@@ -0,0 +1,36 @@
+package portfolio
+
+import (
+	"bytes"
+	"encoding/csv"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/bc/porkbun-tui/internal/api"
+)
+
+func TestWriteDomainsCSV(t *testing.T) {
+	domains := []api.Domain{{
+		Name:       "example.com",
+		TLD:        "com",
+		Status:     "ACTIVE",
+		CreateDate: time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC),
+		ExpireDate: time.Date(2027, 1, 2, 0, 0, 0, 0, time.UTC),
+		AutoRenew:  true,
+		Labels:     []string{"client", "prod"},
+	}}
+
+	var buf bytes.Buffer
+	if err := WriteDomainsCSV(&buf, domains); err != nil {
+		t.Fatalf("WriteDomainsCSV: %v", err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not valid CSV: %v", err)
+	}
+	want := []string{"example.com", "com", "ACTIVE", "2020-01-02", "2027-01-02", "yes", "no", "no", "client;prod"}
+	if len(rows) != 2 || !reflect.DeepEqual(rows[1], want) {
+		t.Errorf("rows = %v, want header and %v", rows, want)
+	}
+}
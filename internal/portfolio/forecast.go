@@ -0,0 +1,285 @@
+// Package portfolio derives money and calendar figures from the domain list
+// and TLD pricing, shared by the TUI views and the CLI commands.
+package portfolio
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/bc/porkbun-tui/internal/api"
+)
+
+const (
+	MinForecastYears = 1
+	MaxForecastYears = 5
+
+	// UnlabeledGroup collects domains without any Porkbun label.
+	UnlabeledGroup = "(unlabeled)"
+)
+
+// Grouping selects how a forecast is broken down.
+type Grouping int
+
+const (
+	ByMonth Grouping = iota
+	ByTLD
+	ByLabel
+)
+
+func (g Grouping) String() string {
+	switch g {
+	case ByTLD:
+		return "tld"
+	case ByLabel:
+		return "label"
+	default:
+		return "month"
+	}
+}
+
+// ParseGrouping is the inverse of Grouping.String.
+func ParseGrouping(s string) (Grouping, error) {
+	switch s {
+	case "month":
+		return ByMonth, nil
+	case "tld":
+		return ByTLD, nil
+	case "label":
+		return ByLabel, nil
+	}
+	return 0, fmt.Errorf("unknown grouping %q (want month, tld or label)", s)
+}
+
+// RenewalPrice returns the renewal price for tld, or 0 when pricing is
+// missing or unparsable.
+func RenewalPrice(pricing map[string]api.TLDPricing, tld string) float64 {
+	p, ok := pricing[tld]
+	if !ok {
+		return 0
+	}
+	price, _ := strconv.ParseFloat(p.Renewal, 64)
+	return price
+}
+
+// Renewal is one projected renewal charge.
+type Renewal struct {
+	Domain    api.Domain
+	Date      time.Time
+	Price     float64
+	YearIndex int // 0-based forecast year the charge falls in
+}
+
+// Period is the spend for one month or one forecast year.
+type Period struct {
+	Start    time.Time
+	Renewals int
+	Cost     float64
+}
+
+// GroupTotal is the spend for one TLD or label, split per forecast year.
+type GroupTotal struct {
+	Key     string
+	Domains int
+	Years   []float64
+	Total   float64
+}
+
+// Forecast projects renewal spend over the next Years years starting at
+// From. Each domain renews on every anniversary of its ExpireDate inside the
+// window at the current renewal price; domains with unknown pricing still
+// count as renewals but add nothing to the totals.
+type Forecast struct {
+	From     time.Time
+	Years    int
+	Renewals []Renewal
+	Months   []Period
+	ByYear   []Period
+	Total    float64
+	Unpriced int // renewals whose TLD has no pricing
+}
+
+// NewForecast builds a forecast for the given number of years, clamped to
+// MinForecastYears..MaxForecastYears.
+func NewForecast(domains []api.Domain, pricing map[string]api.TLDPricing, from time.Time, years int) *Forecast {
+	years = min(max(years, MinForecastYears), MaxForecastYears)
+	f := &Forecast{From: from, Years: years}
+	end := from.AddDate(years, 0, 0)
+
+	for _, d := range domains {
+		if d.ExpireDate.IsZero() {
+			continue
+		}
+		price := RenewalPrice(pricing, d.TLD)
+		// Expiry dates come back in UTC; month and year buckets are in
+		// from's zone, so a late-evening renewal lands in the month the
+		// user sees rather than one past the end of the window.
+		expire := d.ExpireDate.In(from.Location())
+		// Anniversaries are computed from the original date each time so a
+		// Feb 29 expiry does not drift to Mar 1 forever after one step.
+		for n := 0; ; n++ {
+			date := expire.AddDate(n, 0, 0)
+			if !date.Before(end) {
+				break
+			}
+			if date.Before(from) {
+				continue
+			}
+			f.Renewals = append(f.Renewals, Renewal{
+				Domain:    d,
+				Date:      date,
+				Price:     price,
+				YearIndex: f.yearIndex(date),
+			})
+		}
+	}
+
+	sort.Slice(f.Renewals, func(i, j int) bool {
+		if !f.Renewals[i].Date.Equal(f.Renewals[j].Date) {
+			return f.Renewals[i].Date.Before(f.Renewals[j].Date)
+		}
+		return f.Renewals[i].Domain.Name < f.Renewals[j].Domain.Name
+	})
+
+	f.ByYear = make([]Period, years)
+	for i := range f.ByYear {
+		f.ByYear[i].Start = from.AddDate(i, 0, 0)
+	}
+
+	// Every calendar month touched by the window gets a row, including
+	// empty ones, so a budget sheet lines up month by month.
+	start := time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, from.Location())
+	for m := start; m.Before(end); m = m.AddDate(0, 1, 0) {
+		f.Months = append(f.Months, Period{Start: m})
+	}
+
+	for _, r := range f.Renewals {
+		f.Total += r.Price
+		if r.Price == 0 {
+			f.Unpriced++
+		}
+		f.ByYear[r.YearIndex].Renewals++
+		f.ByYear[r.YearIndex].Cost += r.Price
+		mi := monthsBetween(start, r.Date)
+		f.Months[mi].Renewals++
+		f.Months[mi].Cost += r.Price
+	}
+
+	return f
+}
+
+func (f *Forecast) yearIndex(date time.Time) int {
+	for i := f.Years - 1; i > 0; i-- {
+		if !date.Before(f.From.AddDate(i, 0, 0)) {
+			return i
+		}
+	}
+	return 0
+}
+
+func monthsBetween(start, t time.Time) int {
+	return (t.Year()-start.Year())*12 + int(t.Month()-start.Month())
+}
+
+// Groups breaks the forecast down by TLD or label, highest total first. A
+// domain with several labels counts toward each of them, so label totals can
+// add up to more than the forecast total.
+func (f *Forecast) Groups(g Grouping) []GroupTotal {
+	groups := make(map[string]*GroupTotal)
+	domains := make(map[string]map[string]bool)
+
+	add := func(key string, r Renewal) {
+		gt, ok := groups[key]
+		if !ok {
+			gt = &GroupTotal{Key: key, Years: make([]float64, f.Years)}
+			groups[key] = gt
+			domains[key] = make(map[string]bool)
+		}
+		gt.Years[r.YearIndex] += r.Price
+		gt.Total += r.Price
+		if !domains[key][r.Domain.Name] {
+			domains[key][r.Domain.Name] = true
+			gt.Domains++
+		}
+	}
+
+	for _, r := range f.Renewals {
+		switch g {
+		case ByLabel:
+			if len(r.Domain.Labels) == 0 {
+				add(UnlabeledGroup, r)
+			}
+			for _, l := range r.Domain.Labels {
+				add(l, r)
+			}
+		default:
+			add(r.Domain.TLD, r)
+		}
+	}
+
+	out := make([]GroupTotal, 0, len(groups))
+	for _, gt := range groups {
+		out = append(out, *gt)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Total != out[j].Total {
+			return out[i].Total > out[j].Total
+		}
+		return out[i].Key < out[j].Key
+	})
+	return out
+}
+
+// WriteCSV writes the forecast as CSV. ByMonth writes one row per month;
+// ByTLD and ByLabel write one row per group with a column per forecast year
+// (headed by the year's start date) and a total.
+func (f *Forecast) WriteCSV(w io.Writer, g Grouping) error {
+	cw := csv.NewWriter(w)
+
+	if g == ByMonth {
+		if err := cw.Write([]string{"month", "renewals", "cost"}); err != nil {
+			return err
+		}
+		for _, m := range f.Months {
+			if err := cw.Write([]string{
+				m.Start.Format("2006-01"),
+				strconv.Itoa(m.Renewals),
+				formatAmount(m.Cost),
+			}); err != nil {
+				return err
+			}
+		}
+		if err := cw.Write([]string{"total", strconv.Itoa(len(f.Renewals)), formatAmount(f.Total)}); err != nil {
+			return err
+		}
+	} else {
+		header := []string{g.String(), "domains"}
+		for _, y := range f.ByYear {
+			header = append(header, y.Start.Format("2006-01-02"))
+		}
+		header = append(header, "total")
+		if err := cw.Write(header); err != nil {
+			return err
+		}
+		for _, gt := range f.Groups(g) {
+			row := []string{gt.Key, strconv.Itoa(gt.Domains)}
+			for _, y := range gt.Years {
+				row = append(row, formatAmount(y))
+			}
+			row = append(row, formatAmount(gt.Total))
+			if err := cw.Write(row); err != nil {
+				return err
+			}
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func formatAmount(v float64) string {
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
@@ -0,0 +1,204 @@
+package portfolio
+
+import (
+	"bytes"
+	"encoding/csv"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/bc/porkbun-tui/internal/api"
+)
+
+var forecastStart = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func forecastFixture() ([]api.Domain, map[string]api.TLDPricing) {
+	domains := []api.Domain{
+		{Name: "a.com", TLD: "com", ExpireDate: time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC), Labels: []string{"prod"}},
+		{Name: "b.com", TLD: "com", ExpireDate: time.Date(2026, 3, 20, 0, 0, 0, 0, time.UTC)},
+		{Name: "c.io", TLD: "io", ExpireDate: time.Date(2026, 11, 5, 0, 0, 0, 0, time.UTC), Labels: []string{"prod", "eu"}},
+		{Name: "d.xyz", TLD: "xyz", ExpireDate: time.Date(2027, 6, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	pricing := map[string]api.TLDPricing{
+		"com": {Renewal: "10.00"},
+		"io":  {Renewal: "30.00"},
+		// xyz deliberately unpriced
+	}
+	return domains, pricing
+}
+
+func approx(a, b float64) bool {
+	return math.Abs(a-b) < 0.001
+}
+
+func TestNewForecast_OneYear(t *testing.T) {
+	domains, pricing := forecastFixture()
+
+	f := NewForecast(domains, pricing, forecastStart, 1)
+
+	// d.xyz expires outside the first year.
+	if len(f.Renewals) != 3 {
+		t.Fatalf("got %d renewals in year one, want 3", len(f.Renewals))
+	}
+	if !approx(f.Total, 50) {
+		t.Errorf("Total = %.2f, want 50.00", f.Total)
+	}
+	if len(f.Months) != 12 {
+		t.Fatalf("got %d months, want 12", len(f.Months))
+	}
+	if march := f.Months[2]; march.Renewals != 2 || !approx(march.Cost, 20) {
+		t.Errorf("March = %d renewals / %.2f, want 2 / 20.00", march.Renewals, march.Cost)
+	}
+	if nov := f.Months[10]; nov.Renewals != 1 || !approx(nov.Cost, 30) {
+		t.Errorf("November = %d renewals / %.2f, want 1 / 30.00", nov.Renewals, nov.Cost)
+	}
+}
+
+func TestNewForecast_ProjectsAnniversaries(t *testing.T) {
+	domains, pricing := forecastFixture()
+
+	f := NewForecast(domains, pricing, forecastStart, 3)
+
+	// a, b, c renew three times each; d renews in years two and three.
+	if len(f.Renewals) != 11 {
+		t.Fatalf("got %d renewals over 3 years, want 11", len(f.Renewals))
+	}
+	if len(f.ByYear) != 3 {
+		t.Fatalf("got %d years, want 3", len(f.ByYear))
+	}
+	for i, y := range f.ByYear {
+		if !approx(y.Cost, 50) {
+			t.Errorf("year %d cost = %.2f, want 50.00", i+1, y.Cost)
+		}
+	}
+	if y2 := f.ByYear[1]; y2.Renewals != 4 {
+		t.Errorf("year 2 renewals = %d, want 4 (d.xyz joins)", y2.Renewals)
+	}
+	if f.Unpriced != 2 {
+		t.Errorf("Unpriced = %d, want 2 (d.xyz twice)", f.Unpriced)
+	}
+}
+
+func TestNewForecast_SkipsPastExpiries(t *testing.T) {
+	domains := []api.Domain{
+		{Name: "old.com", TLD: "com", ExpireDate: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	f := NewForecast(domains, map[string]api.TLDPricing{"com": {Renewal: "10.00"}}, forecastStart, 1)
+
+	if len(f.Renewals) != 1 || !f.Renewals[0].Date.Equal(time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("renewals = %+v, want only the 2026-02-01 anniversary", f.Renewals)
+	}
+}
+
+func TestNewForecast_MixedZonesStayInWindow(t *testing.T) {
+	pst := time.FixedZone("PST", -8*3600)
+	jst := time.FixedZone("JST", 9*3600)
+	pricing := map[string]api.TLDPricing{"com": {Renewal: "10.00"}}
+	for _, tc := range []struct {
+		name   string
+		from   time.Time
+		expire time.Time
+	}{
+		// Already November in UTC, still October 31 in PST.
+		{"behind UTC", time.Date(2026, 10, 31, 20, 0, 0, 0, pst), time.Date(2026, 11, 1, 1, 0, 0, 0, time.UTC)},
+		// Still October 31 in UTC, already November in JST.
+		{"ahead of UTC", time.Date(2026, 11, 1, 1, 0, 0, 0, jst), time.Date(2026, 10, 31, 20, 0, 0, 0, time.UTC)},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			domains := []api.Domain{{Name: "a.com", TLD: "com", ExpireDate: tc.expire}}
+			f := NewForecast(domains, pricing, tc.from, 1)
+			var counted int
+			for _, m := range f.Months {
+				counted += m.Renewals
+			}
+			if counted != len(f.Renewals) {
+				t.Errorf("months count %d renewals, want %d", counted, len(f.Renewals))
+			}
+		})
+	}
+}
+
+func TestNewForecast_ClampsYears(t *testing.T) {
+	if f := NewForecast(nil, nil, forecastStart, 0); f.Years != MinForecastYears {
+		t.Errorf("Years = %d for 0, want %d", f.Years, MinForecastYears)
+	}
+	if f := NewForecast(nil, nil, forecastStart, 50); f.Years != MaxForecastYears {
+		t.Errorf("Years = %d for 50, want %d", f.Years, MaxForecastYears)
+	}
+}
+
+func TestForecast_GroupsByTLDAndLabel(t *testing.T) {
+	domains, pricing := forecastFixture()
+	f := NewForecast(domains, pricing, forecastStart, 2)
+
+	byTLD := f.Groups(ByTLD)
+	if byTLD[0].Key != "io" || !approx(byTLD[0].Total, 60) {
+		t.Errorf("top TLD = %s %.2f, want io 60.00", byTLD[0].Key, byTLD[0].Total)
+	}
+	if byTLD[1].Key != "com" || byTLD[1].Domains != 2 {
+		t.Errorf("second TLD = %s with %d domains, want com with 2", byTLD[1].Key, byTLD[1].Domains)
+	}
+
+	labels := map[string]GroupTotal{}
+	for _, g := range f.Groups(ByLabel) {
+		labels[g.Key] = g
+	}
+	// c.io carries two labels and counts toward both.
+	if !approx(labels["prod"].Total, 80) {
+		t.Errorf("prod total = %.2f, want 80.00 (a.com + c.io, two years)", labels["prod"].Total)
+	}
+	if !approx(labels["eu"].Total, 60) {
+		t.Errorf("eu total = %.2f, want 60.00", labels["eu"].Total)
+	}
+	if labels[UnlabeledGroup].Domains != 2 {
+		t.Errorf("unlabeled domains = %d, want 2", labels[UnlabeledGroup].Domains)
+	}
+}
+
+func TestForecast_WriteCSV(t *testing.T) {
+	domains, pricing := forecastFixture()
+	f := NewForecast(domains, pricing, forecastStart, 2)
+
+	var buf bytes.Buffer
+	if err := f.WriteCSV(&buf, ByTLD); err != nil {
+		t.Fatalf("WriteCSV: %v", err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not valid CSV: %v", err)
+	}
+	wantHeader := []string{"tld", "domains", "2026-01-01", "2027-01-01", "total"}
+	for i, h := range wantHeader {
+		if rows[0][i] != h {
+			t.Errorf("header[%d] = %q, want %q", i, rows[0][i], h)
+		}
+	}
+	if got := rows[1]; got[0] != "io" || got[4] != "60.00" {
+		t.Errorf("first row = %v, want io ... 60.00", got)
+	}
+
+	buf.Reset()
+	if err := f.WriteCSV(&buf, ByMonth); err != nil {
+		t.Fatalf("WriteCSV: %v", err)
+	}
+	rows, _ = csv.NewReader(&buf).ReadAll()
+	// header + 24 months + total
+	if len(rows) != 26 {
+		t.Fatalf("month CSV has %d rows, want 26", len(rows))
+	}
+	if last := rows[len(rows)-1]; last[0] != "total" || last[2] != "100.00" {
+		t.Errorf("total row = %v, want total ... 100.00", last)
+	}
+}
+
+func TestParseGrouping(t *testing.T) {
+	for _, g := range []Grouping{ByMonth, ByTLD, ByLabel} {
+		got, err := ParseGrouping(g.String())
+		if err != nil || got != g {
+			t.Errorf("ParseGrouping(%q) = %v, %v", g.String(), got, err)
+		}
+	}
+	if _, err := ParseGrouping("weekly"); err == nil {
+		t.Error("ParseGrouping(\"weekly\") returned nil error")
+	}
+}
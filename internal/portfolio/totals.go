@@ -0,0 +1,117 @@
+package portfolio
+
+import (
+	"sort"
+	"time"
+
+	"github.com/bc/porkbun-tui/internal/api"
+)
+
+// MonthTotal is the domains expiring in one calendar month and what
+// renewing them costs.
+type MonthTotal struct {
+	Year    int
+	Month   time.Month
+	Domains []api.Domain // by expiration date
+
+	// ChargeCost is what auto-renewing domains will be charged this month.
+	// Domains with auto-renew off are not charged but lapse unless renewed
+	// by hand; LapseCost is what renewing them would cost.
+	ChargeCost float64
+	LapseCost  float64
+	LapseCount int
+	Unpriced   int // domains whose TLD has no pricing
+}
+
+// OverBudget reports whether the month's auto-renew charges exceed budget;
+// a zero budget never does.
+func (m MonthTotal) OverBudget(budget float64) bool {
+	return budget > 0 && m.ChargeCost > budget
+}
+
+// Price recomputes the month's costs from pricing.
+func (m *MonthTotal) Price(pricing map[string]api.TLDPricing) {
+	m.ChargeCost, m.LapseCost, m.LapseCount, m.Unpriced = 0, 0, 0, 0
+	for _, d := range m.Domains {
+		price := RenewalPrice(pricing, d.TLD)
+		if price == 0 {
+			m.Unpriced++
+		}
+		if d.AutoRenew {
+			m.ChargeCost += price
+		} else {
+			m.LapseCost += price
+			m.LapseCount++
+		}
+	}
+}
+
+// ExpiringByMonth groups domains by the month they expire in, earliest
+// month first, and prices each month.
+func ExpiringByMonth(domains []api.Domain, pricing map[string]api.TLDPricing) []MonthTotal {
+	type monthKey struct {
+		year  int
+		month time.Month
+	}
+	byMonth := make(map[monthKey]*MonthTotal)
+	for _, d := range domains {
+		k := monthKey{d.ExpireDate.Year(), d.ExpireDate.Month()}
+		m, ok := byMonth[k]
+		if !ok {
+			m = &MonthTotal{Year: k.year, Month: k.month}
+			byMonth[k] = m
+		}
+		m.Domains = append(m.Domains, d)
+	}
+
+	months := make([]MonthTotal, 0, len(byMonth))
+	for _, m := range byMonth {
+		sort.Slice(m.Domains, func(i, j int) bool {
+			return m.Domains[i].ExpireDate.Before(m.Domains[j].ExpireDate)
+		})
+		m.Price(pricing)
+		months = append(months, *m)
+	}
+	sort.Slice(months, func(i, j int) bool {
+		if months[i].Year != months[j].Year {
+			return months[i].Year < months[j].Year
+		}
+		return months[i].Month < months[j].Month
+	})
+	return months
+}
+
+// TLDTotal is the domains owned in one TLD and their yearly renewal cost.
+type TLDTotal struct {
+	TLD          string
+	Domains      []api.Domain
+	RenewalPrice float64
+	TotalCost    float64
+}
+
+// CostByTLD groups domains by TLD, most expensive first; ties break on
+// the TLD so the order is deterministic even when prices are missing.
+func CostByTLD(domains []api.Domain, pricing map[string]api.TLDPricing) []TLDTotal {
+	byTLD := make(map[string][]api.Domain)
+	for _, d := range domains {
+		byTLD[d.TLD] = append(byTLD[d.TLD], d)
+	}
+
+	totals := make([]TLDTotal, 0, len(byTLD))
+	for tld, doms := range byTLD {
+		price := RenewalPrice(pricing, tld)
+		totals = append(totals, TLDTotal{
+			TLD:          tld,
+			Domains:      doms,
+			RenewalPrice: price,
+			TotalCost:    price * float64(len(doms)),
+		})
+	}
+	sort.Slice(totals, func(i, j int) bool {
+		if totals[i].TotalCost != totals[j].TotalCost {
+			return totals[i].TotalCost > totals[j].TotalCost
+		}
+		return totals[i].TLD < totals[j].TLD
+	})
+	return totals
+}
@@ -0,0 +1,57 @@
+package portfolio
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExpiringByMonth(t *testing.T) {
+	domains, pricing := forecastFixture()
+	domains[1].AutoRenew = true // b.com charged, a.com lapses
+
+	months := ExpiringByMonth(domains, pricing)
+
+	if len(months) != 3 {
+		t.Fatalf("got %d months, want 3", len(months))
+	}
+	march := months[0]
+	if march.Year != 2026 || march.Month != time.March || len(march.Domains) != 2 {
+		t.Fatalf("first month = %d-%v with %d domains", march.Year, march.Month, len(march.Domains))
+	}
+	if march.Domains[0].Name != "a.com" {
+		t.Errorf("domains not sorted by expiry: %s first", march.Domains[0].Name)
+	}
+	if !approx(march.ChargeCost, 10) || !approx(march.LapseCost, 10) || march.LapseCount != 1 {
+		t.Errorf("March = charge %.2f, lapse %.2f (%d)", march.ChargeCost, march.LapseCost, march.LapseCount)
+	}
+	if !march.OverBudget(5) || march.OverBudget(0) {
+		t.Error("OverBudget wrong for a $10 charge")
+	}
+	if months[2].Unpriced != 1 {
+		t.Errorf("xyz month Unpriced = %d, want 1", months[2].Unpriced)
+	}
+}
+
+func TestCostByTLD(t *testing.T) {
+	domains, pricing := forecastFixture()
+
+	tlds := CostByTLD(domains, pricing)
+
+	var got []string
+	for _, g := range tlds {
+		got = append(got, g.TLD)
+	}
+	// One .io outcosts two .com; unpriced xyz sorts last.
+	want := []string{"io", "com", "xyz"}
+	if len(got) != len(want) {
+		t.Fatalf("TLDs = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("TLDs = %v, want %v", got, want)
+		}
+	}
+	if !approx(tlds[0].TotalCost, 30) || !approx(tlds[1].TotalCost, 20) {
+		t.Errorf("costs = %.2f, %.2f", tlds[0].TotalCost, tlds[1].TotalCost)
+	}
+}
@@ -2,12 +2,26 @@ package tui
 
 import (
 	"context"
+	"crypto/x509"
 	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
 
+	"github.com/bc/porkbun-tui/internal/alerts"
 	"github.com/bc/porkbun-tui/internal/api"
 	"github.com/bc/porkbun-tui/internal/cache"
+	"github.com/bc/porkbun-tui/internal/certs"
+	"github.com/bc/porkbun-tui/internal/config"
 	"github.com/bc/porkbun-tui/internal/demo"
+	"github.com/bc/porkbun-tui/internal/dnsprovider"
+	"github.com/bc/porkbun-tui/internal/dnstemplate"
+	"github.com/bc/porkbun-tui/internal/ical"
 	"github.com/bc/porkbun-tui/internal/keys"
+	"github.com/bc/porkbun-tui/internal/nsmigrate"
+	"github.com/bc/porkbun-tui/internal/nspreset"
+	"github.com/bc/porkbun-tui/internal/portfolio"
 	"github.com/bc/porkbun-tui/internal/styles"
 	"github.com/bc/porkbun-tui/internal/tui/views"
 	"github.com/charmbracelet/bubbles/key"
@@ -23,8 +37,15 @@ const (
 	ViewDetail
 	ViewDNS
 	ViewNameservers
+	ViewSSL
+	ViewForwards
+	ViewGlue
+	ViewDNSSEC
+	ViewBulk
+	ViewMigrate
 	ViewAvailability
 	ViewTLD
+	ViewProviders
 	ViewCalendar
 	ViewHelp
 )
@@ -38,19 +59,47 @@ type App struct {
 	// Current view
 	view     View
 	prevView View
+	// detailReturn is where esc leaves DetailView: the domain list, or the
+	// calendar when the detail was opened from a calendar day.
+	detailReturn View
 
 	// Views
 	domainsView      *views.DomainsView
 	detailView       *views.DetailView
 	dnsView          *views.DNSView
 	nameserversView  *views.NameserversView
+	sslView          *views.SSLView
+	forwardsView     *views.ForwardsView
+	glueView         *views.GlueView
+	dnssecView       *views.DNSSECView
+	bulkView         *views.BulkView
+	migrateView      *views.MigrateView
 	availabilityView *views.AvailabilityView
 	tldView          *views.TLDView
+	providerView     *views.ProviderView
 	calendarView     *views.CalendarView
 	helpView         *views.HelpView
 
 	// Data
 	pricing map[string]api.TLDPricing
+	// dnssec is the known DNSSEC status by domain name. ListDomains doesn't
+	// report it, so it fills in as domains are opened or scanned.
+	dnssec         map[string]bool
+	dnssecScanning bool
+	// nameservers is the last known nameserver set by domain name, for
+	// telling DNS providers apart; providerRules do the telling.
+	nameservers   map[string][]string
+	providerRules []dnsprovider.Rule
+	nsScanning    bool
+	// nsUndo has, by domain name, the nameservers its last change replaced.
+	nsUndo map[string]cache.NSUndo
+	// bulkJob is the bulk action the bulk view is running.
+	bulkJob views.BulkRequest
+	// migration is the nameserver migration the migrate view is applying.
+	migration nsMigration
+
+	// Settings
+	calendarReminders []int // nil: exporter defaults
 
 	// State
 	loading    bool
@@ -70,10 +119,172 @@ type dnsLoadedMsg struct {
 }
 
 type nsLoadedMsg struct {
+	domain      string
 	nameservers []string
 }
 
-type nsSavedMsg struct{}
+// nsScannedMsg has the nameservers of each domain the scan could fetch.
+type nsScannedMsg struct {
+	nameservers map[string][]string
+}
+
+// nsCopiedMsg has the nameservers of from, to edit into domain's.
+type nsCopiedMsg struct {
+	domain string
+	from   string
+	ns     []string
+}
+
+// nsConflictMsg reports a save that wasn't made: the nameservers were
+// current when checked, not the ones the view loaded.
+type nsConflictMsg struct {
+	domain  string
+	current []string
+	yours   []string
+}
+
+// nsSavedMsg reports a completed nameserver change; previous is what it
+// replaced, for undo.
+type nsSavedMsg struct {
+	domain   string
+	previous []string
+	restored bool // the change was an undo
+}
+
+// sslLoadedMsg carries only the parsed chain: the bundle's private key
+// stays in the command that fetched it.
+type sslLoadedMsg struct {
+	chain []*x509.Certificate
+}
+
+type forwardsLoadedMsg struct {
+	forwards []api.URLForward
+}
+
+// forwardSavedMsg reports a completed add or delete.
+type forwardSavedMsg struct {
+	message string
+}
+
+type glueLoadedMsg struct {
+	hosts []api.GlueHost
+}
+
+// glueSavedMsg reports a completed create, update or delete.
+type glueSavedMsg struct {
+	message string
+}
+
+// dnssecLoadedMsg and dnssecErrMsg carry their domain: background checks
+// for the detail badge and the list filter share them with the view.
+type dnssecLoadedMsg struct {
+	domain  string
+	records []api.DSRecord
+}
+
+type dnssecErrMsg struct {
+	domain string
+	err    error
+}
+
+// dnssecSavedMsg reports a completed add or delete.
+type dnssecSavedMsg struct {
+	domain  string
+	message string
+}
+
+// dnssecScannedMsg has the status of each domain the scan could check.
+type dnssecScannedMsg struct {
+	status map[string]bool
+}
+
+// autoRenewResultMsg reports an auto-renew change the list already shows:
+// prev has each domain's flag from before, to roll back the ones in failed,
+// or all of them when err is set.
+type autoRenewResultMsg struct {
+	on     bool
+	prev   map[string]bool
+	failed map[string]error
+	err    error
+}
+
+// bulkStepMsg reports the bulk action on bulkJob.Domains[index].
+type bulkStepMsg struct {
+	index int
+	err   error
+}
+
+type bulkExportedMsg struct {
+	path string
+	err  error
+}
+
+// nsCurrentMsg has the current nameservers of the domains in a migration
+// preview; errs has the domains that couldn't be read.
+type nsCurrentMsg struct {
+	current map[string][]string
+	errs    map[string]error
+}
+
+// nsMigratedMsg reports one domain of a migration. previous is what the
+// worker re-read right before the change; changed means that differed from
+// the preview, so the domain was left alone.
+type nsMigratedMsg struct {
+	domain   string
+	previous []string
+	changed  bool
+	err      error
+}
+
+// nsRollbackReadyMsg reports the rollback file written ahead of a
+// migration; nothing is changed until it exists.
+type nsRollbackReadyMsg struct {
+	path string
+	err  error
+}
+
+type nsRollbackWrittenMsg struct {
+	path string
+	err  error
+}
+
+// migrateWorkers is how many domains a migration changes at once.
+const migrateWorkers = 3
+
+// nsMigration tracks a migration being applied: queue[next:] are still to
+// start, and previous has the nameservers of the domains changed so far.
+type nsMigration struct {
+	target   []string
+	queue    []string
+	next     int
+	inFlight int
+	previous map[string][]string
+	// rollback is the rollback file, written before the first change with
+	// every queued domain so quitting mid-run still leaves one.
+	rollback *migrationRollback
+}
+
+// migrationRollback is a migration's rollback file while workers update
+// it: each domain's entry becomes what was re-read right before changing
+// it, and a domain skipped for changing since the preview is dropped.
+type migrationRollback struct {
+	mu   sync.Mutex
+	path string // empty until the file is written
+	r    nsmigrate.Rollback
+}
+
+// set records ns as domain's previous nameservers, or drops domain when
+// ns is empty, and rewrites the file.
+func (f *migrationRollback) set(domain string, ns []string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(ns) == 0 {
+		delete(f.r.Previous, domain)
+	} else {
+		f.r.Previous[domain] = ns
+	}
+	return nsmigrate.UpdateRollback(f.path, f.r)
+}
 
 type availabilityResultMsg struct {
 	result *api.AvailabilityResult
@@ -107,10 +318,32 @@ type nsErrMsg struct {
 	err error
 }
 
+type sslErrMsg struct {
+	err error
+}
+
+type forwardsErrMsg struct {
+	err error
+}
+
+type glueErrMsg struct {
+	err error
+}
+
 type pricingLoadedMsg struct {
 	pricing map[string]api.TLDPricing
 }
 
+type forecastExportedMsg struct {
+	path string
+	err  error
+}
+
+type calendarExportedMsg struct {
+	path string
+	err  error
+}
+
 type errMsg struct {
 	err error
 }
@@ -131,30 +364,109 @@ func NewApp(client *api.Client, appCache *cache.Cache, cachedDomains []api.Domai
 	tldView := views.NewTLDView()
 	calendarView := views.NewCalendarView()
 
-	// If we have cached data, populate the views
+	// If we have cached data, populate the views. The TLD view takes pricing
+	// even without domains: its explorer lists every TLD Porkbun sells.
+	tldView.SetData(cachedDomains, cachedPricing)
+	calendarView.SetPricing(cachedPricing)
 	if hasCachedDomains {
-		tldView.SetData(cachedDomains, cachedPricing)
 		calendarView.SetDomains(cachedDomains)
 	}
 
-	return &App{
+	var dnssec map[string]bool
+	if demoMode {
+		dnssec = demo.DNSSEC()
+	} else if appCache != nil {
+		dnssec, _, _ = appCache.LoadDNSSEC()
+	}
+	if dnssec == nil {
+		dnssec = make(map[string]bool)
+	}
+	domainsView.SetDNSSEC(dnssec)
+	detailView := views.NewDetailView()
+
+	var nsUndo map[string]cache.NSUndo
+	if appCache != nil && !demoMode {
+		nsUndo, _ = appCache.LoadNSUndo()
+	}
+	if nsUndo == nil {
+		nsUndo = make(map[string]cache.NSUndo)
+	}
+	detailView.SetDNSSEC(dnssec)
+
+	var nameservers map[string][]string
+	if demoMode {
+		nameservers = demo.Nameservers()
+	} else if appCache != nil {
+		nameservers, _, _ = appCache.LoadNS()
+	}
+	if nameservers == nil {
+		nameservers = make(map[string][]string)
+	}
+
+	a := &App{
 		client:           client,
 		cache:            appCache,
 		view:             ViewDomains,
 		domainsView:      domainsView,
-		detailView:       views.NewDetailView(),
+		detailView:       detailView,
 		dnsView:          views.NewDNSView(),
 		nameserversView:  views.NewNameserversView(),
+		sslView:          views.NewSSLView(),
+		forwardsView:     views.NewForwardsView(),
+		glueView:         views.NewGlueView(),
+		dnssecView:       views.NewDNSSECView(),
+		bulkView:         views.NewBulkView(),
+		migrateView:      views.NewMigrateView(),
 		availabilityView: views.NewAvailabilityView(),
 		tldView:          tldView,
+		providerView:     views.NewProviderView(),
 		calendarView:     calendarView,
 		helpView:         views.NewHelpView(),
 		pricing:          cachedPricing,
+		dnssec:           dnssec,
+		nameservers:      nameservers,
+		providerRules:    dnsprovider.Builtin,
+		nsUndo:           nsUndo,
 		spinner:          s,
 		loading:          !hasCachedDomains && !demoMode, // Only show loading if no cached data and not demo
 		refreshing:       !demoMode,                      // Don't refresh in demo mode
 		demoMode:         demoMode,
 	}
+	a.refreshProviders()
+	return a
+}
+
+// SetConfig applies the non-credential settings from config.yaml to the
+// views. It fails on alert rules that don't compile.
+func (a *App) SetConfig(cfg *config.Config) error {
+	if cfg == nil {
+		return nil
+	}
+	rules, err := alerts.Compile(cfg.Alerts)
+	if err != nil {
+		return err
+	}
+	templates, err := dnstemplate.Compile(cfg.DNSTemplates)
+	if err != nil {
+		return err
+	}
+	presets, err := nspreset.Compile(cfg.NSPresets)
+	if err != nil {
+		return err
+	}
+	providerRules, err := dnsprovider.Compile(cfg.DNSProviders)
+	if err != nil {
+		return err
+	}
+	a.domainsView.SetAlertRules(rules)
+	a.bulkView.SetTemplates(templates)
+	a.nameserversView.SetPresets(presets)
+	a.migrateView.SetPresets(presets)
+	a.providerRules = providerRules
+	a.refreshProviders()
+	a.calendarView.SetBudget(cfg.MonthlyBudget)
+	a.calendarReminders = cfg.CalendarReminders
+	return nil
 }
 
 func (a *App) Init() tea.Cmd {
@@ -205,17 +517,555 @@ func (a *App) loadNameservers(domain string) tea.Cmd {
 		if err != nil {
 			return nsErrMsg{err}
 		}
-		return nsLoadedMsg{ns}
+		return nsLoadedMsg{domain, ns}
 	}
 }
 
-func (a *App) saveNameservers(domain string, ns []string) tea.Cmd {
+// saveNameservers sets domain's nameservers to ns, unless they are no
+// longer loaded (what the view showed when the edit began): then nothing is
+// written and the conflict goes back to the view.
+func (a *App) saveNameservers(domain string, ns, loaded []string, restored bool) tea.Cmd {
 	return func() tea.Msg {
-		err := a.client.UpdateNameservers(context.Background(), domain, ns)
+		ctx := context.Background()
+		current, err := a.client.GetNameservers(ctx, domain)
 		if err != nil {
 			return nsErrMsg{err}
 		}
-		return nsSavedMsg{}
+		// Order matters here: the editor can reorder rows, and a reorder
+		// made elsewhere is a change too.
+		if !nsmigrate.Equal(current, loaded) {
+			return nsConflictMsg{domain, current, ns}
+		}
+		if err := a.client.UpdateNameservers(ctx, domain, ns); err != nil {
+			return nsErrMsg{err}
+		}
+		if nsmigrate.Equal(current, ns) {
+			// Nothing changed, so keep the undo for the real change.
+			current = nil
+		}
+		return nsSavedMsg{domain, current, restored}
+	}
+}
+
+// copyNameservers fetches from's nameservers for domain's editor.
+func (a *App) copyNameservers(domain, from string) tea.Cmd {
+	return func() tea.Msg {
+		ns, err := a.client.GetNameservers(context.Background(), from)
+		if err != nil {
+			return nsErrMsg{fmt.Errorf("copying from %s: %w", from, err)}
+		}
+		return nsCopiedMsg{domain, from, ns}
+	}
+}
+
+// recordNSUndo keeps previous as what domain had before its last change.
+// Changes from an unknown set (the view never loaded) leave nothing to
+// undo.
+func (a *App) recordNSUndo(domain string, previous []string) {
+	if len(previous) == 0 {
+		return
+	}
+	a.nsUndo[domain] = cache.NSUndo{Previous: previous, ChangedAt: time.Now()}
+	if a.cache != nil {
+		_ = a.cache.SaveNSUndo(a.nsUndo)
+	}
+	if a.nameserversView.Domain() == domain {
+		a.nameserversView.SetUndo(previous, a.nsUndo[domain].ChangedAt)
+	}
+}
+
+// openNameservers shows domain's nameserver view and loads it.
+func (a *App) openNameservers(domain string) tea.Cmd {
+	a.nameserversView.SetDomain(domain)
+	var names []string
+	for _, d := range a.domainsView.GetDomains() {
+		names = append(names, d.Name)
+	}
+	a.nameserversView.SetDomainNames(names)
+	if u, ok := a.nsUndo[domain]; ok {
+		a.nameserversView.SetUndo(u.Previous, u.ChangedAt)
+	}
+	a.view = ViewNameservers
+	return a.loadNameservers(domain)
+}
+
+func (a *App) loadSSL(domain string) tea.Cmd {
+	return func() tea.Msg {
+		bundle, err := a.client.RetrieveSSLBundle(context.Background(), domain)
+		if err != nil {
+			return sslErrMsg{err}
+		}
+		chain, err := certs.ParseChain(bundle.CertificateChain)
+		if err != nil {
+			return sslErrMsg{err}
+		}
+		return sslLoadedMsg{chain}
+	}
+}
+
+func (a *App) loadForwards(domain string) tea.Cmd {
+	return func() tea.Msg {
+		forwards, err := a.client.GetURLForwards(context.Background(), domain)
+		if err != nil {
+			return forwardsErrMsg{err}
+		}
+		return forwardsLoadedMsg{forwards}
+	}
+}
+
+func (a *App) addForward(domain string, f api.URLForward) tea.Cmd {
+	return func() tea.Msg {
+		if err := a.client.AddURLForward(context.Background(), domain, f); err != nil {
+			return forwardsErrMsg{err}
+		}
+		return forwardSavedMsg{fmt.Sprintf("Forward to %s added.", f.Location)}
+	}
+}
+
+func (a *App) deleteForward(domain string, f api.URLForward) tea.Cmd {
+	return func() tea.Msg {
+		if err := a.client.DeleteURLForward(context.Background(), domain, f.ID); err != nil {
+			return forwardsErrMsg{err}
+		}
+		return forwardSavedMsg{fmt.Sprintf("Forward to %s deleted.", f.Location)}
+	}
+}
+
+func (a *App) loadGlue(domain string) tea.Cmd {
+	return func() tea.Msg {
+		hosts, err := a.client.GetGlueRecords(context.Background(), domain)
+		if err != nil {
+			return glueErrMsg{err}
+		}
+		return glueLoadedMsg{hosts}
+	}
+}
+
+// saveGlue sends a validated glue change; subdomain is relative to domain.
+func (a *App) saveGlue(domain, subdomain string, ips []string, r views.GlueRequest) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		host := subdomain + "." + domain
+		var err error
+		var done string
+		switch {
+		case r.Delete:
+			err, done = a.client.DeleteGlueRecord(ctx, domain, subdomain), "deleted"
+		case r.Update:
+			err, done = a.client.UpdateGlueRecord(ctx, domain, subdomain, ips), "updated"
+		default:
+			err, done = a.client.CreateGlueRecord(ctx, domain, subdomain, ips), "added"
+		}
+		if err != nil {
+			return glueErrMsg{err}
+		}
+		return glueSavedMsg{fmt.Sprintf("Glue record for %s %s.", host, done)}
+	}
+}
+
+func (a *App) loadDNSSEC(domain string) tea.Cmd {
+	return func() tea.Msg {
+		records, err := a.client.GetDSRecords(context.Background(), domain)
+		if err != nil {
+			return dnssecErrMsg{domain, err}
+		}
+		return dnssecLoadedMsg{domain, records}
+	}
+}
+
+// checkDNSSEC loads the domain's DNSSEC status for the detail badge unless
+// it is already known.
+func (a *App) checkDNSSEC(domain string) tea.Cmd {
+	if _, known := a.dnssec[domain]; known || a.demoMode {
+		return nil
+	}
+	return a.loadDNSSEC(domain)
+}
+
+// scanDNSSEC checks the DNSSEC status of domains for the list filter, a few
+// at a time. Domains that fail stay unchecked.
+func (a *App) scanDNSSEC(domains []string) tea.Cmd {
+	return func() tea.Msg {
+		var (
+			mu     sync.Mutex
+			wg     sync.WaitGroup
+			status = make(map[string]bool)
+			sem    = make(chan struct{}, 4)
+		)
+		for _, name := range domains {
+			wg.Add(1)
+			sem <- struct{}{}
+			go func(name string) {
+				defer wg.Done()
+				defer func() { <-sem }()
+				records, err := a.client.GetDSRecords(context.Background(), name)
+				if err != nil {
+					return
+				}
+				mu.Lock()
+				status[name] = len(records) > 0
+				mu.Unlock()
+			}(name)
+		}
+		wg.Wait()
+		return dnssecScannedMsg{status}
+	}
+}
+
+func (a *App) addDSRecord(domain string, r api.DSRecord) tea.Cmd {
+	return func() tea.Msg {
+		if err := a.client.CreateDSRecord(context.Background(), domain, r); err != nil {
+			return dnssecErrMsg{domain, err}
+		}
+		return dnssecSavedMsg{domain, fmt.Sprintf("DS record %s added.", r.KeyTag)}
+	}
+}
+
+func (a *App) deleteDSRecord(domain string, r api.DSRecord) tea.Cmd {
+	return func() tea.Msg {
+		if err := a.client.DeleteDSRecord(context.Background(), domain, r.KeyTag); err != nil {
+			return dnssecErrMsg{domain, err}
+		}
+		return dnssecSavedMsg{domain, fmt.Sprintf("DS record %s deleted.", r.KeyTag)}
+	}
+}
+
+// setDNSSEC records DNSSEC statuses, caches them and refreshes the views
+// that show them.
+func (a *App) setDNSSEC(status map[string]bool) {
+	for name, on := range status {
+		a.dnssec[name] = on
+	}
+	if a.cache != nil {
+		_ = a.cache.SaveDNSSEC(a.dnssec)
+	}
+	a.domainsView.SetDNSSEC(a.dnssec)
+	a.detailView.SetDNSSEC(a.dnssec)
+}
+
+// checkNameservers fetches the domain's nameservers for its DNS provider
+// unless they are already known or being scanned.
+func (a *App) checkNameservers(domain string) tea.Cmd {
+	if _, known := a.nameservers[domain]; known || a.demoMode || a.nsScanning {
+		return nil
+	}
+	return a.scanNameservers([]string{domain})
+}
+
+// checkDetail loads what DetailView shows beyond the domain list.
+func (a *App) checkDetail(domain string) tea.Cmd {
+	return tea.Batch(a.checkDNSSEC(domain), a.checkNameservers(domain))
+}
+
+// scanNameservers fetches the nameservers of domains for the DNS provider
+// column and view, a few at a time. Domains that fail stay unknown.
+func (a *App) scanNameservers(domains []string) tea.Cmd {
+	a.nsScanning = true
+	a.providerView.SetScanning(true)
+	return func() tea.Msg {
+		var (
+			mu          sync.Mutex
+			wg          sync.WaitGroup
+			nameservers = make(map[string][]string)
+			sem         = make(chan struct{}, 4)
+		)
+		for _, name := range domains {
+			wg.Add(1)
+			sem <- struct{}{}
+			go func(name string) {
+				defer wg.Done()
+				defer func() { <-sem }()
+				ns, err := a.client.GetNameservers(context.Background(), name)
+				if err != nil {
+					return
+				}
+				mu.Lock()
+				nameservers[name] = ns
+				mu.Unlock()
+			}(name)
+		}
+		wg.Wait()
+		return nsScannedMsg{nameservers}
+	}
+}
+
+// scanMissingNameservers scans the listed domains whose nameservers
+// aren't known yet.
+func (a *App) scanMissingNameservers() tea.Cmd {
+	if a.demoMode || a.nsScanning {
+		return nil
+	}
+	var missing []string
+	for _, d := range a.domainsView.GetDomains() {
+		if _, ok := a.nameservers[d.Name]; !ok {
+			missing = append(missing, d.Name)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	return a.scanNameservers(missing)
+}
+
+// setNameservers records nameserver sets, caches them and refreshes the
+// views that show their providers.
+func (a *App) setNameservers(nameservers map[string][]string) {
+	for name, ns := range nameservers {
+		a.nameservers[name] = ns
+	}
+	if a.cache != nil && !a.demoMode {
+		_ = a.cache.SaveNS(a.nameservers)
+	}
+	a.refreshProviders()
+}
+
+// refreshProviders classifies the known nameservers and hands the
+// providers to the views that show them.
+func (a *App) refreshProviders() {
+	providers := make(map[string]string, len(a.nameservers))
+	for name, ns := range a.nameservers {
+		if p := dnsprovider.Detect(a.providerRules, name, ns); p != "" {
+			providers[name] = p
+		}
+	}
+	a.domainsView.SetProviders(providers)
+	a.detailView.SetProviders(providers)
+	a.providerView.SetData(a.domainsView.GetDomains(), providers)
+}
+
+// setAutoRenew shows the change right away and sends it; the result rolls
+// back whatever the registry didn't take.
+func (a *App) setAutoRenew(domains []string, on bool) tea.Cmd {
+	status := make(map[string]bool, len(domains))
+	for _, name := range domains {
+		status[name] = on
+	}
+	prev := a.domainsView.SetAutoRenew(status)
+	a.domainsChanged()
+	return func() tea.Msg {
+		failed, err := a.client.SetAutoRenew(context.Background(), domains, on)
+		return autoRenewResultMsg{on, prev, failed, err}
+	}
+}
+
+// startBulk runs a confirmed bulk action. Per-domain actions go one
+// domain at a time, each step queuing the next; the client's limiter
+// spaces out their requests.
+func (a *App) startBulk(r views.BulkRequest) tea.Cmd {
+	a.bulkJob = r
+	switch r.Action {
+	case views.BulkAutoRenew:
+		// One call covers every domain.
+		return a.setAutoRenew(r.Domains, r.AutoRenew)
+	case views.BulkExport:
+		return a.exportDomains(r.Domains)
+	}
+	if len(r.Domains) == 0 {
+		a.bulkView.Finish("")
+		return nil
+	}
+	return a.runBulkStep(0)
+}
+
+func (a *App) runBulkStep(index int) tea.Cmd {
+	r := a.bulkJob
+	domain := r.Domains[index]
+	return func() tea.Msg {
+		ctx := context.Background()
+		var err error
+		switch r.Action {
+		case views.BulkDNSTemplate:
+			records := r.Template.For(domain)
+			for i, rec := range records {
+				if _, err = a.client.CreateDNSRecord(ctx, domain, rec); err != nil {
+					err = fmt.Errorf("%s record %d of %d: %w", rec.Type, i+1, len(records), err)
+					break
+				}
+			}
+		}
+		return bulkStepMsg{index, err}
+	}
+}
+
+// fetchCurrentNameservers reads the nameservers of every domain in a
+// migration, a few at a time.
+func (a *App) fetchCurrentNameservers(domains []string) tea.Cmd {
+	return func() tea.Msg {
+		var (
+			mu      sync.Mutex
+			wg      sync.WaitGroup
+			current = make(map[string][]string)
+			errs    = make(map[string]error)
+			sem     = make(chan struct{}, 4)
+		)
+		for _, name := range domains {
+			wg.Add(1)
+			sem <- struct{}{}
+			go func(name string) {
+				defer wg.Done()
+				defer func() { <-sem }()
+				ns, err := a.client.GetNameservers(context.Background(), name)
+				mu.Lock()
+				if err != nil {
+					errs[name] = err
+				} else {
+					current[name] = ns
+				}
+				mu.Unlock()
+			}(name)
+		}
+		wg.Wait()
+		return nsCurrentMsg{current, errs}
+	}
+}
+
+// startMigration applies the migration target to domains, up to
+// migrateWorkers at a time; each result starts the next domain.
+func (a *App) startMigration(domains []string) tea.Cmd {
+	a.migration = nsMigration{
+		target:   a.migrateView.Target(),
+		queue:    domains,
+		previous: make(map[string][]string),
+	}
+	if len(domains) == 0 {
+		return a.finishMigration()
+	}
+	// Every queued domain goes in the file up front: rolling back one that
+	// was never changed just sets the nameservers it already has.
+	r := nsmigrate.Rollback{Created: time.Now(), Target: a.migration.target, Previous: make(map[string][]string)}
+	for _, name := range domains {
+		// A domain without nameservers has nothing to put back.
+		if ns := a.migrateView.Current(name); len(ns) > 0 {
+			r.Previous[name] = ns
+		}
+	}
+	a.migration.rollback = &migrationRollback{r: r}
+	return func() tea.Msg {
+		path, err := nsmigrate.WriteRollback(".", r)
+		return nsRollbackReadyMsg{path, err}
+	}
+}
+
+// startMigrationWorkers sends the first few changes once the rollback file
+// is written.
+func (a *App) startMigrationWorkers() tea.Cmd {
+	var cmds []tea.Cmd
+	for range migrateWorkers {
+		if cmd := a.migrateNext(); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+	}
+	if len(cmds) == 0 {
+		return a.finishMigration()
+	}
+	return tea.Batch(cmds...)
+}
+
+// migrateNext starts the next queued domain, or returns nil when there is
+// none or the user stopped the run.
+func (a *App) migrateNext() tea.Cmd {
+	m := &a.migration
+	if m.next >= len(m.queue) || a.migrateView.IsStopped() {
+		return nil
+	}
+	domain, target, rollback := m.queue[m.next], m.target, m.rollback
+	previewed := a.migrateView.Current(domain)
+	m.next++
+	m.inFlight++
+	a.migrateView.SetApplying(domain)
+	return func() tea.Msg {
+		// Re-read first, like a nameserver save: a domain changed while the
+		// preview sat open isn't overwritten, and the rollback entry is
+		// what the domain really had.
+		ctx := context.Background()
+		current, err := a.client.GetNameservers(ctx, domain)
+		if err != nil {
+			return nsMigratedMsg{domain: domain, err: fmt.Errorf("re-reading nameservers: %w", err)}
+		}
+		if !nsmigrate.Equal(current, previewed) {
+			if err := rollback.set(domain, nil); err != nil {
+				return nsMigratedMsg{domain: domain, previous: current, changed: true,
+					err: fmt.Errorf("changed since the preview, but the rollback file still lists it: %w", err)}
+			}
+			return nsMigratedMsg{domain: domain, previous: current, changed: true}
+		}
+		if err := rollback.set(domain, current); err != nil {
+			return nsMigratedMsg{domain: domain, err: fmt.Errorf("updating the rollback file, nothing changed: %w", err)}
+		}
+		err = a.client.UpdateNameservers(ctx, domain, target)
+		return nsMigratedMsg{domain: domain, previous: current, err: err}
+	}
+}
+
+// finishMigration writes the rollback file for the domains that changed.
+func (a *App) finishMigration() tea.Cmd {
+	m := a.migration
+	if m.rollback == nil || m.rollback.path == "" {
+		a.migrateView.Finish("", nil)
+		return nil
+	}
+	if len(m.previous) == 0 {
+		// Nothing changed, so there is nothing to roll back.
+		return func() tea.Msg {
+			_ = os.Remove(m.rollback.path)
+			return nsRollbackWrittenMsg{}
+		}
+	}
+	// Narrow the file down to the domains that did change. Should that
+	// fail, the file written up front still covers every change.
+	r := nsmigrate.Rollback{Created: time.Now(), Target: m.target, Previous: m.previous}
+	return func() tea.Msg {
+		_ = nsmigrate.UpdateRollback(m.rollback.path, r)
+		return nsRollbackWrittenMsg{m.rollback.path, nil}
+	}
+}
+
+// exportDomains writes the named domains to a CSV in the working
+// directory, dated like the forecast export.
+func (a *App) exportDomains(names []string) tea.Cmd {
+	byName := make(map[string]api.Domain)
+	for _, d := range a.domainsView.GetDomains() {
+		byName[d.Name] = d
+	}
+	domains := make([]api.Domain, 0, len(names))
+	for _, name := range names {
+		domains = append(domains, byName[name])
+	}
+	return func() tea.Msg {
+		name := fmt.Sprintf("porkbun-domains-%s.csv", time.Now().Format("2006-01-02"))
+		path, err := filepath.Abs(name)
+		if err != nil {
+			return bulkExportedMsg{err: err}
+		}
+		file, err := os.Create(path)
+		if err != nil {
+			return bulkExportedMsg{err: err}
+		}
+		if err := portfolio.WriteDomainsCSV(file, domains); err != nil {
+			file.Close()
+			return bulkExportedMsg{err: err}
+		}
+		if err := file.Close(); err != nil {
+			return bulkExportedMsg{err: err}
+		}
+		return bulkExportedMsg{path: path}
+	}
+}
+
+// domainsChanged pushes a local edit of the domain list to the views that
+// copy it and to the cache.
+func (a *App) domainsChanged() {
+	domains := a.domainsView.GetDomains()
+	a.tldView.SetData(domains, a.pricing)
+	a.calendarView.SetDomains(domains)
+	a.refreshProviders()
+	if a.view == ViewDetail {
+		if d := a.domainsView.SelectedDomain(); d != nil {
+			a.detailView.SetDomain(d)
+		}
+	}
+	if a.cache != nil {
+		_ = a.cache.SaveDomains(domains)
 	}
 }
 
@@ -244,6 +1094,56 @@ func (a *App) purchaseDomain(domain string, costCents int) tea.Cmd {
 	}
 }
 
+// exportForecast writes the forecast CSV into the working directory, named
+// after the grouping and today's date so repeated exports don't collide
+// across days.
+func (a *App) exportForecast(f *portfolio.Forecast, g portfolio.Grouping) tea.Cmd {
+	return func() tea.Msg {
+		name := fmt.Sprintf("porkbun-forecast-%s-%s.csv", g, time.Now().Format("2006-01-02"))
+		path, err := filepath.Abs(name)
+		if err != nil {
+			return forecastExportedMsg{err: err}
+		}
+		file, err := os.Create(path)
+		if err != nil {
+			return forecastExportedMsg{err: err}
+		}
+		if err := f.WriteCSV(file, g); err != nil {
+			file.Close()
+			return forecastExportedMsg{err: err}
+		}
+		if err := file.Close(); err != nil {
+			return forecastExportedMsg{err: err}
+		}
+		return forecastExportedMsg{path: path}
+	}
+}
+
+// exportCalendar writes the expirations .ics into the working directory.
+// The name carries no date: event UIDs are stable, so the newest export
+// is a drop-in replacement for the last.
+func (a *App) exportCalendar(domains []api.Domain, pricing map[string]api.TLDPricing) tea.Cmd {
+	reminders := a.calendarReminders
+	return func() tea.Msg {
+		path, err := filepath.Abs("porkbun-expirations.ics")
+		if err != nil {
+			return calendarExportedMsg{err: err}
+		}
+		file, err := os.Create(path)
+		if err != nil {
+			return calendarExportedMsg{err: err}
+		}
+		if err := ical.Write(file, ical.Expirations(domains, pricing, reminders), time.Now()); err != nil {
+			file.Close()
+			return calendarExportedMsg{err: err}
+		}
+		if err := file.Close(); err != nil {
+			return calendarExportedMsg{err: err}
+		}
+		return calendarExportedMsg{path: path}
+	}
+}
+
 func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 
@@ -255,8 +1155,15 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		a.detailView.SetSize(msg.Width, msg.Height)
 		a.dnsView.SetSize(msg.Width, msg.Height)
 		a.nameserversView.SetSize(msg.Width, msg.Height)
+		a.sslView.SetSize(msg.Width, msg.Height)
+		a.forwardsView.SetSize(msg.Width, msg.Height)
+		a.glueView.SetSize(msg.Width, msg.Height)
+		a.dnssecView.SetSize(msg.Width, msg.Height)
+		a.bulkView.SetSize(msg.Width, msg.Height)
+		a.migrateView.SetSize(msg.Width, msg.Height)
 		a.availabilityView.SetSize(msg.Width, msg.Height)
 		a.tldView.SetSize(msg.Width, msg.Height)
+		a.providerView.SetSize(msg.Width, msg.Height)
 		a.calendarView.SetSize(msg.Width, msg.Height)
 		a.helpView.SetSize(msg.Width, msg.Height)
 
@@ -272,24 +1179,183 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		a.domainsView.SetDomains(msg.domains)
 		a.tldView.SetData(msg.domains, a.pricing)
 		a.calendarView.SetDomains(msg.domains)
+		a.refreshProviders()
 		// Save to cache
 		if a.cache != nil {
 			_ = a.cache.SaveDomains(msg.domains)
 		}
+		// The DNS provider column needs every domain's nameservers.
+		if cmd := a.scanMissingNameservers(); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
 
 	case dnsLoadedMsg:
 		a.dnsView.SetRecords(msg.records)
 
 	case nsLoadedMsg:
-		a.nameserversView.SetNameservers(msg.nameservers)
+		a.setNameservers(map[string][]string{msg.domain: msg.nameservers})
+		if a.nameserversView.Domain() == msg.domain {
+			a.nameserversView.SetNameservers(msg.nameservers)
+		}
+
+	case nsScannedMsg:
+		a.nsScanning = false
+		a.providerView.SetScanning(false)
+		a.setNameservers(msg.nameservers)
+
+	case nsCopiedMsg:
+		if a.nameserversView.Domain() == msg.domain {
+			a.nameserversView.SetCopied(msg.from, msg.ns)
+		}
+
+	case nsConflictMsg:
+		if a.nameserversView.Domain() == msg.domain {
+			a.nameserversView.SetConflict(msg.current, msg.yours)
+		}
 
 	case nsSavedMsg:
-		a.nameserversView.SetSuccess("Nameservers updated successfully!")
+		a.recordNSUndo(msg.domain, msg.previous)
+		if msg.restored {
+			a.nameserversView.SetSuccess("Nameservers restored.")
+		} else {
+			a.nameserversView.SetSuccess("Nameservers updated successfully!")
+		}
 		// Reload nameservers to confirm
 		if d := a.domainsView.SelectedDomain(); d != nil {
 			cmds = append(cmds, a.loadNameservers(d.Name))
 		}
 
+	case sslLoadedMsg:
+		a.sslView.SetChain(msg.chain)
+
+	case forwardsLoadedMsg:
+		a.forwardsView.SetForwards(msg.forwards)
+
+	case forwardSavedMsg:
+		a.forwardsView.SetSuccess(msg.message)
+		// Reload to show the change as Porkbun has it
+		if d := a.domainsView.SelectedDomain(); d != nil {
+			cmds = append(cmds, a.loadForwards(d.Name))
+		}
+
+	case glueLoadedMsg:
+		a.glueView.SetHosts(msg.hosts)
+
+	case glueSavedMsg:
+		a.glueView.SetSuccess(msg.message)
+		if d := a.domainsView.SelectedDomain(); d != nil {
+			cmds = append(cmds, a.loadGlue(d.Name))
+		}
+
+	case dnssecLoadedMsg:
+		a.setDNSSEC(map[string]bool{msg.domain: len(msg.records) > 0})
+		if a.dnssecView.Domain() == msg.domain {
+			a.dnssecView.SetRecords(msg.records)
+		}
+
+	case dnssecSavedMsg:
+		if a.dnssecView.Domain() == msg.domain {
+			a.dnssecView.SetSuccess(msg.message)
+		}
+		cmds = append(cmds, a.loadDNSSEC(msg.domain))
+
+	case dnssecScannedMsg:
+		a.dnssecScanning = false
+		a.setDNSSEC(msg.status)
+
+	case autoRenewResultMsg:
+		rollback := make(map[string]bool)
+		for name, was := range msg.prev {
+			if _, failed := msg.failed[name]; failed || msg.err != nil {
+				rollback[name] = was
+			}
+		}
+		if len(rollback) > 0 {
+			a.domainsView.SetAutoRenew(rollback)
+			a.domainsChanged()
+		}
+		if a.bulkView.IsRunning() && a.bulkJob.Action == views.BulkAutoRenew {
+			// The progress list shows the failures.
+			for _, name := range a.bulkJob.Domains {
+				err := msg.err
+				if err == nil {
+					err = msg.failed[name]
+				}
+				a.bulkView.SetResult(name, err)
+			}
+			a.bulkView.Finish("")
+			break
+		}
+		switch {
+		case msg.err != nil:
+			a.err = fmt.Errorf("auto-renew not changed: %w", msg.err)
+		case len(msg.failed) == 1:
+			for name, err := range msg.failed {
+				a.err = fmt.Errorf("auto-renew not changed for %s: %w", name, err)
+			}
+		case len(msg.failed) > 1:
+			a.err = fmt.Errorf("auto-renew not changed for %d of %d domains", len(msg.failed), len(msg.prev))
+		}
+
+	case bulkStepMsg:
+		a.bulkView.SetResult(a.bulkJob.Domains[msg.index], msg.err)
+		if next := msg.index + 1; next < len(a.bulkJob.Domains) && !a.bulkView.IsStopped() {
+			cmds = append(cmds, a.runBulkStep(next))
+		} else {
+			a.bulkView.Finish("")
+		}
+
+	case bulkExportedMsg:
+		for _, name := range a.bulkJob.Domains {
+			a.bulkView.SetResult(name, msg.err)
+		}
+		note := ""
+		if msg.err == nil {
+			note = "Exported to " + msg.path
+		}
+		a.bulkView.Finish(note)
+
+	case nsCurrentMsg:
+		a.migrateView.SetCurrent(msg.current, msg.errs)
+		a.setNameservers(msg.current)
+
+	case nsMigratedMsg:
+		a.migration.inFlight--
+		switch {
+		case msg.changed:
+			a.setNameservers(map[string][]string{msg.domain: msg.previous})
+			a.migrateView.SetChanged(msg.domain, msg.previous, msg.err)
+		case msg.err == nil:
+			if len(msg.previous) > 0 { // no nameservers: nothing to put back
+				a.migration.previous[msg.domain] = msg.previous
+			}
+			a.recordNSUndo(msg.domain, msg.previous)
+			a.setNameservers(map[string][]string{msg.domain: a.migration.target})
+			a.migrateView.SetResult(msg.domain, nil)
+		default:
+			a.migrateView.SetResult(msg.domain, msg.err)
+		}
+		if cmd := a.migrateNext(); cmd != nil {
+			cmds = append(cmds, cmd)
+		} else if a.migration.inFlight == 0 {
+			cmds = append(cmds, a.finishMigration())
+		}
+
+	case nsRollbackReadyMsg:
+		if msg.err != nil {
+			a.migrateView.Finish("", fmt.Errorf("rollback file not written, nothing changed: %w", msg.err))
+			break
+		}
+		a.migration.rollback.path = msg.path
+		cmds = append(cmds, a.startMigrationWorkers())
+
+	case nsRollbackWrittenMsg:
+		if msg.err != nil {
+			a.migrateView.Finish("", fmt.Errorf("rollback file not written: %w", msg.err))
+		} else {
+			a.migrateView.Finish(msg.path, nil)
+		}
+
 	case availabilityResultMsg:
 		a.availabilityView.SetResult(msg.result)
 
@@ -309,16 +1375,20 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case pricingLoadedMsg:
 		a.pricing = msg.pricing
-		// Update TLD view with new pricing
-		domains := a.domainsView.GetDomains()
-		if len(domains) > 0 {
-			a.tldView.SetData(domains, a.pricing)
-		}
+		// Update TLD and calendar views with new pricing
+		a.tldView.SetData(a.domainsView.GetDomains(), a.pricing)
+		a.calendarView.SetPricing(a.pricing)
 		// Save to cache
 		if a.cache != nil {
 			_ = a.cache.SavePricing(msg.pricing)
 		}
 
+	case forecastExportedMsg:
+		a.tldView.SetExportResult(msg.path, msg.err)
+
+	case calendarExportedMsg:
+		a.calendarView.SetExportResult(msg.path, msg.err)
+
 	case errMsg:
 		a.err = msg.err
 		a.loading = false
@@ -330,6 +1400,21 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case nsErrMsg:
 		a.nameserversView.SetError(msg.err)
 
+	case sslErrMsg:
+		a.sslView.SetError(msg.err)
+
+	case forwardsErrMsg:
+		a.forwardsView.SetError(msg.err)
+
+	case glueErrMsg:
+		a.glueView.SetError(msg.err)
+
+	case dnssecErrMsg:
+		// A failed background check leaves the badge unknown.
+		if a.dnssecView.Domain() == msg.domain {
+			a.dnssecView.SetError(msg.err)
+		}
+
 	case tea.KeyMsg:
 		// ctrl+c always quits, even in contexts that capture other keys.
 		if msg.String() == "ctrl+c" {
@@ -376,10 +1461,24 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return a.updateDNS(msg)
 		case ViewNameservers:
 			return a.updateNameservers(msg)
+		case ViewSSL:
+			return a.updateSSL(msg)
+		case ViewForwards:
+			return a.updateForwards(msg)
+		case ViewGlue:
+			return a.updateGlue(msg)
+		case ViewDNSSEC:
+			return a.updateDNSSEC(msg)
+		case ViewBulk:
+			return a.updateBulk(msg)
+		case ViewMigrate:
+			return a.updateMigrate(msg)
 		case ViewAvailability:
 			return a.updateAvailability(msg)
 		case ViewTLD:
 			return a.updateTLD(msg)
+		case ViewProviders:
+			return a.updateProviders(msg)
 		case ViewCalendar:
 			return a.updateCalendar(msg)
 		case ViewHelp:
@@ -399,18 +1498,46 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 func (a *App) viewCapturesKeys() bool {
 	switch a.view {
 	case ViewDomains:
-		return a.domainsView.IsSearching()
+		return a.domainsView.IsSearching() || a.domainsView.IsConfirmingAutoRenew()
+	case ViewDetail:
+		return a.detailView.IsConfirming()
 	case ViewAvailability:
 		// The domain input is always focused, and the buy confirmation
 		// must swallow everything except y/n/esc.
 		return true
 	case ViewNameservers:
-		return a.nameserversView.IsEditing()
+		return a.nameserversView.IsEditing() || a.nameserversView.IsConfirming()
+	case ViewTLD:
+		return a.tldView.IsSearching()
+	case ViewForwards:
+		return a.forwardsView.IsEditing()
+	case ViewGlue:
+		return a.glueView.IsEditing()
+	case ViewDNSSEC:
+		return a.dnssecView.IsEditing()
+	case ViewBulk:
+		return a.bulkView.IsEditing()
+	case ViewMigrate:
+		// q mid-run would quit before the rollback file is narrowed down
+		// to what changed; esc stops instead.
+		return a.migrateView.IsEditing() || a.migrateView.IsBusy()
 	}
 	return false
 }
 
 func (a *App) updateDomains(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// The bulk auto-renew prompt captures every key.
+	if a.domainsView.IsConfirmingAutoRenew() {
+		switch msg.String() {
+		case "o", "f":
+			a.domainsView.CancelAutoRenewConfirmation()
+			return a, a.setAutoRenew(a.domainsView.ShownDomains(), msg.String() == "o")
+		case "esc", "n":
+			a.domainsView.CancelAutoRenewConfirmation()
+		}
+		return a, nil
+	}
+
 	// Skip command keys when searching
 	if !a.domainsView.IsSearching() {
 		switch {
@@ -418,6 +1545,8 @@ func (a *App) updateDomains(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			if d := a.domainsView.SelectedDomain(); d != nil {
 				a.detailView.SetDomain(d)
 				a.view = ViewDetail
+				a.detailReturn = ViewDomains
+				return a, a.checkDetail(d.Name)
 			}
 			return a, nil
 
@@ -437,9 +1566,7 @@ func (a *App) updateDomains(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				return a, nil // No NS view in demo mode
 			}
 			if d := a.domainsView.SelectedDomain(); d != nil {
-				a.nameserversView.SetDomain(d.Name)
-				a.view = ViewNameservers
-				return a, a.loadNameservers(d.Name)
+				return a, a.openNameservers(d.Name)
 			}
 			return a, nil
 
@@ -452,10 +1579,31 @@ func (a *App) updateDomains(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			a.view = ViewTLD
 			return a, nil
 
+		case key.Matches(msg, keys.Keys.Providers):
+			a.view = ViewProviders
+			return a, a.scanMissingNameservers()
+
 		case key.Matches(msg, keys.Keys.Calendar):
 			a.view = ViewCalendar
 			return a, nil
 
+		case key.Matches(msg, keys.Keys.AutoRenew):
+			if a.demoMode {
+				return a, nil // No auto-renew changes in demo mode
+			}
+			a.domainsView.StartAutoRenewConfirmation()
+			return a, nil
+
+		case key.Matches(msg, keys.Keys.Bulk):
+			if a.demoMode {
+				return a, nil // No bulk actions in demo mode
+			}
+			if marked := a.domainsView.Marked(); len(marked) > 0 {
+				a.bulkView.Open(marked)
+				a.view = ViewBulk
+			}
+			return a, nil
+
 		case key.Matches(msg, keys.Keys.Refresh):
 			if a.demoMode {
 				return a, nil // No refresh in demo mode
@@ -467,13 +1615,35 @@ func (a *App) updateDomains(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	var cmd tea.Cmd
 	a.domainsView, cmd = a.domainsView.Update(msg)
+
+	// Filtering by DNSSEC needs every domain's status.
+	if a.domainsView.DNSSECFilter() != views.DNSSECAny && !a.dnssecScanning && !a.demoMode {
+		if unchecked := a.domainsView.UncheckedDNSSEC(); len(unchecked) > 0 {
+			a.dnssecScanning = true
+			return a, tea.Batch(cmd, a.scanDNSSEC(unchecked))
+		}
+	}
 	return a, cmd
 }
 
 func (a *App) updateDetail(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// The auto-renew prompt captures every key.
+	if a.detailView.IsConfirming() {
+		switch msg.String() {
+		case "y":
+			a.detailView.CancelAutoRenewConfirmation()
+			if d := a.domainsView.SelectedDomain(); d != nil {
+				return a, a.setAutoRenew([]string{d.Name}, !d.AutoRenew)
+			}
+		case "n", "esc":
+			a.detailView.CancelAutoRenewConfirmation()
+		}
+		return a, nil
+	}
+
 	switch {
 	case key.Matches(msg, keys.Keys.Back):
-		a.view = ViewDomains
+		a.view = a.detailReturn
 		return a, nil
 
 	case key.Matches(msg, keys.Keys.DNS):
@@ -492,9 +1662,47 @@ func (a *App) updateDetail(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			return a, nil // No NS view in demo mode
 		}
 		if d := a.domainsView.SelectedDomain(); d != nil {
-			a.nameserversView.SetDomain(d.Name)
-			a.view = ViewNameservers
-			return a, a.loadNameservers(d.Name)
+			return a, a.openNameservers(d.Name)
+		}
+		return a, nil
+
+	case key.Matches(msg, keys.Keys.SSL):
+		if a.demoMode {
+			return a, nil // No SSL view in demo mode
+		}
+		if d := a.domainsView.SelectedDomain(); d != nil {
+			a.sslView.SetDomain(d.Name)
+			a.view = ViewSSL
+			return a, a.loadSSL(d.Name)
+		}
+		return a, nil
+
+	case key.Matches(msg, keys.Keys.Forwards):
+		if a.demoMode {
+			return a, nil // No forwards view in demo mode
+		}
+		if d := a.domainsView.SelectedDomain(); d != nil {
+			a.forwardsView.SetDomain(d.Name)
+			a.view = ViewForwards
+			return a, a.loadForwards(d.Name)
+		}
+		return a, nil
+
+	case key.Matches(msg, keys.Keys.AutoRenew):
+		if a.demoMode {
+			return a, nil // No auto-renew changes in demo mode
+		}
+		a.detailView.StartAutoRenewConfirmation()
+		return a, nil
+
+	case key.Matches(msg, keys.Keys.DNSSEC):
+		if a.demoMode {
+			return a, nil // No DNSSEC view in demo mode
+		}
+		if d := a.domainsView.SelectedDomain(); d != nil {
+			a.dnssecView.SetDomain(d.Name)
+			a.view = ViewDNSSEC
+			return a, a.loadDNSSEC(d.Name)
 		}
 		return a, nil
 
@@ -503,6 +1711,7 @@ func (a *App) updateDetail(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		a.domainsView, _ = a.domainsView.Update(msg)
 		if d := a.domainsView.SelectedDomain(); d != nil {
 			a.detailView.SetDomain(d)
+			return a, a.checkDetail(d.Name)
 		}
 		return a, nil
 	}
@@ -522,7 +1731,9 @@ func (a *App) updateDNS(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 }
 
 func (a *App) updateNameservers(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	if key.Matches(msg, keys.Keys.Back) && !a.nameserversView.IsSaving() {
+	// The confirmations and the copy picker handle esc themselves.
+	if key.Matches(msg, keys.Keys.Back) && !a.nameserversView.IsSaving() &&
+		!a.nameserversView.IsConfirming() && !a.nameserversView.IsPickingDomain() {
 		a.view = ViewDomains
 		return a, nil
 	}
@@ -535,9 +1746,177 @@ func (a *App) updateNameservers(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	// in-flight window and would re-fire on every keypress.
 	if a.nameserversView.TakeSaveRequest() {
 		if d := a.domainsView.SelectedDomain(); d != nil {
-			ns := a.nameserversView.GetNameservers()
-			return a, a.saveNameservers(d.Name, ns)
+			ns, err := api.NormalizeNameservers(a.nameserversView.GetNameservers())
+			if err != nil {
+				a.nameserversView.SetError(err)
+				return a, nil
+			}
+			return a, a.saveNameservers(d.Name, ns, a.nameserversView.Nameservers(), false)
+		}
+	}
+	if ns, ok := a.nameserversView.TakeUndoRequest(); ok {
+		if d := a.domainsView.SelectedDomain(); d != nil {
+			return a, a.saveNameservers(d.Name, ns, a.nameserversView.Nameservers(), true)
+		}
+	}
+	if from, ok := a.nameserversView.TakeCopyRequest(); ok {
+		return a, a.copyNameservers(a.nameserversView.Domain(), from)
+	}
+	if current, yours, ok := a.nameserversView.TakeOverwriteRequest(); ok {
+		if d := a.domainsView.SelectedDomain(); d != nil {
+			// Checked against what was seen in the conflict, so a change
+			// made since then is caught too.
+			return a, a.saveNameservers(d.Name, yours, current, false)
+		}
+	}
+	if a.nameserversView.TakeGlueRequest() {
+		if d := a.domainsView.SelectedDomain(); d != nil {
+			a.glueView.SetDomain(d.Name, a.nameserversView.Nameservers())
+			a.view = ViewGlue
+			return a, a.loadGlue(d.Name)
+		}
+	}
+
+	return a, cmd
+}
+
+// updateSSL returns to the detail view the certificate was opened from.
+func (a *App) updateSSL(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if key.Matches(msg, keys.Keys.Back) {
+		a.view = ViewDetail
+	}
+	return a, nil
+}
+
+func (a *App) updateForwards(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// The add form and the delete prompt handle esc themselves.
+	if key.Matches(msg, keys.Keys.Back) && !a.forwardsView.IsEditing() && !a.forwardsView.IsSaving() {
+		a.view = ViewDetail
+		return a, nil
+	}
+
+	var cmd tea.Cmd
+	a.forwardsView, cmd = a.forwardsView.Update(msg)
+
+	d := a.domainsView.SelectedDomain()
+	if f, ok := a.forwardsView.TakeAddRequest(); ok && d != nil {
+		if err := api.ValidateURLForward(f); err != nil {
+			a.forwardsView.SetError(err)
+			return a, nil
 		}
+		return a, a.addForward(d.Name, f)
+	}
+	if f, ok := a.forwardsView.TakeDeleteRequest(); ok && d != nil {
+		return a, a.deleteForward(d.Name, f)
+	}
+
+	return a, cmd
+}
+
+func (a *App) updateGlue(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// Forms and the delete prompt handle esc themselves.
+	if key.Matches(msg, keys.Keys.Back) && !a.glueView.IsEditing() && !a.glueView.IsSaving() {
+		a.view = ViewNameservers
+		return a, nil
+	}
+
+	var cmd tea.Cmd
+	a.glueView, cmd = a.glueView.Update(msg)
+
+	if r, ok := a.glueView.TakeRequest(); ok {
+		d := a.domainsView.SelectedDomain()
+		if d == nil {
+			return a, nil
+		}
+		sub, err := api.GlueSubdomain(r.Host, d.Name)
+		if err != nil {
+			a.glueView.SetError(err)
+			return a, nil
+		}
+		var ips []string
+		if !r.Delete {
+			if ips, err = api.NormalizeGlueIPs(r.IPs); err != nil {
+				a.glueView.SetError(err)
+				return a, nil
+			}
+		}
+		return a, a.saveGlue(d.Name, sub, ips, r)
+	}
+
+	return a, cmd
+}
+
+func (a *App) updateDNSSEC(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// The add form and the delete prompt handle esc themselves.
+	if key.Matches(msg, keys.Keys.Back) && !a.dnssecView.IsEditing() && !a.dnssecView.IsSaving() {
+		a.view = ViewDetail
+		return a, nil
+	}
+
+	var cmd tea.Cmd
+	a.dnssecView, cmd = a.dnssecView.Update(msg)
+
+	domain := a.dnssecView.Domain()
+	if text, ok := a.dnssecView.TakeAddRequest(); ok {
+		r, err := api.ParseDSRecord(text)
+		if err != nil {
+			a.dnssecView.SetError(err)
+			return a, nil
+		}
+		return a, a.addDSRecord(domain, r)
+	}
+	if r, ok := a.dnssecView.TakeDeleteRequest(); ok {
+		return a, a.deleteDSRecord(domain, r)
+	}
+
+	return a, cmd
+}
+
+func (a *App) updateBulk(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// Pick lists and the confirmation handle esc themselves; while running
+	// it stops the run.
+	if key.Matches(msg, keys.Keys.Back) && !a.bulkView.IsEditing() && !a.bulkView.IsRunning() {
+		a.view = ViewDomains
+		return a, nil
+	}
+
+	var cmd tea.Cmd
+	a.bulkView, cmd = a.bulkView.Update(msg)
+
+	if r, ok := a.bulkView.TakeRequest(); ok {
+		if r.Action == views.BulkNameservers {
+			a.migrateView.Open(r.Domains)
+			a.view = ViewMigrate
+			return a, nil
+		}
+		return a, a.startBulk(r)
+	}
+
+	return a, cmd
+}
+
+func (a *App) updateMigrate(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// The custom input and the preview go back a step on esc; while
+	// applying it stops starting new domains.
+	if key.Matches(msg, keys.Keys.Back) && !a.migrateView.IsEditing() && !a.migrateView.IsBusy() {
+		a.view = ViewDomains
+		return a, nil
+	}
+
+	var cmd tea.Cmd
+	a.migrateView, cmd = a.migrateView.Update(msg)
+
+	if ns, ok := a.migrateView.TakeTargetRequest(); ok {
+		target, err := api.NormalizeNameservers(ns)
+		if err != nil {
+			a.migrateView.SetError(err)
+			return a, nil
+		}
+		a.migrateView.SetTarget(target)
+		return a, a.fetchCurrentNameservers(a.migrateView.Domains())
+	}
+	if domains, ok := a.migrateView.TakeApplyRequest(); ok {
+		return a, a.startMigration(domains)
 	}
 
 	return a, cmd
@@ -590,24 +1969,67 @@ func (a *App) updateAvailability(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 }
 
 func (a *App) updateTLD(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	if key.Matches(msg, keys.Keys.Back) {
+	// While searching the explorer, esc only closes the search input.
+	if key.Matches(msg, keys.Keys.Back) && !a.tldView.IsSearching() {
 		a.view = ViewDomains
 		return a, nil
 	}
 
 	var cmd tea.Cmd
 	a.tldView, cmd = a.tldView.Update(msg)
+
+	if a.tldView.TakeExportRequest() {
+		return a, a.exportForecast(a.tldView.Forecast(), a.tldView.ForecastGrouping())
+	}
+
 	return a, cmd
 }
 
-func (a *App) updateCalendar(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+func (a *App) updateProviders(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	if key.Matches(msg, keys.Keys.Back) {
 		a.view = ViewDomains
 		return a, nil
 	}
 
+	var cmd tea.Cmd
+	a.providerView, cmd = a.providerView.Update(msg)
+
+	if a.providerView.TakeRescanRequest() && !a.demoMode && !a.nsScanning {
+		var names []string
+		for _, d := range a.domainsView.GetDomains() {
+			names = append(names, d.Name)
+		}
+		if len(names) > 0 {
+			return a, a.scanNameservers(names)
+		}
+	}
+
+	return a, cmd
+}
+
+func (a *App) updateCalendar(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// With a day's domain list open, esc only closes the list.
+	if key.Matches(msg, keys.Keys.Back) && !a.calendarView.IsDayOpen() {
+		a.view = ViewDomains
+		return a, nil
+	}
+
 	var cmd tea.Cmd
 	a.calendarView, cmd = a.calendarView.Update(msg)
+
+	if d, ok := a.calendarView.TakeDetailRequest(); ok {
+		// Keep the list selection on the same domain so d/n/j/k in the
+		// detail view act on what is shown.
+		a.domainsView.SelectDomain(d.Name)
+		a.detailView.SetDomain(d)
+		a.view = ViewDetail
+		a.detailReturn = ViewCalendar
+		return a, a.checkDetail(d.Name)
+	}
+	if a.calendarView.TakeExportRequest() {
+		return a, a.exportCalendar(a.calendarView.Domains(), a.pricing)
+	}
+
 	return a, cmd
 }
 
@@ -630,10 +2052,24 @@ func (a *App) View() string {
 			content = a.dnsView.View()
 		case ViewNameservers:
 			content = a.nameserversView.View()
+		case ViewSSL:
+			content = a.sslView.View()
+		case ViewForwards:
+			content = a.forwardsView.View()
+		case ViewGlue:
+			content = a.glueView.View()
+		case ViewDNSSEC:
+			content = a.dnssecView.View()
+		case ViewBulk:
+			content = a.bulkView.View()
+		case ViewMigrate:
+			content = a.migrateView.View()
 		case ViewAvailability:
 			content = a.availabilityView.View()
 		case ViewTLD:
 			content = a.tldView.View()
+		case ViewProviders:
+			content = a.providerView.View()
 		case ViewCalendar:
 			content = a.calendarView.View()
 		case ViewHelp:
@@ -672,10 +2108,24 @@ func (a *App) statusBar() string {
 		status = a.dnsView.StatusText()
 	case ViewNameservers:
 		status = a.nameserversView.StatusText()
+	case ViewSSL:
+		status = a.sslView.StatusText()
+	case ViewForwards:
+		status = a.forwardsView.StatusText()
+	case ViewGlue:
+		status = a.glueView.StatusText()
+	case ViewDNSSEC:
+		status = a.dnssecView.StatusText()
+	case ViewBulk:
+		status = a.bulkView.StatusText()
+	case ViewMigrate:
+		status = a.migrateView.StatusText()
 	case ViewAvailability:
 		status = a.availabilityView.StatusText()
 	case ViewTLD:
 		status = a.tldView.StatusText()
+	case ViewProviders:
+		status = a.providerView.StatusText()
 	case ViewCalendar:
 		status = a.calendarView.StatusText()
 	case ViewHelp:
@@ -706,10 +2156,24 @@ func (a *App) helpBar() string {
 		help = a.dnsView.HelpText()
 	case ViewNameservers:
 		help = a.nameserversView.HelpText()
+	case ViewSSL:
+		help = a.sslView.HelpText()
+	case ViewForwards:
+		help = a.forwardsView.HelpText()
+	case ViewGlue:
+		help = a.glueView.HelpText()
+	case ViewDNSSEC:
+		help = a.dnssecView.HelpText()
+	case ViewBulk:
+		help = a.bulkView.HelpText()
+	case ViewMigrate:
+		help = a.migrateView.HelpText()
 	case ViewAvailability:
 		help = a.availabilityView.HelpText()
 	case ViewTLD:
 		help = a.tldView.HelpText()
+	case ViewProviders:
+		help = a.providerView.HelpText()
 	case ViewCalendar:
 		help = a.calendarView.HelpText()
 	case ViewHelp:
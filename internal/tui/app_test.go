@@ -4,12 +4,18 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/bc/porkbun-tui/internal/api"
 	"github.com/bc/porkbun-tui/internal/config"
+	"github.com/bc/porkbun-tui/internal/dnsprovider"
+	"github.com/bc/porkbun-tui/internal/nsmigrate"
+	"github.com/bc/porkbun-tui/internal/nspreset"
+	"github.com/bc/porkbun-tui/internal/tui/views"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
@@ -666,7 +672,7 @@ func TestSecondCtrlSWhileSavingDoesNotFireAnotherSave(t *testing.T) {
 	// A selected domain is required: the save command targets it.
 	a := NewApp(nil, nil, []api.Domain{{Name: "example.com"}}, nil, false)
 	a.view = ViewNameservers
-	a.nameserversView.SetNameservers([]string{"ns1.example.com"})
+	a.nameserversView.SetNameservers([]string{"ns1.example.com", "ns2.example.com"})
 	a, _ = update(t, a, keyMsg("e"))
 
 	a, cmd1 := update(t, a, tea.KeyMsg{Type: tea.KeyCtrlS})
@@ -688,6 +694,182 @@ func TestSecondCtrlSWhileSavingDoesNotFireAnotherSave(t *testing.T) {
 	_ = a
 }
 
+func TestInvalidNameserversAreNotSaved(t *testing.T) {
+	a := NewApp(nil, nil, []api.Domain{{Name: "example.com"}}, nil, false)
+	a.view = ViewNameservers
+	a.nameserversView.SetNameservers([]string{"ns1.example.com", "ns1.example.com"})
+	a, _ = update(t, a, keyMsg("e"))
+
+	a, cmd := update(t, a, tea.KeyMsg{Type: tea.KeyCtrlS})
+
+	if cmd != nil {
+		t.Error("duplicate nameservers sent to the API")
+	}
+	if a.nameserversView.IsSaving() {
+		t.Error("saving stuck after a validation error")
+	}
+	if !strings.Contains(a.nameserversView.View(), "same as NS1") {
+		t.Error("validation error not shown")
+	}
+}
+
+func TestNameserverUndoRestoresPreviousSet(t *testing.T) {
+	a := NewApp(nil, nil, []api.Domain{{Name: "example.com"}}, nil, false)
+	a.openNameservers("example.com")
+	old := []string{"ns1.example.net", "ns2.example.net"}
+	a.nameserversView.SetNameservers(old)
+
+	a, _ = update(t, a, keyMsg("u"))
+	if a.nameserversView.IsConfirming() {
+		t.Fatal("u offered an undo with no change made")
+	}
+
+	a, _ = update(t, a, keyMsg("p"))
+	a, _ = update(t, a, tea.KeyMsg{Type: tea.KeyEnter}) // Porkbun default
+	a, cmd := update(t, a, tea.KeyMsg{Type: tea.KeyCtrlS})
+	if cmd == nil {
+		t.Fatal("save queued nothing")
+	}
+	a, _ = update(t, a, nsSavedMsg{domain: "example.com", previous: old})
+	a, _ = update(t, a, tea.KeyMsg{Type: tea.KeyEsc})
+	a.openNameservers("example.com")
+	a.nameserversView.SetNameservers(nspreset.Builtin[0].NS)
+	if got := a.nsUndo["example.com"].Previous; len(got) != 2 || got[0] != "ns1.example.net" {
+		t.Fatalf("undo = %v, want the set from before the save", got)
+	}
+
+	a, _ = update(t, a, keyMsg("u"))
+	if !a.nameserversView.IsConfirming() {
+		t.Fatal("u did not ask for confirmation")
+	}
+	a, cmd = update(t, a, keyMsg("q"))
+	if cmd != nil || a.view != ViewNameservers {
+		t.Fatal("q escaped the undo confirmation")
+	}
+	a, _ = update(t, a, tea.KeyMsg{Type: tea.KeyEsc})
+	if a.nameserversView.IsConfirming() || a.view != ViewNameservers {
+		t.Fatal("esc did not just cancel the confirmation")
+	}
+
+	a, _ = update(t, a, keyMsg("u"))
+	a, cmd = update(t, a, keyMsg("y"))
+	if cmd == nil || !a.nameserversView.IsSaving() {
+		t.Fatal("confirming queued no restore")
+	}
+	a, _ = update(t, a, nsSavedMsg{domain: "example.com", previous: nspreset.Builtin[0].NS, restored: true})
+	if !strings.Contains(a.nameserversView.View(), "Nameservers restored.") {
+		t.Errorf("restore not reported:\n%s", a.nameserversView.View())
+	}
+	// Undoing the undo puts the preset back.
+	if got := a.nsUndo["example.com"].Previous; len(got) != len(nspreset.Builtin[0].NS) {
+		t.Errorf("undo after restoring = %v, want the preset", got)
+	}
+}
+
+func TestNameserverConflictNeedsConfirmation(t *testing.T) {
+	a := NewApp(nil, nil, []api.Domain{{Name: "example.com"}}, nil, false)
+	a.openNameservers("example.com")
+	a.nameserversView.SetNameservers([]string{"maceio.ns.porkbun.com"})
+	a, _ = update(t, a, keyMsg("p"))
+	a, _ = update(t, a, keyMsg("j"))
+	a, _ = update(t, a, tea.KeyMsg{Type: tea.KeyEnter}) // Google Cloud DNS
+	a, _ = update(t, a, tea.KeyMsg{Type: tea.KeyCtrlS})
+
+	theirs := []string{"ada.ns.cloudflare.com", "bob.ns.cloudflare.com"}
+	a, _ = update(t, a, nsConflictMsg{"example.com", theirs, nspreset.Builtin[1].NS})
+	if a.nameserversView.IsSaving() {
+		t.Error("still saving after the conflict")
+	}
+	out := a.nameserversView.View()
+	for _, want := range []string{"changed since they were loaded", "Loaded", "Now", "Yours", "maceio.ns.porkbun.com", "ada.ns.cloudflare.com", "ns-cloud-a1.googledomains.com"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("conflict missing %q:\n%s", want, out)
+		}
+	}
+
+	a, cmd := update(t, a, keyMsg("q"))
+	if cmd != nil || !a.nameserversView.IsConfirming() {
+		t.Fatal("q escaped the conflict")
+	}
+	a, _ = update(t, a, tea.KeyMsg{Type: tea.KeyEsc})
+	if a.view != ViewNameservers {
+		t.Fatal("esc left the view instead of answering the conflict")
+	}
+	if got := a.nameserversView.Nameservers(); len(got) != 2 || got[0] != "ada.ns.cloudflare.com" {
+		t.Errorf("after keeping theirs the view shows %v", got)
+	}
+
+	a.nameserversView.SetConflict(theirs, nspreset.Builtin[1].NS)
+	a, cmd = update(t, a, keyMsg("y"))
+	if cmd == nil || !a.nameserversView.IsSaving() {
+		t.Error("confirming the overwrite queued no save")
+	}
+}
+
+func TestNameserverCopyFromAnotherDomain(t *testing.T) {
+	domains := []api.Domain{{Name: "example.com"}, {Name: "other.net"}, {Name: "third.org"}}
+	a := NewApp(nil, nil, domains, nil, false)
+	a.openNameservers("example.com")
+	a.nameserversView.SetNameservers([]string{"maceio.ns.porkbun.com"})
+
+	a, _ = update(t, a, keyMsg("p"))
+	for range nspreset.Builtin {
+		a, _ = update(t, a, keyMsg("j"))
+	}
+	a, _ = update(t, a, tea.KeyMsg{Type: tea.KeyEnter}) // Copy from another domain
+	for _, r := range "org" {
+		a, _ = update(t, a, keyMsg(string(r)))
+	}
+	out := a.nameserversView.View()
+	if !strings.Contains(out, "third.org") || strings.Contains(out, "other.net") || strings.Contains(out, "  example.com") {
+		t.Errorf("filter shows the wrong domains:\n%s", out)
+	}
+
+	a, cmd := update(t, a, keyMsg("q"))
+	if cmd != nil {
+		if _, quit := cmd().(tea.QuitMsg); quit {
+			t.Fatal("typing q into the copy filter quit the app")
+		}
+	}
+	if !a.nameserversView.IsPickingDomain() {
+		t.Fatal("q left the copy picker")
+	}
+	a, _ = update(t, a, tea.KeyMsg{Type: tea.KeyBackspace})
+	a, cmd = update(t, a, tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("picking a domain fetched nothing")
+	}
+
+	a, _ = update(t, a, nsCopiedMsg{"example.com", "third.org", []string{"ns1.third.org", "ns2.third.org"}})
+	if !a.nameserversView.IsEditing() {
+		t.Fatal("copied nameservers not opened for editing")
+	}
+	if got := a.nameserversView.GetNameservers(); len(got) != 2 || got[1] != "ns2.third.org" {
+		t.Errorf("editor has %v, want third.org's nameservers", got)
+	}
+}
+
+func TestConfigNameserverPresetsAreOffered(t *testing.T) {
+	a := newTestApp(false)
+	err := a.SetConfig(&config.Config{NSPresets: []config.NSPreset{
+		{Name: "Cloudflare (work)", Nameservers: []string{"ada.ns.cloudflare.com", "bob.ns.cloudflare.com"}},
+	}})
+	if err != nil {
+		t.Fatalf("SetConfig: %v", err)
+	}
+	a.openNameservers("example.com")
+	a.nameserversView.SetNameservers(nil)
+	a, _ = update(t, a, keyMsg("p"))
+	if !strings.Contains(a.nameserversView.View(), "Cloudflare (work)") {
+		t.Errorf("config preset missing:\n%s", a.nameserversView.View())
+	}
+
+	err = a.SetConfig(&config.Config{NSPresets: []config.NSPreset{{Name: "bad", Nameservers: []string{"not a host"}}}})
+	if err == nil {
+		t.Error("an invalid preset was accepted")
+	}
+}
+
 func TestQuitKeyReturnsQuit(t *testing.T) {
 	a := newTestApp(false)
 	_, cmd := update(t, a, keyMsg("q"))
@@ -699,3 +881,738 @@ func TestQuitKeyReturnsQuit(t *testing.T) {
 		t.Errorf("cmd() = %T, want tea.QuitMsg", cmd())
 	}
 }
+
+func TestTypingQInTLDExplorerSearchDoesNotQuit(t *testing.T) {
+	a := NewApp(nil, nil, nil, map[string]api.TLDPricing{"com": {TLD: "com", Renewal: "10.00"}}, false)
+	a.view = ViewTLD
+	a, _ = update(t, a, keyMsg("p"))
+	a, _ = update(t, a, keyMsg("/"))
+
+	a, cmd := update(t, a, keyMsg("q"))
+	if cmd != nil {
+		if _, quit := cmd().(tea.QuitMsg); quit {
+			t.Fatal("typing q into the TLD explorer search quit the app")
+		}
+	}
+
+	// esc closes the search but stays in the TLD view.
+	a, _ = update(t, a, tea.KeyMsg{Type: tea.KeyEsc})
+	if a.view != ViewTLD {
+		t.Errorf("view = %v after esc in explorer search, want ViewTLD", a.view)
+	}
+	if a.tldView.IsSearching() {
+		t.Error("still searching after esc")
+	}
+}
+
+func TestCalendarGridJumpsToDetailAndBack(t *testing.T) {
+	a := newTestApp(false)
+	soon := time.Now().AddDate(0, 0, 3)
+	a, _ = update(t, a, domainsLoadedMsg{[]api.Domain{
+		{Name: "first.com", TLD: "com", ExpireDate: time.Now().AddDate(0, 0, 1)},
+		{Name: "target.com", TLD: "com", ExpireDate: soon},
+	}})
+
+	a, _ = update(t, a, keyMsg("c"))
+	a, _ = update(t, a, keyMsg("g"))
+	for _, k := range []string{"l", "l", "l"} {
+		a, _ = update(t, a, keyMsg(k))
+	}
+	a, _ = update(t, a, tea.KeyMsg{Type: tea.KeyEnter})
+
+	// esc with the day list open stays in the calendar
+	a, _ = update(t, a, tea.KeyMsg{Type: tea.KeyEsc})
+	if a.view != ViewCalendar {
+		t.Fatal("esc closing the day list left the calendar")
+	}
+
+	a, _ = update(t, a, tea.KeyMsg{Type: tea.KeyEnter})
+	a, _ = update(t, a, tea.KeyMsg{Type: tea.KeyEnter})
+	if a.view != ViewDetail {
+		t.Fatalf("view = %v after picking a domain, want ViewDetail", a.view)
+	}
+	if d := a.domainsView.SelectedDomain(); d == nil || d.Name != "target.com" {
+		t.Errorf("domain list selection = %v, want target.com so d/n act on it", d)
+	}
+
+	a, _ = update(t, a, tea.KeyMsg{Type: tea.KeyEsc})
+	if a.view != ViewCalendar {
+		t.Errorf("esc from a detail opened in the calendar went to %v, want ViewCalendar", a.view)
+	}
+}
+
+func TestCalendarExportWritesICS(t *testing.T) {
+	t.Chdir(t.TempDir())
+	a := newTestApp(false)
+	a.SetConfig(&config.Config{CalendarReminders: []int{14}})
+	a, _ = update(t, a, domainsLoadedMsg{[]api.Domain{
+		{Name: "example.com", TLD: "com", AutoRenew: true, ExpireDate: time.Now().AddDate(0, 2, 0)},
+	}})
+	a, _ = update(t, a, keyMsg("c"))
+
+	_, cmd := update(t, a, keyMsg("x"))
+	if cmd == nil {
+		t.Fatal("x in the calendar queued no export")
+	}
+	msg, ok := cmd().(calendarExportedMsg)
+	if !ok || msg.err != nil {
+		t.Fatalf("export = %+v, want success", msg)
+	}
+
+	data, err := os.ReadFile(msg.path)
+	if err != nil {
+		t.Fatalf("reading export: %v", err)
+	}
+	for _, want := range []string{"UID:expire-example.com@porkbun-tui", "TRIGGER:-P14D"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("export missing %q", want)
+		}
+	}
+}
+
+func TestSetConfigRejectsBadAlertRules(t *testing.T) {
+	a := newTestApp(false)
+
+	err := a.SetConfig(&config.Config{Alerts: []config.AlertRule{{Within: "soon"}}})
+	if err == nil {
+		t.Error("an invalid alert rule was accepted")
+	}
+}
+
+func TestSSLKeyOpensCertificateFromDetail(t *testing.T) {
+	domains := []api.Domain{{Name: "example.com", TLD: "com"}}
+	a := NewApp(nil, nil, domains, nil, false)
+	a, _ = update(t, a, tea.KeyMsg{Type: tea.KeyEnter})
+
+	a, cmd := update(t, a, keyMsg("s"))
+	if a.view != ViewSSL {
+		t.Fatalf("view = %v after s in detail, want ViewSSL", a.view)
+	}
+	if cmd == nil {
+		t.Error("no command queued to retrieve the SSL bundle")
+	}
+
+	a, _ = update(t, a, tea.KeyMsg{Type: tea.KeyEsc})
+	if a.view != ViewDetail {
+		t.Errorf("view = %v after esc, want ViewDetail", a.view)
+	}
+}
+
+func TestDemoModeBlocksSSL(t *testing.T) {
+	domains := []api.Domain{{Name: "example.com", TLD: "com"}}
+	a := NewApp(nil, nil, domains, nil, true)
+	a, _ = update(t, a, tea.KeyMsg{Type: tea.KeyEnter})
+
+	a, cmd := update(t, a, keyMsg("s"))
+	if a.view != ViewDetail || cmd != nil {
+		t.Errorf("demo mode: view = %v, cmd = %v after s; want ViewDetail and no command", a.view, cmd != nil)
+	}
+}
+
+func TestSSLErrorReachesViewAfterLeaving(t *testing.T) {
+	domains := []api.Domain{{Name: "example.com", TLD: "com"}}
+	a := NewApp(nil, nil, domains, nil, false)
+	a, _ = update(t, a, tea.WindowSizeMsg{Width: 120, Height: 40})
+	a, _ = update(t, a, tea.KeyMsg{Type: tea.KeyEnter})
+	a, _ = update(t, a, keyMsg("s"))
+	a, _ = update(t, a, tea.KeyMsg{Type: tea.KeyEsc})
+
+	a, _ = update(t, a, sslErrMsg{errors.New("no SSL certificate")})
+	a.view = ViewSSL
+	if got := a.View(); !strings.Contains(got, "no SSL certificate") || strings.Contains(got, "Retrieving") {
+		t.Errorf("SSL view still loading after its error arrived:\n%s", got)
+	}
+}
+
+func TestForwardsKeyOpensViewAndFormCapturesKeys(t *testing.T) {
+	domains := []api.Domain{{Name: "example.com", TLD: "com"}}
+	a := NewApp(nil, nil, domains, nil, false)
+	a, _ = update(t, a, tea.KeyMsg{Type: tea.KeyEnter})
+
+	a, cmd := update(t, a, keyMsg("f"))
+	if a.view != ViewForwards || cmd == nil {
+		t.Fatalf("view = %v, cmd = %v after f; want ViewForwards loading", a.view, cmd != nil)
+	}
+	a, _ = update(t, a, forwardsLoadedMsg{})
+
+	a, _ = update(t, a, keyMsg("a"))
+	a, cmd = update(t, a, keyMsg("q"))
+	if cmd != nil {
+		if _, quit := cmd().(tea.QuitMsg); quit {
+			t.Fatal("typing q into the add form quit the app")
+		}
+	}
+	if a.view != ViewForwards {
+		t.Error("q in the add form left the view")
+	}
+
+	// esc closes the form first, then the view
+	a, _ = update(t, a, tea.KeyMsg{Type: tea.KeyEsc})
+	if a.view != ViewForwards {
+		t.Fatal("esc in the add form left the view")
+	}
+	a, _ = update(t, a, tea.KeyMsg{Type: tea.KeyEsc})
+	if a.view != ViewDetail {
+		t.Errorf("view = %v after esc, want ViewDetail", a.view)
+	}
+}
+
+func TestInvalidForwardIsNotAdded(t *testing.T) {
+	domains := []api.Domain{{Name: "example.com", TLD: "com"}}
+	a := NewApp(nil, nil, domains, nil, false)
+	a, _ = update(t, a, tea.KeyMsg{Type: tea.KeyEnter})
+	a, _ = update(t, a, keyMsg("f"))
+	a, _ = update(t, a, forwardsLoadedMsg{})
+	a, _ = update(t, a, keyMsg("a"))
+	a, _ = update(t, a, tea.KeyMsg{Type: tea.KeyTab})
+	for _, r := range "not a url" {
+		a, _ = update(t, a, keyMsg(string(r)))
+	}
+
+	a, cmd := update(t, a, tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd != nil {
+		t.Error("an add command was queued for an invalid location")
+	}
+	if a.forwardsView.IsSaving() || !strings.Contains(a.forwardsView.View(), "must be an http") {
+		t.Errorf("validation error not shown:\n%s", a.forwardsView.View())
+	}
+}
+
+func TestDemoModeBlocksForwards(t *testing.T) {
+	domains := []api.Domain{{Name: "example.com", TLD: "com"}}
+	a := NewApp(nil, nil, domains, nil, true)
+	a, _ = update(t, a, tea.KeyMsg{Type: tea.KeyEnter})
+
+	a, cmd := update(t, a, keyMsg("f"))
+	if a.view != ViewDetail || cmd != nil {
+		t.Errorf("demo mode: view = %v, cmd = %v after f; want ViewDetail and no command", a.view, cmd != nil)
+	}
+}
+
+func TestGlueOpensFromNameserversAndValidatesAddresses(t *testing.T) {
+	domains := []api.Domain{{Name: "example.com", TLD: "com"}}
+	a := NewApp(nil, nil, domains, nil, false)
+	a, _ = update(t, a, keyMsg("n"))
+	a, _ = update(t, a, nsLoadedMsg{"example.com", []string{"ns1.example.com"}})
+
+	a, cmd := update(t, a, keyMsg("g"))
+	if a.view != ViewGlue || cmd == nil {
+		t.Fatalf("view = %v, cmd = %v after g; want ViewGlue loading", a.view, cmd != nil)
+	}
+	a, _ = update(t, a, glueLoadedMsg{})
+
+	a, _ = update(t, a, keyMsg("a"))
+	for _, r := range "not-an-ip" {
+		a, _ = update(t, a, keyMsg(string(r)))
+	}
+	a, cmd = update(t, a, tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd != nil {
+		t.Error("a glue command was queued for an invalid address")
+	}
+	if a.glueView.IsSaving() || !strings.Contains(a.glueView.View(), "not an IP address") {
+		t.Errorf("validation error not shown:\n%s", a.glueView.View())
+	}
+
+	a, _ = update(t, a, tea.KeyMsg{Type: tea.KeyEsc})
+	a, _ = update(t, a, tea.KeyMsg{Type: tea.KeyEsc})
+	if a.view != ViewNameservers {
+		t.Errorf("view = %v after esc, want ViewNameservers", a.view)
+	}
+}
+
+func TestDNSSECKeyOpensViewAndRejectsInvalidRecord(t *testing.T) {
+	domains := []api.Domain{{Name: "example.com", TLD: "com"}}
+	a := NewApp(nil, nil, domains, nil, false)
+
+	// Opening the details checks the badge status in the background.
+	a, cmd := update(t, a, tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Error("opening the details queued no DNSSEC check")
+	}
+
+	a, cmd = update(t, a, keyMsg("D"))
+	if a.view != ViewDNSSEC || cmd == nil {
+		t.Fatalf("view = %v, cmd = %v after D; want ViewDNSSEC loading", a.view, cmd != nil)
+	}
+	a, _ = update(t, a, dnssecLoadedMsg{domain: "example.com"})
+	if on, known := a.dnssec["example.com"]; !known || on {
+		t.Errorf("status = %v, %v after an empty load; want known and off", on, known)
+	}
+
+	a, _ = update(t, a, keyMsg("a"))
+	for _, r := range "2371 13 2 ABCD" {
+		a, _ = update(t, a, keyMsg(string(r)))
+	}
+	a, cmd = update(t, a, tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd != nil {
+		t.Error("an add command was queued for a short digest")
+	}
+	if a.dnssecView.IsSaving() || !strings.Contains(a.dnssecView.View(), "64 hex characters") {
+		t.Errorf("validation error not shown:\n%s", a.dnssecView.View())
+	}
+
+	a, _ = update(t, a, tea.KeyMsg{Type: tea.KeyEsc})
+	a, _ = update(t, a, tea.KeyMsg{Type: tea.KeyEsc})
+	if a.view != ViewDetail {
+		t.Errorf("view = %v after esc, want ViewDetail", a.view)
+	}
+}
+
+func TestDNSSECFilterScansUncheckedDomainsOnce(t *testing.T) {
+	domains := []api.Domain{
+		{Name: "signed.com", TLD: "com"},
+		{Name: "unsigned.com", TLD: "com"},
+	}
+	a := NewApp(nil, nil, domains, nil, false)
+
+	a, cmd := update(t, a, keyMsg("D"))
+	if cmd == nil || !a.dnssecScanning {
+		t.Fatal("filtering by DNSSEC started no scan")
+	}
+	if _, cmd = update(t, a, keyMsg("D")); cmd != nil {
+		t.Error("a second scan started while one was running")
+	}
+
+	a, _ = update(t, a, dnssecScannedMsg{map[string]bool{"signed.com": true, "unsigned.com": false}})
+	if a.dnssecScanning {
+		t.Error("scanning still set after the result")
+	}
+	if got := a.domainsView.StatusText(); !strings.Contains(got, "1/2") {
+		t.Errorf("status = %q, want one of two domains shown", got)
+	}
+}
+
+func TestDemoModeShowsDNSSECWithoutAPI(t *testing.T) {
+	domains := []api.Domain{{Name: "acmecorp.com", TLD: "com"}}
+	a := NewApp(nil, nil, domains, nil, true)
+
+	a, cmd := update(t, a, tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd != nil {
+		t.Error("demo mode queued a DNSSEC check")
+	}
+	if !strings.Contains(a.detailView.View(), "On") {
+		t.Errorf("demo DNSSEC badge missing:\n%s", a.detailView.View())
+	}
+	a, cmd = update(t, a, keyMsg("D"))
+	if a.view != ViewDetail || cmd != nil {
+		t.Errorf("demo mode: view = %v, cmd = %v after D; want ViewDetail and no command", a.view, cmd != nil)
+	}
+}
+
+func TestDomainsLoadedScansNameserversForProviders(t *testing.T) {
+	a := NewApp(nil, nil, nil, nil, false)
+	a.nameservers["known.com"] = []string{"ns1.known.com", "ns2.known.com"}
+
+	a, cmd := update(t, a, domainsLoadedMsg{[]api.Domain{
+		{Name: "known.com", TLD: "com"},
+		{Name: "cf.com", TLD: "com"},
+		{Name: "r53.com", TLD: "com"},
+	}})
+	if cmd == nil || !a.nsScanning {
+		t.Fatal("loading domains with unknown nameservers started no scan")
+	}
+	a, cmd = update(t, a, keyMsg("P"))
+	if a.view != ViewProviders || cmd != nil {
+		t.Fatalf("view = %v, cmd = %v after P; want ViewProviders and no second scan", a.view, cmd != nil)
+	}
+
+	a, _ = update(t, a, nsScannedMsg{map[string][]string{
+		"cf.com":  {"ada.ns.cloudflare.com", "bob.ns.cloudflare.com"},
+		"r53.com": {"ns-1.awsdns-01.org", "ns-2.awsdns-02.com"},
+	}})
+	if a.nsScanning {
+		t.Error("scanning still set after the result")
+	}
+	got := make(map[string]int)
+	for _, g := range a.providerView.Groups() {
+		got[g.Name] = len(g.Domains)
+	}
+	want := map[string]int{"Cloudflare": 1, "Route 53": 1, dnsprovider.SelfHosted: 1}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("provider groups = %v, want %v", got, want)
+	}
+
+	a.view = ViewDomains
+	a, _ = update(t, a, tea.WindowSizeMsg{Width: 140, Height: 40})
+	if !strings.Contains(a.domainsView.View(), "Cloudflare") {
+		t.Errorf("DNS column missing from the list:\n%s", a.domainsView.View())
+	}
+
+	a.view = ViewProviders
+	if _, cmd = update(t, a, keyMsg("r")); cmd == nil || !a.nsScanning {
+		t.Error("r in the provider view started no rescan")
+	}
+}
+
+func TestProviderRulesFromConfigOverrideBuiltins(t *testing.T) {
+	a := NewApp(nil, nil, []api.Domain{{Name: "example.com", TLD: "com"}}, nil, false)
+	a.nameservers["example.com"] = []string{"ada.ns.cloudflare.com"}
+	err := a.SetConfig(&config.Config{DNSProviders: []config.DNSProvider{
+		{Name: "Cloudflare (work)", Patterns: []string{"ada.ns.cloudflare.com"}},
+	}})
+	if err != nil {
+		t.Fatalf("SetConfig: %v", err)
+	}
+	a.detailView.SetDomain(&a.domainsView.GetDomains()[0])
+	if !strings.Contains(a.detailView.View(), "Cloudflare (work)") {
+		t.Errorf("detail view missing the config provider:\n%s", a.detailView.View())
+	}
+
+	if err := a.SetConfig(&config.Config{DNSProviders: []config.DNSProvider{{Name: "no patterns"}}}); err == nil {
+		t.Error("SetConfig accepted a provider rule without patterns")
+	}
+}
+
+func TestAutoRenewToggleIsOptimisticAndRollsBack(t *testing.T) {
+	domains := []api.Domain{{Name: "example.com", TLD: "com", AutoRenew: true}}
+	a := NewApp(nil, nil, domains, nil, false)
+	a, _ = update(t, a, tea.KeyMsg{Type: tea.KeyEnter})
+
+	a, _ = update(t, a, keyMsg("A"))
+	if !a.detailView.IsConfirming() {
+		t.Fatal("A did not ask for confirmation")
+	}
+	a, cmd := update(t, a, keyMsg("q"))
+	if cmd != nil || a.view != ViewDetail {
+		t.Fatal("q escaped the auto-renew prompt")
+	}
+
+	a, cmd = update(t, a, keyMsg("y"))
+	if cmd == nil {
+		t.Fatal("y queued no auto-renew change")
+	}
+	if a.domainsView.SelectedDomain().AutoRenew {
+		t.Error("auto-renew still on before the result; want the change shown right away")
+	}
+
+	a, _ = update(t, a, autoRenewResultMsg{
+		on:     false,
+		prev:   map[string]bool{"example.com": true},
+		failed: map[string]error{"example.com": errors.New("porkbun: Domain is not eligible.")},
+	})
+	if !a.domainsView.SelectedDomain().AutoRenew {
+		t.Error("failed change not rolled back")
+	}
+	if a.err == nil || !strings.Contains(a.err.Error(), "not eligible") {
+		t.Errorf("err = %v, want the registry's reason", a.err)
+	}
+}
+
+func TestBulkAutoRenewAppliesToShownDomains(t *testing.T) {
+	domains := []api.Domain{
+		{Name: "a.com", TLD: "com"},
+		{Name: "b.org", TLD: "org"},
+	}
+	a := NewApp(nil, nil, domains, nil, false)
+	a, _ = update(t, a, keyMsg("/"))
+	for _, r := range ".com" {
+		a, _ = update(t, a, keyMsg(string(r)))
+	}
+	a, _ = update(t, a, tea.KeyMsg{Type: tea.KeyEnter})
+
+	a, _ = update(t, a, keyMsg("A"))
+	if !a.domainsView.IsConfirmingAutoRenew() {
+		t.Fatal("A did not ask for confirmation")
+	}
+	a, cmd := update(t, a, keyMsg("o"))
+	if cmd == nil {
+		t.Fatal("o queued no auto-renew change")
+	}
+	for _, d := range a.domainsView.GetDomains() {
+		if want := d.Name == "a.com"; d.AutoRenew != want {
+			t.Errorf("%s auto-renew = %v, want %v", d.Name, d.AutoRenew, want)
+		}
+	}
+}
+
+func TestBulkTemplateRunsOneDomainAtATime(t *testing.T) {
+	domains := []api.Domain{
+		{Name: "a.com", TLD: "com"},
+		{Name: "b.com", TLD: "com"},
+		{Name: "c.com", TLD: "com"},
+	}
+	a := NewApp(nil, nil, domains, nil, false)
+
+	a, _ = update(t, a, keyMsg("b"))
+	if a.view != ViewDomains {
+		t.Fatal("b opened bulk actions with nothing marked")
+	}
+	a, _ = update(t, a, keyMsg("*"))
+	a, _ = update(t, a, keyMsg("b"))
+	if a.view != ViewBulk {
+		t.Fatalf("view = %v after b, want ViewBulk", a.view)
+	}
+
+	a, _ = update(t, a, keyMsg("j"))
+	a, _ = update(t, a, keyMsg("j"))
+	a, _ = update(t, a, tea.KeyMsg{Type: tea.KeyEnter}) // DNS template
+	a, _ = update(t, a, tea.KeyMsg{Type: tea.KeyEnter}) // first template
+	a, cmd := update(t, a, keyMsg("y"))
+	if cmd == nil || a.bulkJob.Action != views.BulkDNSTemplate {
+		t.Fatal("confirming queued no first step")
+	}
+
+	a, cmd = update(t, a, bulkStepMsg{0, nil})
+	if cmd == nil {
+		t.Fatal("first result queued no second step")
+	}
+	// esc stops before the third domain
+	a, _ = update(t, a, tea.KeyMsg{Type: tea.KeyEsc})
+	a, cmd = update(t, a, bulkStepMsg{1, errors.New("porkbun: Invalid domain.")})
+	if cmd != nil {
+		t.Error("a step was queued after stopping")
+	}
+	if a.bulkView.IsRunning() {
+		t.Error("bulk still running after the last step")
+	}
+	if got := a.bulkView.StatusText(); got != "1/3 done, 1 failed" {
+		t.Errorf("status = %q", got)
+	}
+
+	a, _ = update(t, a, tea.KeyMsg{Type: tea.KeyEsc})
+	if a.view != ViewDomains {
+		t.Errorf("view = %v after esc, want ViewDomains", a.view)
+	}
+}
+
+func TestMigrationPreviewSkipsDomainsOnTarget(t *testing.T) {
+	domains := []api.Domain{
+		{Name: "a.com", TLD: "com"},
+		{Name: "b.com", TLD: "com"},
+		{Name: "c.com", TLD: "com"},
+	}
+	a := NewApp(nil, nil, domains, nil, false)
+	a, _ = update(t, a, keyMsg("*"))
+	a, _ = update(t, a, keyMsg("b"))
+	a, _ = update(t, a, tea.KeyMsg{Type: tea.KeyEnter}) // migrate nameservers
+	if a.view != ViewMigrate {
+		t.Fatalf("view = %v, want ViewMigrate", a.view)
+	}
+	a, _ = update(t, a, keyMsg("j"))
+	a, cmd := update(t, a, tea.KeyMsg{Type: tea.KeyEnter}) // Google Cloud DNS
+	if cmd == nil || !a.migrateView.IsBusy() {
+		t.Fatal("choosing a target fetched nothing")
+	}
+
+	a, _ = update(t, a, nsCurrentMsg{
+		current: map[string][]string{
+			"a.com": {"NS-Cloud-A4.googledomains.com.", "ns-cloud-a3.googledomains.com", "ns-cloud-a2.googledomains.com", "ns-cloud-a1.googledomains.com"},
+			"b.com": {"maceio.ns.porkbun.com"},
+		},
+		errs: map[string]error{"c.com": errors.New("porkbun: Invalid domain.")},
+	})
+	out := a.migrateView.View()
+	for _, want := range []string{"already on target", "Invalid domain.", "1 to change"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("preview missing %q:\n%s", want, out)
+		}
+	}
+
+	a, cmd = update(t, a, keyMsg("y"))
+	if cmd == nil || a.migration.inFlight != 0 {
+		t.Fatalf("apply started %+v before the rollback file was written", a.migration)
+	}
+	a, cmd = update(t, a, nsRollbackReadyMsg{path: "/tmp/porkbun-ns-rollback.json"})
+	if cmd == nil || a.migration.inFlight != 1 || a.migration.queue[0] != "b.com" {
+		t.Fatalf("apply started %+v, want b.com alone", a.migration)
+	}
+	a, cmd = update(t, a, nsMigratedMsg{domain: "b.com", previous: []string{"maceio.ns.porkbun.com"}})
+	if cmd == nil {
+		t.Fatal("last result queued no rollback file")
+	}
+	if got := a.migration.previous["b.com"]; len(got) != 1 || got[0] != "maceio.ns.porkbun.com" {
+		t.Errorf("rollback for b.com = %v", got)
+	}
+
+	a, _ = update(t, a, nsRollbackWrittenMsg{path: "/tmp/porkbun-ns-rollback.json"})
+	if a.migrateView.IsBusy() {
+		t.Error("migration still busy after the rollback file")
+	}
+	if !strings.Contains(a.migrateView.View(), "/tmp/porkbun-ns-rollback.json") {
+		t.Errorf("rollback path missing:\n%s", a.migrateView.View())
+	}
+	a, _ = update(t, a, tea.KeyMsg{Type: tea.KeyEsc})
+	if a.view != ViewDomains {
+		t.Errorf("view = %v after esc, want ViewDomains", a.view)
+	}
+}
+
+func TestMigrationQuitMidRunLeavesRollbackFile(t *testing.T) {
+	t.Chdir(t.TempDir())
+	domains := []api.Domain{{Name: "a.com", TLD: "com"}, {Name: "b.com", TLD: "com"}}
+	a := NewApp(nil, nil, domains, nil, false)
+	a, _ = update(t, a, keyMsg("*"))
+	a, _ = update(t, a, keyMsg("b"))
+	a, _ = update(t, a, tea.KeyMsg{Type: tea.KeyEnter}) // migrate nameservers
+	a, _ = update(t, a, keyMsg("j"))
+	a, _ = update(t, a, tea.KeyMsg{Type: tea.KeyEnter}) // Google Cloud DNS
+	a, _ = update(t, a, nsCurrentMsg{current: map[string][]string{
+		"a.com": {"maceio.ns.porkbun.com", "curitiba.ns.porkbun.com"},
+		"b.com": {"salvador.ns.porkbun.com", "fortaleza.ns.porkbun.com"},
+	}})
+
+	a, cmd := update(t, a, keyMsg("y"))
+	ready, ok := cmd().(nsRollbackReadyMsg)
+	if !ok || ready.err != nil {
+		t.Fatalf("apply wrote no rollback file first: %#v", ready)
+	}
+	a, _ = update(t, a, ready)
+	if a.migration.inFlight == 0 {
+		t.Fatal("no change sent after the rollback file")
+	}
+
+	// One domain done, one in flight: quitting now must not lose either.
+	a, _ = update(t, a, nsMigratedMsg{domain: "a.com", previous: []string{"maceio.ns.porkbun.com", "curitiba.ns.porkbun.com"}})
+	a, cmd = update(t, a, keyMsg("q"))
+	if cmd != nil {
+		if _, quit := cmd().(tea.QuitMsg); quit {
+			t.Error("q quit while the migration was applying")
+		}
+	}
+	r, err := nsmigrate.ReadRollback(ready.path)
+	if err != nil {
+		t.Fatalf("ReadRollback: %v", err)
+	}
+	if d := r.Domains(); !reflect.DeepEqual(d, []string{"a.com", "b.com"}) {
+		t.Errorf("rollback file covers %v, want every queued domain", d)
+	}
+	if got := r.Previous["a.com"]; !reflect.DeepEqual(got, []string{"maceio.ns.porkbun.com", "curitiba.ns.porkbun.com"}) {
+		t.Errorf("rollback for a.com = %v", got)
+	}
+}
+
+func TestMigrationRollbackLeavesOutDomainsWithoutNameservers(t *testing.T) {
+	t.Chdir(t.TempDir())
+	domains := []api.Domain{{Name: "a.com", TLD: "com"}, {Name: "b.com", TLD: "com"}}
+	a := NewApp(nil, nil, domains, nil, false)
+	a, _ = update(t, a, keyMsg("*"))
+	a, _ = update(t, a, keyMsg("b"))
+	a, _ = update(t, a, tea.KeyMsg{Type: tea.KeyEnter}) // migrate nameservers
+	a, _ = update(t, a, keyMsg("j"))
+	a, _ = update(t, a, tea.KeyMsg{Type: tea.KeyEnter}) // Google Cloud DNS
+	a, _ = update(t, a, nsCurrentMsg{current: map[string][]string{
+		"a.com": {},
+		"b.com": {"salvador.ns.porkbun.com", "fortaleza.ns.porkbun.com"},
+	}})
+	_, cmd := update(t, a, keyMsg("y"))
+	ready := cmd().(nsRollbackReadyMsg)
+	if ready.err != nil {
+		t.Fatalf("rollback file: %v", ready.err)
+	}
+	r, err := nsmigrate.ReadRollback(ready.path)
+	if err != nil {
+		t.Fatalf("ReadRollback: %v", err)
+	}
+	if d := r.Domains(); !reflect.DeepEqual(d, []string{"b.com"}) || len(r.Skipped) != 0 {
+		t.Errorf("rollback file covers %v, skipped %v; want b.com alone", d, r.Skipped)
+	}
+}
+
+func TestMigrationSkipsDomainsChangedSincePreview(t *testing.T) {
+	t.Chdir(t.TempDir())
+	domains := []api.Domain{{Name: "a.com", TLD: "com"}, {Name: "b.com", TLD: "com"}}
+	a := NewApp(nil, nil, domains, nil, false)
+	a, _ = update(t, a, keyMsg("*"))
+	a, _ = update(t, a, keyMsg("b"))
+	a, _ = update(t, a, tea.KeyMsg{Type: tea.KeyEnter}) // migrate nameservers
+	a, _ = update(t, a, keyMsg("j"))
+	a, _ = update(t, a, tea.KeyMsg{Type: tea.KeyEnter}) // Google Cloud DNS
+	a, _ = update(t, a, nsCurrentMsg{current: map[string][]string{
+		"a.com": {"maceio.ns.porkbun.com", "curitiba.ns.porkbun.com"},
+		"b.com": {"salvador.ns.porkbun.com", "fortaleza.ns.porkbun.com"},
+	}})
+	a, cmd := update(t, a, keyMsg("y"))
+	ready := cmd().(nsRollbackReadyMsg)
+	a, _ = update(t, a, ready)
+
+	// The workers' rollback updates: b.com moved to Cloudflare while the
+	// preview was open, so it's dropped from the file and left alone.
+	cloudflare := []string{"ada.ns.cloudflare.com", "bob.ns.cloudflare.com"}
+	if err := a.migration.rollback.set("b.com", nil); err != nil {
+		t.Fatalf("dropping b.com: %v", err)
+	}
+	r, err := nsmigrate.ReadRollback(ready.path)
+	if err != nil {
+		t.Fatalf("ReadRollback: %v", err)
+	}
+	if d := r.Domains(); !reflect.DeepEqual(d, []string{"a.com"}) {
+		t.Errorf("rollback file covers %v after the skip, want a.com alone", d)
+	}
+
+	a, _ = update(t, a, nsMigratedMsg{domain: "b.com", previous: cloudflare, changed: true})
+	a, cmd = update(t, a, nsMigratedMsg{domain: "a.com", previous: []string{"maceio.ns.porkbun.com", "curitiba.ns.porkbun.com"}})
+	if _, ok := a.migration.previous["b.com"]; ok {
+		t.Error("a domain left alone was recorded as changed")
+	}
+	out := a.migrateView.View()
+	for _, want := range []string{"changed since the preview", "1 changed, 0 failed, 1 skipped", "ada.ns.cloudflare"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("result missing %q:\n%s", want, out)
+		}
+	}
+	if cmd == nil {
+		t.Fatal("last result narrowed no rollback file")
+	}
+	cmd()
+	if r, err = nsmigrate.ReadRollback(ready.path); err != nil || !reflect.DeepEqual(r.Domains(), []string{"a.com"}) {
+		t.Errorf("final rollback file = %v, %v; want a.com alone", r.Domains(), err)
+	}
+}
+
+func TestMigrationCustomTargetIsValidated(t *testing.T) {
+	a := NewApp(nil, nil, []api.Domain{{Name: "a.com", TLD: "com"}}, nil, false)
+	a, _ = update(t, a, keyMsg("*"))
+	a, _ = update(t, a, keyMsg("b"))
+	a, _ = update(t, a, tea.KeyMsg{Type: tea.KeyEnter})
+	for range nspreset.Builtin {
+		a, _ = update(t, a, keyMsg("j"))
+	}
+	a, _ = update(t, a, tea.KeyMsg{Type: tea.KeyEnter}) // Custom...
+	for _, r := range "ns1.example.net, ns1.example.net" {
+		a, _ = update(t, a, keyMsg(string(r)))
+	}
+	a, cmd := update(t, a, tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd != nil || a.migrateView.IsBusy() {
+		t.Fatal("a duplicate nameserver was accepted")
+	}
+	if !strings.Contains(a.migrateView.View(), "Error") {
+		t.Errorf("validation error missing:\n%s", a.migrateView.View())
+	}
+}
+
+func TestBulkAutoRenewFillsProgressFromOneCall(t *testing.T) {
+	domains := []api.Domain{
+		{Name: "a.com", TLD: "com"},
+		{Name: "b.com", TLD: "com"},
+	}
+	a := NewApp(nil, nil, domains, nil, false)
+	a, _ = update(t, a, keyMsg("*"))
+	a, _ = update(t, a, keyMsg("b"))
+	a, _ = update(t, a, keyMsg("j"))
+	a, _ = update(t, a, tea.KeyMsg{Type: tea.KeyEnter}) // auto-renew
+	a, _ = update(t, a, tea.KeyMsg{Type: tea.KeyEnter}) // on
+	a, cmd := update(t, a, keyMsg("y"))
+	if cmd == nil {
+		t.Fatal("confirming queued no auto-renew call")
+	}
+
+	a, _ = update(t, a, autoRenewResultMsg{
+		on:     true,
+		prev:   map[string]bool{"a.com": false, "b.com": false},
+		failed: map[string]error{"b.com": errors.New("porkbun: Domain is not eligible.")},
+	})
+	if a.bulkView.IsRunning() {
+		t.Error("bulk still running after the result")
+	}
+	if !strings.Contains(a.bulkView.View(), "not eligible") {
+		t.Errorf("failure missing from progress:\n%s", a.bulkView.View())
+	}
+	if a.err != nil {
+		t.Errorf("err = %v; bulk failures belong in the progress list", a.err)
+	}
+	for _, d := range a.domainsView.GetDomains() {
+		if want := d.Name == "a.com"; d.AutoRenew != want {
+			t.Errorf("%s auto-renew = %v, want %v", d.Name, d.AutoRenew, want)
+		}
+	}
+}
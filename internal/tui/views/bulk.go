@@ -0,0 +1,464 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bc/porkbun-tui/internal/dnstemplate"
+	"github.com/bc/porkbun-tui/internal/keys"
+	"github.com/bc/porkbun-tui/internal/styles"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type BulkAction int
+
+const (
+	BulkNameservers BulkAction = iota
+	BulkAutoRenew
+	BulkDNSTemplate
+	BulkExport
+)
+
+func (a BulkAction) String() string {
+	switch a {
+	case BulkNameservers:
+		return "Migrate nameservers"
+	case BulkAutoRenew:
+		return "Turn auto-renew on or off"
+	case BulkDNSTemplate:
+		return "Apply a DNS template"
+	default:
+		return "Export to CSV"
+	}
+}
+
+var bulkActions = []BulkAction{BulkNameservers, BulkAutoRenew, BulkDNSTemplate, BulkExport}
+
+// BulkRequest is a bulk action for the app to run over Domains. Only the
+// field for the chosen action is set. BulkNameservers is raised straight
+// from the menu; the app hands it on to the migration view.
+type BulkRequest struct {
+	Action      BulkAction
+	Domains     []string
+	AutoRenew   bool
+	Template    dnstemplate.Template
+	Description string // e.g. "Turn auto-renew on"
+}
+
+type BulkViewMode int
+
+const (
+	BulkModeMenu BulkViewMode = iota
+	BulkModePick
+	BulkModeConfirm
+	BulkModeRun
+)
+
+type bulkState int
+
+const (
+	bulkPending bulkState = iota
+	bulkDone
+	bulkFailed
+	bulkSkipped
+)
+
+type bulkRow struct {
+	domain string
+	state  bulkState
+	err    error
+}
+
+// BulkView picks an action for the marked domains, confirms it, and then
+// shows how it went for each domain. The app runs the action and reports
+// each domain with SetResult.
+type BulkView struct {
+	domains   []string
+	templates []dnstemplate.Template
+	mode      BulkViewMode
+	cursor    int
+	action    BulkAction
+	request   BulkRequest
+	width     int
+	height    int
+
+	rows    []bulkRow
+	running bool
+	stopped bool   // esc while running: skip the domains not started yet
+	result  string // e.g. where the export went
+
+	// requested is the one-shot edge for the app.
+	requested bool
+}
+
+func NewBulkView() *BulkView {
+	return &BulkView{templates: dnstemplate.Builtin}
+}
+
+// SetTemplates sets the DNS templates on offer.
+func (v *BulkView) SetTemplates(templates []dnstemplate.Template) {
+	v.templates = templates
+}
+
+// Open starts over with the action menu for domains.
+func (v *BulkView) Open(domains []string) {
+	v.domains = domains
+	v.mode = BulkModeMenu
+	v.cursor = 0
+	v.rows = nil
+	v.running = false
+	v.stopped = false
+	v.result = ""
+	v.requested = false
+}
+
+func (v *BulkView) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+}
+
+// IsEditing reports whether a pick list or the confirmation is open; esc
+// goes back a step rather than leaving the view.
+func (v *BulkView) IsEditing() bool {
+	return v.mode == BulkModePick || v.mode == BulkModeConfirm
+}
+
+// IsRunning reports whether domains are still being worked through.
+func (v *BulkView) IsRunning() bool {
+	return v.running
+}
+
+// IsStopped reports whether the user asked to stop; the app starts no
+// further domains.
+func (v *BulkView) IsStopped() bool {
+	return v.stopped
+}
+
+// TakeRequest returns the confirmed action, once.
+func (v *BulkView) TakeRequest() (BulkRequest, bool) {
+	if !v.requested {
+		return BulkRequest{}, false
+	}
+	v.requested = false
+	return v.request, true
+}
+
+// SetResult records how the action went for domain.
+func (v *BulkView) SetResult(domain string, err error) {
+	for i := range v.rows {
+		if v.rows[i].domain == domain {
+			v.rows[i].state = bulkDone
+			v.rows[i].err = err
+			if err != nil {
+				v.rows[i].state = bulkFailed
+			}
+			return
+		}
+	}
+}
+
+// Finish ends the run; domains never reached are marked skipped. note is
+// shown under the progress list when set.
+func (v *BulkView) Finish(note string) {
+	v.running = false
+	v.result = note
+	for i := range v.rows {
+		if v.rows[i].state == bulkPending {
+			v.rows[i].state = bulkSkipped
+		}
+	}
+}
+
+func (v *BulkView) Update(msg tea.Msg) (*BulkView, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return v, nil
+	}
+	switch v.mode {
+	case BulkModeMenu:
+		v.updateMenu(keyMsg)
+	case BulkModePick:
+		v.updatePick(keyMsg)
+	case BulkModeConfirm:
+		v.updateConfirm(keyMsg)
+	case BulkModeRun:
+		if key.Matches(keyMsg, keys.Keys.Back) && v.running {
+			v.stopped = true
+		}
+	}
+	return v, nil
+}
+
+func (v *BulkView) updateMenu(msg tea.KeyMsg) {
+	switch {
+	case key.Matches(msg, keys.Keys.Up):
+		if v.cursor > 0 {
+			v.cursor--
+		}
+	case key.Matches(msg, keys.Keys.Down):
+		if v.cursor < len(bulkActions)-1 {
+			v.cursor++
+		}
+	case key.Matches(msg, keys.Keys.Enter):
+		v.action = bulkActions[v.cursor]
+		v.cursor = 0
+		switch v.action {
+		case BulkExport:
+			v.confirm(BulkRequest{Action: BulkExport, Description: "Export to CSV"})
+			return
+		case BulkNameservers:
+			v.request = BulkRequest{Action: BulkNameservers, Domains: v.domains, Description: "Migrate nameservers"}
+			v.requested = true
+			return
+		}
+		v.mode = BulkModePick
+	}
+}
+
+// pickOptions are the choices for the action: on/off or templates.
+func (v *BulkView) pickOptions() []string {
+	var opts []string
+	switch v.action {
+	case BulkAutoRenew:
+		opts = []string{"On", "Off"}
+	case BulkDNSTemplate:
+		for _, t := range v.templates {
+			opts = append(opts, t.Name)
+		}
+	}
+	return opts
+}
+
+func (v *BulkView) updatePick(msg tea.KeyMsg) {
+	opts := v.pickOptions()
+	switch {
+	case key.Matches(msg, keys.Keys.Back):
+		v.mode = BulkModeMenu
+		v.cursor = indexOf(bulkActions, v.action)
+	case key.Matches(msg, keys.Keys.Up):
+		if v.cursor > 0 {
+			v.cursor--
+		}
+	case key.Matches(msg, keys.Keys.Down):
+		if v.cursor < len(opts)-1 {
+			v.cursor++
+		}
+	case key.Matches(msg, keys.Keys.Enter):
+		if v.cursor >= len(opts) {
+			return
+		}
+		r := BulkRequest{Action: v.action}
+		switch v.action {
+		case BulkAutoRenew:
+			r.AutoRenew = v.cursor == 0
+			r.Description = fmt.Sprintf("Turn auto-renew %s", onOff(r.AutoRenew))
+		case BulkDNSTemplate:
+			r.Template = v.templates[v.cursor]
+			r.Description = fmt.Sprintf("Add the %q DNS records", r.Template.Name)
+		}
+		v.confirm(r)
+	}
+}
+
+func (v *BulkView) confirm(r BulkRequest) {
+	r.Domains = v.domains
+	v.request = r
+	v.mode = BulkModeConfirm
+}
+
+func (v *BulkView) updateConfirm(msg tea.KeyMsg) {
+	switch msg.String() {
+	case "y":
+		v.mode = BulkModeRun
+		v.running = true
+		v.stopped = false
+		v.rows = make([]bulkRow, len(v.domains))
+		for i, d := range v.domains {
+			v.rows[i] = bulkRow{domain: d}
+		}
+		v.requested = true
+	case "n", "esc":
+		v.mode = BulkModeMenu
+		v.cursor = indexOf(bulkActions, v.request.Action)
+	}
+}
+
+func indexOf(actions []BulkAction, a BulkAction) int {
+	for i, x := range actions {
+		if x == a {
+			return i
+		}
+	}
+	return 0
+}
+
+func (v *BulkView) View() string {
+	var b strings.Builder
+
+	title := styles.TitleStyle.Render(fmt.Sprintf(" Bulk actions: %d domains ", len(v.domains)))
+	b.WriteString(title)
+	b.WriteString("\n\n")
+
+	switch v.mode {
+	case BulkModeMenu:
+		for i, a := range bulkActions {
+			b.WriteString(pickRow(a.String(), i == v.cursor))
+		}
+	case BulkModePick:
+		b.WriteString(fmt.Sprintf("  %s:\n\n", v.action))
+		opts := v.pickOptions()
+		if len(opts) == 0 {
+			b.WriteString("  Nothing to choose from.\n")
+		}
+		for i, o := range opts {
+			b.WriteString(pickRow(o, i == v.cursor))
+		}
+		if v.action == BulkDNSTemplate && v.cursor < len(v.templates) {
+			b.WriteString("\n")
+			for _, r := range v.templates[v.cursor].Records {
+				name := r.Name
+				if name == "" {
+					name = "@"
+				}
+				b.WriteString(styles.HelpStyle.Render(fmt.Sprintf("  %-6s %-12s %s", r.Type, name, r.Content)))
+				b.WriteString("\n")
+			}
+		}
+	case BulkModeConfirm:
+		b.WriteString(styles.PremiumStyle.Render(fmt.Sprintf("  %s for %d domains?", v.request.Description, len(v.domains))))
+		b.WriteString("\n\n")
+		for _, d := range v.domains {
+			b.WriteString("    " + d + "\n")
+		}
+		b.WriteString("\n")
+		b.WriteString(styles.HelpStyle.Render("  y confirm · n cancel"))
+	case BulkModeRun:
+		b.WriteString(v.progressView())
+	}
+
+	return b.String()
+}
+
+func pickRow(label string, selected bool) string {
+	if selected {
+		return styles.TableSelectedStyle.Render("  > "+label) + "\n"
+	}
+	return "    " + label + "\n"
+}
+
+func (v *BulkView) progressView() string {
+	var b strings.Builder
+
+	b.WriteString(fmt.Sprintf("  %s\n\n", v.request.Description))
+
+	// Keep the domain in progress in sight on long lists.
+	visible := max(v.height-12, 5)
+	start := 0
+	for i, r := range v.rows {
+		if r.state == bulkPending {
+			start = max(i-visible/2, 0)
+			break
+		}
+	}
+	end := min(start+visible, len(v.rows))
+	start = max(end-visible, 0)
+
+	for _, r := range v.rows[start:end] {
+		var mark string
+		switch r.state {
+		case bulkPending:
+			mark = styles.HelpStyle.Render("·")
+		case bulkDone:
+			mark = styles.SuccessStyle.Render("✓")
+		case bulkFailed:
+			mark = styles.ErrorStyle.Render("✗")
+		case bulkSkipped:
+			mark = styles.HelpStyle.Render("-")
+		}
+		line := fmt.Sprintf("  %s %s", mark, r.domain)
+		switch {
+		case r.err != nil:
+			line += "  " + styles.ErrorStyle.Render(r.err.Error())
+		case r.state == bulkSkipped:
+			line += "  " + styles.HelpStyle.Render("skipped")
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	if end-start < len(v.rows) {
+		b.WriteString(styles.HelpStyle.Render(fmt.Sprintf("  %d-%d of %d", start+1, end, len(v.rows))))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	done, failed := v.counts()
+	summary := fmt.Sprintf("  %d done, %d failed", done, failed)
+	if v.running {
+		summary = fmt.Sprintf("  %d of %d: %d done, %d failed", done+failed, len(v.rows), done, failed)
+		if v.stopped {
+			summary += " · stopping after this domain"
+		}
+		b.WriteString(styles.SpinnerStyle.Render(summary))
+	} else {
+		b.WriteString(summary)
+	}
+	b.WriteString("\n")
+	if v.result != "" {
+		b.WriteString(styles.SuccessStyle.Render("  " + v.result))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func (v *BulkView) counts() (done, failed int) {
+	for _, r := range v.rows {
+		switch r.state {
+		case bulkDone:
+			done++
+		case bulkFailed:
+			failed++
+		}
+	}
+	return done, failed
+}
+
+func (v *BulkView) HelpText() string {
+	switch {
+	case v.mode == BulkModeConfirm:
+		return lipgloss.JoinHorizontal(lipgloss.Top,
+			styles.HelpStyle.Render("y"),
+			" confirm  ",
+			styles.HelpStyle.Render("n/esc"),
+			" cancel",
+		)
+	case v.running:
+		return lipgloss.JoinHorizontal(lipgloss.Top,
+			styles.HelpStyle.Render("esc"),
+			" stop after this domain",
+		)
+	case v.mode == BulkModeRun:
+		return lipgloss.JoinHorizontal(lipgloss.Top,
+			styles.HelpStyle.Render("esc"),
+			" back to the list",
+		)
+	default:
+		return lipgloss.JoinHorizontal(lipgloss.Top,
+			styles.HelpStyle.Render("j/k"),
+			" navigate  ",
+			styles.HelpStyle.Render("enter"),
+			" select  ",
+			styles.HelpStyle.Render("esc"),
+			" back",
+		)
+	}
+}
+
+func (v *BulkView) StatusText() string {
+	if v.mode != BulkModeRun {
+		return fmt.Sprintf("%d marked domains", len(v.domains))
+	}
+	done, failed := v.counts()
+	return fmt.Sprintf("%d/%d done, %d failed", done, len(v.rows), failed)
+}
@@ -0,0 +1,109 @@
+package views
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/bc/porkbun-tui/internal/dnstemplate"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func bulkKey(v *BulkView, s string) *BulkView {
+	var msg tea.KeyMsg
+	switch s {
+	case "enter":
+		msg = tea.KeyMsg{Type: tea.KeyEnter}
+	case "esc":
+		msg = tea.KeyMsg{Type: tea.KeyEsc}
+	default:
+		msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+	}
+	v, _ = v.Update(msg)
+	return v
+}
+
+func TestBulkView_TemplateRequestNeedsConfirmation(t *testing.T) {
+	v := NewBulkView()
+	v.Open([]string{"a.com", "b.com"})
+
+	v = bulkKey(v, "j")
+	v = bulkKey(v, "j")
+	v = bulkKey(v, "enter") // DNS template
+	v = bulkKey(v, "enter") // first template
+	if _, ok := v.TakeRequest(); ok {
+		t.Fatal("request raised before confirmation")
+	}
+	if !strings.Contains(v.View(), `Add the "No mail`) || !strings.Contains(v.View(), "for 2 domains?") {
+		t.Errorf("confirmation missing:\n%s", v.View())
+	}
+
+	v = bulkKey(v, "y")
+	r, ok := v.TakeRequest()
+	if !ok || r.Action != BulkDNSTemplate || r.Template.Name != dnstemplate.Builtin[0].Name || len(r.Domains) != 2 {
+		t.Fatalf("TakeRequest() = %+v, %v", r, ok)
+	}
+	if _, again := v.TakeRequest(); again {
+		t.Error("request raised twice")
+	}
+	if !v.IsRunning() {
+		t.Error("view not running after confirmation")
+	}
+}
+
+func TestBulkView_NameserversHandOffStraightAway(t *testing.T) {
+	v := NewBulkView()
+	v.Open([]string{"a.com", "b.com"})
+
+	v = bulkKey(v, "enter") // migrate nameservers
+	r, ok := v.TakeRequest()
+	if !ok || r.Action != BulkNameservers || len(r.Domains) != 2 {
+		t.Fatalf("TakeRequest() = %+v, %v", r, ok)
+	}
+	if v.IsRunning() {
+		t.Error("bulk view runs the migration itself; want it handed off")
+	}
+}
+
+func TestBulkView_ProgressAndStop(t *testing.T) {
+	v := NewBulkView()
+	v.Open([]string{"a.com", "b.com", "c.com"})
+	v = bulkKey(v, "j")
+	v = bulkKey(v, "j")
+	v = bulkKey(v, "enter")
+	v = bulkKey(v, "enter")
+	v = bulkKey(v, "y")
+	v.TakeRequest()
+
+	v.SetResult("a.com", nil)
+	v.SetResult("b.com", errors.New("porkbun: Invalid domain."))
+	v = bulkKey(v, "esc")
+	if !v.IsStopped() {
+		t.Fatal("esc while running did not stop")
+	}
+	v.Finish("")
+
+	out := v.View()
+	for _, want := range []string{"✓ a.com", "✗ b.com", "Invalid domain.", "c.com  skipped", "1 done, 1 failed"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("progress missing %q:\n%s", want, out)
+		}
+	}
+	if v.IsRunning() {
+		t.Error("still running after Finish")
+	}
+}
+
+func TestBulkView_EscBacksOutOfPick(t *testing.T) {
+	v := NewBulkView()
+	v.Open([]string{"a.com"})
+	v = bulkKey(v, "j")
+	v = bulkKey(v, "enter") // auto-renew on/off
+	if !v.IsEditing() {
+		t.Fatal("pick list not open")
+	}
+	v = bulkKey(v, "esc")
+	if v.IsEditing() || v.cursor != 1 {
+		t.Errorf("esc left editing = %v, cursor = %d; want the menu on auto-renew", v.IsEditing(), v.cursor)
+	}
+}
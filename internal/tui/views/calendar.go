@@ -2,73 +2,88 @@ package views
 
 import (
 	"fmt"
-	"sort"
 	"strings"
 	"time"
 
 	"github.com/bc/porkbun-tui/internal/api"
 	"github.com/bc/porkbun-tui/internal/keys"
+	"github.com/bc/porkbun-tui/internal/portfolio"
 	"github.com/bc/porkbun-tui/internal/styles"
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
 
+// MonthGroup is a month of expirations as listed in the calendar.
 type MonthGroup struct {
-	Year     int
-	Month    time.Month
-	Domains  []api.Domain
+	portfolio.MonthTotal
 	Expanded bool
 }
 
+type CalendarMode int
+
+const (
+	CalendarModeList CalendarMode = iota
+	CalendarModeGrid
+)
+
 type CalendarView struct {
-	groups []MonthGroup
-	cursor int // Which month group is selected
-	offset int // Line offset for scrolling
-	height int
-	width  int
+	domains []api.Domain
+	groups  []MonthGroup
+	cursor  int // Which month group is selected
+	offset  int // Line offset for scrolling
+	height  int
+	width   int
+	pricing map[string]api.TLDPricing
+	budget  float64
+
+	// Grid mode: a month calendar with a selected day. byDay indexes
+	// domains by their expiration date ("2006-01-02").
+	mode      CalendarMode
+	selected  time.Time
+	byDay     map[string][]api.Domain
+	dayOpen   bool // the selected day's domain list has focus
+	dayCursor int
+	// detailRequested is the one-shot edge for the app to open DetailView
+	// on the domain under dayCursor.
+	detailRequested bool
+
+	// exportRequested is the one-shot edge for the app to write the .ics.
+	exportRequested bool
+	exported        string
+	exportErr       error
 }
 
 func NewCalendarView() *CalendarView {
-	return &CalendarView{}
+	return &CalendarView{
+		selected: dateOnly(time.Now()),
+		byDay:    make(map[string][]api.Domain),
+	}
+}
+
+func dateOnly(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
+
+func dayKey(t time.Time) string {
+	return t.Format("2006-01-02")
 }
 
 func (v *CalendarView) SetDomains(domains []api.Domain) {
-	// Group domains by expiration month/year
-	groupMap := make(map[string]*MonthGroup)
+	v.domains = domains
 
+	v.byDay = make(map[string][]api.Domain)
 	for _, d := range domains {
-		key := fmt.Sprintf("%d-%02d", d.ExpireDate.Year(), d.ExpireDate.Month())
-		if g, ok := groupMap[key]; ok {
-			g.Domains = append(g.Domains, d)
-		} else {
-			groupMap[key] = &MonthGroup{
-				Year:     d.ExpireDate.Year(),
-				Month:    d.ExpireDate.Month(),
-				Domains:  []api.Domain{d},
-				Expanded: false,
-			}
-		}
+		day := dayKey(d.ExpireDate)
+		v.byDay[day] = append(v.byDay[day], d)
 	}
 
-	// Convert to slice
-	v.groups = make([]MonthGroup, 0, len(groupMap))
-	for _, g := range groupMap {
-		// Sort domains within group by expiration date
-		sort.Slice(g.Domains, func(i, j int) bool {
-			return g.Domains[i].ExpireDate.Before(g.Domains[j].ExpireDate)
-		})
-		v.groups = append(v.groups, *g)
+	months := portfolio.ExpiringByMonth(domains, v.pricing)
+	v.groups = make([]MonthGroup, len(months))
+	for i, m := range months {
+		v.groups[i] = MonthGroup{MonthTotal: m}
 	}
 
-	// Sort groups by date (earliest first)
-	sort.Slice(v.groups, func(i, j int) bool {
-		if v.groups[i].Year != v.groups[j].Year {
-			return v.groups[i].Year < v.groups[j].Year
-		}
-		return v.groups[i].Month < v.groups[j].Month
-	})
-
 	// Auto-expand first month (usually the most urgent)
 	if len(v.groups) > 0 {
 		v.groups[0].Expanded = true
@@ -76,11 +91,115 @@ func (v *CalendarView) SetDomains(domains []api.Domain) {
 
 	v.cursor = 0
 	v.offset = 0
+	v.dayOpen = false
+	v.dayCursor = 0
+}
+
+// Mode reports whether the view shows the month list or the month grid.
+func (v *CalendarView) Mode() CalendarMode {
+	return v.mode
+}
+
+// SelectedDay returns the day highlighted in grid mode.
+func (v *CalendarView) SelectedDay() time.Time {
+	return v.selected
+}
+
+// IsDayOpen reports whether the selected day's domain list has focus; esc
+// then closes the list instead of leaving the view.
+func (v *CalendarView) IsDayOpen() bool {
+	return v.dayOpen
+}
+
+// TakeDetailRequest returns the domain picked from a day's list, exactly
+// once per pick.
+func (v *CalendarView) TakeDetailRequest() (*api.Domain, bool) {
+	if !v.detailRequested {
+		return nil, false
+	}
+	v.detailRequested = false
+	domains := v.byDay[dayKey(v.selected)]
+	if v.dayCursor >= len(domains) {
+		return nil, false
+	}
+	d := domains[v.dayCursor]
+	return &d, true
+}
+
+// Domains returns the domains the calendar was built from.
+func (v *CalendarView) Domains() []api.Domain {
+	return v.domains
+}
+
+func (v *CalendarView) requestExport() {
+	if len(v.domains) == 0 {
+		return
+	}
+	v.exportRequested = true
+	v.exported, v.exportErr = "", nil
+}
+
+// TakeExportRequest returns true exactly once per .ics export keypress.
+func (v *CalendarView) TakeExportRequest() bool {
+	if v.exportRequested {
+		v.exportRequested = false
+		return true
+	}
+	return false
+}
+
+// SetExportResult records where the .ics was written, or why not.
+func (v *CalendarView) SetExportResult(path string, err error) {
+	v.exported = path
+	v.exportErr = err
+}
+
+func (v *CalendarView) exportStatus() string {
+	if v.exportErr != nil {
+		return "\n" + styles.ErrorStyle.Render(fmt.Sprintf("  Export failed: %v", v.exportErr))
+	}
+	if v.exported != "" {
+		return "\n" + styles.SuccessStyle.Render("  Exported "+v.exported)
+	}
+	return ""
+}
+
+// moveSelection moves by days or by whole months. A month step keeps the
+// day of month where it can and clamps it otherwise, so Jan 31 steps to
+// Feb 28 rather than spilling into March.
+func (v *CalendarView) moveSelection(days, months int) {
+	if months != 0 {
+		y, m, d := v.selected.Date()
+		first := time.Date(y, m+time.Month(months), 1, 0, 0, 0, 0, v.selected.Location())
+		last := first.AddDate(0, 1, -1).Day()
+		v.selected = first.AddDate(0, 0, min(d, last)-1)
+	}
+	v.selected = v.selected.AddDate(0, 0, days)
+	v.dayCursor = 0
+}
+
+// SetPricing updates the renewal prices used for the monthly totals
+// without disturbing which months are expanded.
+func (v *CalendarView) SetPricing(pricing map[string]api.TLDPricing) {
+	v.pricing = pricing
+	v.computeCosts()
+}
+
+// SetBudget sets the monthly auto-renew budget; months above it are
+// highlighted. Zero disables the highlight.
+func (v *CalendarView) SetBudget(budget float64) {
+	v.budget = budget
+}
+
+func (v *CalendarView) computeCosts() {
+	for i := range v.groups {
+		v.groups[i].Price(v.pricing)
+	}
 }
 
 func (v *CalendarView) SetSize(width, height int) {
 	v.width = width
-	v.height = height - 6
+	v.height = height - 9 // title, totals and budget lines
 	if v.height < 1 {
 		v.height = 1
 	}
@@ -131,9 +250,17 @@ func (v *CalendarView) adjustOffset() {
 }
 
 func (v *CalendarView) Update(msg tea.Msg) (*CalendarView, tea.Cmd) {
+	if v.mode == CalendarModeGrid {
+		return v.updateGrid(msg)
+	}
+
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch {
+		case msg.String() == "g":
+			v.mode = CalendarModeGrid
+		case msg.String() == "x":
+			v.requestExport()
 		case key.Matches(msg, keys.Keys.Up):
 			if v.cursor > 0 {
 				v.cursor--
@@ -154,7 +281,67 @@ func (v *CalendarView) Update(msg tea.Msg) (*CalendarView, tea.Cmd) {
 	return v, nil
 }
 
+func (v *CalendarView) updateGrid(msg tea.Msg) (*CalendarView, tea.Cmd) {
+	km, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return v, nil
+	}
+
+	if v.dayOpen {
+		domains := v.byDay[dayKey(v.selected)]
+		switch {
+		case key.Matches(km, keys.Keys.Back):
+			v.dayOpen = false
+		case key.Matches(km, keys.Keys.Up):
+			if v.dayCursor > 0 {
+				v.dayCursor--
+			}
+		case key.Matches(km, keys.Keys.Down):
+			if v.dayCursor < len(domains)-1 {
+				v.dayCursor++
+			}
+		case key.Matches(km, keys.Keys.Enter):
+			if len(domains) > 0 {
+				v.detailRequested = true
+			}
+		}
+		return v, nil
+	}
+
+	switch km.String() {
+	case "g":
+		v.mode = CalendarModeList
+	case "x":
+		v.requestExport()
+	case "h", "left":
+		v.moveSelection(-1, 0)
+	case "l", "right":
+		v.moveSelection(1, 0)
+	case "k", "up":
+		v.moveSelection(-7, 0)
+	case "j", "down":
+		v.moveSelection(7, 0)
+	case "[":
+		v.moveSelection(0, -1)
+	case "]":
+		v.moveSelection(0, 1)
+	case ".":
+		v.selected = dateOnly(time.Now())
+		v.dayCursor = 0
+	case "enter":
+		if len(v.byDay[dayKey(v.selected)]) > 0 {
+			v.dayOpen = true
+			v.dayCursor = 0
+		}
+	}
+	return v, nil
+}
+
 func (v *CalendarView) View() string {
+	if v.mode == CalendarModeGrid {
+		return v.gridView()
+	}
+
 	var b strings.Builder
 
 	// Title
@@ -177,20 +364,24 @@ func (v *CalendarView) View() string {
 			expandIndicator = "▼"
 		}
 
-		header := fmt.Sprintf("%s %s %d (%d domains)",
+		header := fmt.Sprintf("%s %-14s (%d domains)",
 			expandIndicator,
-			g.Month.String(),
-			g.Year,
+			fmt.Sprintf("%s %d", g.Month.String(), g.Year),
 			len(g.Domains),
 		)
+		cost := v.monthCostText(g)
 
 		// Color the header based on urgency
 		daysUntilFirst := int(time.Until(g.Domains[0].ExpireDate).Hours() / 24)
 
 		if i == v.cursor {
-			header = styles.TableSelectedStyle.Render("  " + header)
+			header = styles.TableSelectedStyle.Render("  " + header + "  " + cost)
 		} else {
-			header = "  " + styles.ExpirationStyle(daysUntilFirst).Render(header)
+			costStyle := styles.HelpStyle
+			if g.OverBudget(v.budget) {
+				costStyle = styles.ErrorStyle
+			}
+			header = "  " + styles.ExpirationStyle(daysUntilFirst).Render(header) + "  " + costStyle.Render(cost)
 		}
 
 		lines = append(lines, header)
@@ -208,13 +399,23 @@ func (v *CalendarView) View() string {
 					daysStr = fmt.Sprintf("%d days", daysUntil)
 				}
 
-				domainLine := fmt.Sprintf("      %-30s  %s  %s",
+				domainLine := fmt.Sprintf("      %-30s  %s  %-9s",
 					truncate(d.Name, 30),
 					expDate,
 					daysStr,
 				)
 
-				domainLine = styles.ExpirationStyle(daysUntil).Render(domainLine)
+				price := "N/A"
+				if p := portfolio.RenewalPrice(v.pricing, d.TLD); p > 0 {
+					price = fmt.Sprintf("$%.2f", p)
+				}
+				charge := styles.AutoRenewOnStyle.Render("auto-renews")
+				if !d.AutoRenew {
+					charge = styles.AutoRenewOffStyle.Render("lapses (auto-renew off)")
+				}
+
+				domainLine = styles.ExpirationStyle(daysUntil).Render(domainLine) +
+					fmt.Sprintf("  %9s  ", price) + charge
 				lines = append(lines, domainLine)
 			}
 		}
@@ -232,17 +433,189 @@ func (v *CalendarView) View() string {
 	if totalLines > v.height {
 		scrollInfo := fmt.Sprintf(" %d-%d of %d lines ", v.offset+1, visibleEnd, totalLines)
 		b.WriteString(styles.HelpStyle.Render(scrollInfo))
+		b.WriteString("\n")
+	}
+
+	// Totals across all months
+	var charge, lapse float64
+	var lapseCount, over int
+	for _, g := range v.groups {
+		charge += g.ChargeCost
+		lapse += g.LapseCost
+		lapseCount += g.LapseCount
+		if g.OverBudget(v.budget) {
+			over++
+		}
+	}
+	b.WriteString("\n")
+	total := fmt.Sprintf("  Auto-renew charges: $%.2f", charge)
+	if lapseCount > 0 {
+		total += fmt.Sprintf("  ·  %d domains will lapse ($%.2f to renew by hand)", lapseCount, lapse)
 	}
+	b.WriteString(styles.ValueStyle.Render(total))
+	if over > 0 {
+		b.WriteString("\n")
+		b.WriteString(styles.ErrorStyle.Render(fmt.Sprintf("  %d months over the $%.2f monthly budget", over, v.budget)))
+	}
+	b.WriteString(v.exportStatus())
 
 	return b.String()
 }
 
+func (v *CalendarView) gridView() string {
+	var b strings.Builder
+
+	title := styles.TitleStyle.Render(" Expiration Calendar ")
+	b.WriteString(title)
+	b.WriteString("\n\n")
+
+	year, month := v.selected.Year(), v.selected.Month()
+	first := time.Date(year, month, 1, 0, 0, 0, 0, time.Local)
+	today := dayKey(time.Now())
+
+	const cellWidth = 6
+	monthTitle := fmt.Sprintf("%s %d", month, year)
+	pad := max(0, (7*cellWidth-len(monthTitle))/2)
+	b.WriteString("  " + strings.Repeat(" ", pad) + styles.ValueStyle.Bold(true).Render(monthTitle))
+	b.WriteString("\n")
+
+	var header strings.Builder
+	for _, wd := range []string{"Mo", "Tu", "We", "Th", "Fr", "Sa", "Su"} {
+		header.WriteString(fmt.Sprintf("%*s", cellWidth-2, wd) + "  ")
+	}
+	b.WriteString("  " + styles.HelpStyle.Render(header.String()))
+	b.WriteString("\n")
+
+	// Weeks start on Monday; Go's Weekday starts on Sunday.
+	lead := (int(first.Weekday()) + 6) % 7
+	b.WriteString("  " + strings.Repeat(" ", lead*cellWidth))
+	col := lead
+	for day := first; day.Month() == month; day = day.AddDate(0, 0, 1) {
+		key := dayKey(day)
+		count := len(v.byDay[key])
+
+		marker := "  "
+		if count == 1 {
+			marker = " •"
+		} else if count > 1 {
+			marker = fmt.Sprintf("%2d", min(count, 99))
+		}
+		cell := fmt.Sprintf("%3d%s ", day.Day(), marker)
+
+		switch {
+		case key == dayKey(v.selected):
+			cell = styles.TableSelectedStyle.Render(cell)
+		case count > 0:
+			daysUntil := int(time.Until(v.byDay[key][0].ExpireDate).Hours() / 24)
+			cell = styles.ExpirationStyle(daysUntil).Bold(true).Render(cell)
+		case key == today:
+			cell = styles.ValueStyle.Underline(true).Render(cell)
+		default:
+			cell = styles.HelpStyle.Render(cell)
+		}
+		b.WriteString(cell)
+
+		col++
+		if col == 7 {
+			col = 0
+			b.WriteString("\n  ")
+		}
+	}
+	b.WriteString("\n\n")
+
+	// Month summary from the list-mode grouping
+	for _, g := range v.groups {
+		if g.Year == year && g.Month == month {
+			b.WriteString(styles.HelpStyle.Render(fmt.Sprintf("  %d domains expire this month · %s", len(g.Domains), v.monthCostText(g))))
+			b.WriteString("\n\n")
+		}
+	}
+
+	// The selected day's domains
+	domains := v.byDay[dayKey(v.selected)]
+	b.WriteString(styles.LabelStyle.Width(0).Render(fmt.Sprintf("  %s", v.selected.Format("Monday, Jan 2 2006"))))
+	b.WriteString("\n")
+	if len(domains) == 0 {
+		b.WriteString(styles.HelpStyle.Render("  No expirations."))
+		b.WriteString("\n")
+	}
+	for i, d := range domains {
+		daysUntil := int(time.Until(d.ExpireDate).Hours() / 24)
+		auto := "auto-renews"
+		if !d.AutoRenew {
+			auto = "lapses (auto-renew off)"
+		}
+		row := fmt.Sprintf("    %-30s  %s", truncate(d.Name, 30), auto)
+		if v.dayOpen && i == v.dayCursor {
+			row = styles.TableSelectedStyle.Render(row)
+		} else {
+			row = styles.ExpirationStyle(daysUntil).Render(row)
+		}
+		b.WriteString(row)
+		b.WriteString("\n")
+	}
+
+	b.WriteString(v.exportStatus())
+
+	return b.String()
+}
+
+// monthCostText summarizes a month's money: the auto-renew charge, how many
+// domains will lapse, and whether the charge is over budget.
+func (v *CalendarView) monthCostText(g MonthGroup) string {
+	text := fmt.Sprintf("$%.2f auto-renew", g.ChargeCost)
+	if g.LapseCount > 0 {
+		text += fmt.Sprintf(" · %d lapsing", g.LapseCount)
+	}
+	if g.Unpriced > 0 {
+		text += fmt.Sprintf(" · %d unpriced", g.Unpriced)
+	}
+	if g.OverBudget(v.budget) {
+		text += fmt.Sprintf(" · OVER BUDGET ($%.2f)", v.budget)
+	}
+	return text
+}
+
 func (v *CalendarView) HelpText() string {
+	if v.mode == CalendarModeGrid {
+		if v.dayOpen {
+			return lipgloss.JoinHorizontal(lipgloss.Top,
+				styles.HelpStyle.Render("j/k"),
+				" navigate  ",
+				styles.HelpStyle.Render("enter"),
+				" details  ",
+				styles.HelpStyle.Render("esc"),
+				" back to grid",
+			)
+		}
+		return lipgloss.JoinHorizontal(lipgloss.Top,
+			styles.HelpStyle.Render("h/l"),
+			" day  ",
+			styles.HelpStyle.Render("j/k"),
+			" week  ",
+			styles.HelpStyle.Render("[/]"),
+			" month  ",
+			styles.HelpStyle.Render("."),
+			" today  ",
+			styles.HelpStyle.Render("enter"),
+			" day's domains  ",
+			styles.HelpStyle.Render("g"),
+			" list  ",
+			styles.HelpStyle.Render("x"),
+			" export .ics  ",
+			styles.HelpStyle.Render("esc"),
+			" back",
+		)
+	}
 	return lipgloss.JoinHorizontal(lipgloss.Top,
 		styles.HelpStyle.Render("j/k"),
 		" navigate  ",
 		styles.HelpStyle.Render("enter"),
 		" expand  ",
+		styles.HelpStyle.Render("g"),
+		" grid  ",
+		styles.HelpStyle.Render("x"),
+		" export .ics  ",
 		styles.HelpStyle.Render("esc"),
 		" back  ",
 		styles.HelpStyle.Render("q"),
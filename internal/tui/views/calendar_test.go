@@ -1,10 +1,12 @@
 package views
 
 import (
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/bc/porkbun-tui/internal/api"
+	tea "github.com/charmbracelet/bubbletea"
 )
 
 func TestCalendarView_SetDomains_GroupsByMonth(t *testing.T) {
@@ -216,3 +218,223 @@ func TestCalendarView_StatusText(t *testing.T) {
 		t.Errorf("expected '2 months, 3 domains', got '%s'", status)
 	}
 }
+
+func TestCalendarView_MonthlyCostsSplitAutoRenewFromLapse(t *testing.T) {
+	v := NewCalendarView()
+
+	v.SetDomains([]api.Domain{
+		{Name: "a.com", TLD: "com", AutoRenew: true, ExpireDate: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{Name: "b.com", TLD: "com", AutoRenew: true, ExpireDate: time.Date(2025, 3, 9, 0, 0, 0, 0, time.UTC)},
+		{Name: "c.io", TLD: "io", AutoRenew: false, ExpireDate: time.Date(2025, 3, 20, 0, 0, 0, 0, time.UTC)},
+		{Name: "d.xyz", TLD: "xyz", AutoRenew: true, ExpireDate: time.Date(2025, 4, 2, 0, 0, 0, 0, time.UTC)},
+	})
+	v.SetPricing(map[string]api.TLDPricing{
+		"com": {Renewal: "10.00"},
+		"io":  {Renewal: "30.00"},
+	})
+
+	march := v.groups[0]
+	if march.ChargeCost != 20 {
+		t.Errorf("March ChargeCost = %.2f, want 20.00 (two auto-renewing .com)", march.ChargeCost)
+	}
+	if march.LapseCount != 1 || march.LapseCost != 30 {
+		t.Errorf("March lapse = %d / %.2f, want 1 / 30.00", march.LapseCount, march.LapseCost)
+	}
+	if april := v.groups[1]; april.Unpriced != 1 || april.ChargeCost != 0 {
+		t.Errorf("April = %d unpriced / %.2f, want 1 / 0.00", april.Unpriced, april.ChargeCost)
+	}
+}
+
+func TestCalendarView_SetPricingKeepsExpansion(t *testing.T) {
+	v := NewCalendarView()
+	v.SetDomains([]api.Domain{
+		{Name: "a.com", TLD: "com", ExpireDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{Name: "b.com", TLD: "com", ExpireDate: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)},
+	})
+	v.groups[1].Expanded = true
+
+	v.SetPricing(map[string]api.TLDPricing{"com": {Renewal: "10.00"}})
+
+	if !v.groups[1].Expanded {
+		t.Error("a pricing refresh collapsed a month the user had expanded")
+	}
+}
+
+func TestCalendarView_BudgetHighlight(t *testing.T) {
+	v := NewCalendarView()
+	v.SetSize(120, 40)
+	v.SetDomains([]api.Domain{
+		{Name: "a.com", TLD: "com", AutoRenew: true, ExpireDate: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{Name: "b.com", TLD: "com", AutoRenew: true, ExpireDate: time.Date(2025, 3, 9, 0, 0, 0, 0, time.UTC)},
+		{Name: "c.com", TLD: "com", AutoRenew: false, ExpireDate: time.Date(2025, 5, 9, 0, 0, 0, 0, time.UTC)},
+	})
+	v.SetPricing(map[string]api.TLDPricing{"com": {Renewal: "10.00"}})
+	v.SetBudget(15)
+
+	if !v.groups[0].OverBudget(v.budget) {
+		t.Error("March ($20 auto-renew) not over a $15 budget")
+	}
+	// Lapsing domains are never charged, so they can't blow the budget.
+	if v.groups[1].OverBudget(v.budget) {
+		t.Error("May (auto-renew off only) flagged over budget")
+	}
+	if !strings.Contains(v.View(), "OVER BUDGET") {
+		t.Error("over-budget month not marked in the view")
+	}
+
+	v.SetBudget(0)
+	if v.groups[0].OverBudget(v.budget) {
+		t.Error("zero budget should disable the check")
+	}
+}
+
+func runes(s string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func TestCalendarView_GridNavigation(t *testing.T) {
+	v := NewCalendarView()
+	v.selected = time.Date(2025, 3, 31, 0, 0, 0, 0, time.Local)
+
+	v, _ = v.Update(runes("g"))
+	if v.Mode() != CalendarModeGrid {
+		t.Fatal("g did not switch to grid mode")
+	}
+
+	steps := []struct {
+		key  string
+		want string
+	}{
+		{"l", "2025-04-01"}, // day, across a month boundary
+		{"h", "2025-03-31"},
+		{"j", "2025-04-07"}, // week
+		{"k", "2025-03-31"},
+		{"[", "2025-02-28"}, // month: Feb has no 31st, so clamp
+		{"]", "2025-03-28"},
+	}
+	for _, s := range steps {
+		v, _ = v.Update(runes(s.key))
+		if got := dayKey(v.SelectedDay()); got != s.want {
+			t.Fatalf("after %q selected %s, want %s", s.key, got, s.want)
+		}
+	}
+
+	v, _ = v.Update(runes("g"))
+	if v.Mode() != CalendarModeList {
+		t.Error("g did not switch back to list mode")
+	}
+}
+
+func TestCalendarView_GridMonthStepsClampTheDay(t *testing.T) {
+	for _, tc := range []struct {
+		from string
+		key  string
+		want string
+	}{
+		{"2025-01-31", "]", "2025-02-28"},
+		{"2024-01-31", "]", "2024-02-29"},
+		{"2025-03-31", "[", "2025-02-28"},
+		{"2024-03-31", "[", "2024-02-29"},
+		{"2024-02-29", "]", "2024-03-29"},
+		{"2024-02-29", "[", "2024-01-29"},
+		{"2025-05-31", "]", "2025-06-30"},
+		{"2025-12-31", "]", "2026-01-31"},
+		{"2025-01-15", "[", "2024-12-15"},
+	} {
+		v := NewCalendarView()
+		from, _ := time.ParseInLocation("2006-01-02", tc.from, time.Local)
+		v.selected = from
+		v, _ = v.Update(runes("g"))
+		v, _ = v.Update(runes(tc.key))
+		if got := dayKey(v.SelectedDay()); got != tc.want {
+			t.Errorf("%s %q selected %s, want %s", tc.from, tc.key, got, tc.want)
+		}
+	}
+}
+
+func TestCalendarView_GridMarksExpiringDays(t *testing.T) {
+	v := NewCalendarView()
+	v.SetDomains([]api.Domain{
+		{Name: "one.com", ExpireDate: time.Date(2025, 3, 10, 12, 0, 0, 0, time.Local)},
+		{Name: "two.com", ExpireDate: time.Date(2025, 3, 18, 12, 0, 0, 0, time.Local)},
+		{Name: "three.com", ExpireDate: time.Date(2025, 3, 18, 12, 0, 0, 0, time.Local)},
+	})
+	v.selected = time.Date(2025, 3, 18, 0, 0, 0, 0, time.Local)
+	v, _ = v.Update(runes("g"))
+
+	out := v.View()
+	for _, want := range []string{"March 2025", "Mo", "10 •", "18 2", "two.com", "three.com"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("grid missing %q", want)
+		}
+	}
+	if strings.Contains(out, "one.com") {
+		t.Error("domains of an unselected day listed under the selected day")
+	}
+}
+
+func TestCalendarView_GridEnterJumpsToDomain(t *testing.T) {
+	v := NewCalendarView()
+	v.SetDomains([]api.Domain{
+		{Name: "two.com", ExpireDate: time.Date(2025, 3, 18, 12, 0, 0, 0, time.Local)},
+		{Name: "three.com", ExpireDate: time.Date(2025, 3, 18, 12, 0, 0, 0, time.Local)},
+	})
+	v.selected = time.Date(2025, 3, 18, 0, 0, 0, 0, time.Local)
+	v, _ = v.Update(runes("g"))
+
+	v, _ = v.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if !v.IsDayOpen() {
+		t.Fatal("enter on a day with expirations did not open its list")
+	}
+	if _, ok := v.TakeDetailRequest(); ok {
+		t.Fatal("detail requested before a domain was picked")
+	}
+
+	v, _ = v.Update(runes("j"))
+	v, _ = v.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	d, ok := v.TakeDetailRequest()
+	if !ok || d.Name != "three.com" {
+		t.Fatalf("TakeDetailRequest() = %v, %v; want three.com", d, ok)
+	}
+	if _, ok := v.TakeDetailRequest(); ok {
+		t.Error("TakeDetailRequest fired twice for one pick")
+	}
+
+	v, _ = v.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if v.IsDayOpen() {
+		t.Error("esc did not close the day list")
+	}
+}
+
+func TestCalendarView_GridEnterOnEmptyDayDoesNothing(t *testing.T) {
+	v := NewCalendarView()
+	v.selected = time.Date(2025, 3, 18, 0, 0, 0, 0, time.Local)
+	v, _ = v.Update(runes("g"))
+
+	v, _ = v.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if v.IsDayOpen() {
+		t.Error("opened the list of a day with no expirations")
+	}
+}
+
+func TestCalendarView_ExportRequestIsEdgeTriggered(t *testing.T) {
+	v := NewCalendarView()
+	v, _ = v.Update(runes("x"))
+	if v.TakeExportRequest() {
+		t.Fatal("export requested with no domains to export")
+	}
+
+	v.SetDomains([]api.Domain{{Name: "a.com", ExpireDate: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)}})
+	v, _ = v.Update(runes("x"))
+	if !v.TakeExportRequest() {
+		t.Fatal("x did not request an export")
+	}
+	if v.TakeExportRequest() {
+		t.Error("export request fired twice for one keypress")
+	}
+
+	v.SetExportResult("/tmp/porkbun-expirations.ics", nil)
+	if !strings.Contains(v.View(), "Exported /tmp/porkbun-expirations.ics") {
+		t.Error("export path not shown")
+	}
+}
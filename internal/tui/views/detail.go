@@ -12,8 +12,18 @@ import (
 
 type DetailView struct {
 	domain *api.Domain
-	width  int
-	height int
+	// dnssec is the known DNSSEC status by domain name; absent means
+	// not checked yet.
+	dnssec map[string]bool
+	// providers is the DNS provider by domain name, from its nameservers;
+	// absent means not checked yet.
+	providers map[string]string
+	width     int
+	height    int
+
+	// confirmingAutoRenew is the armed y/n prompt for flipping the
+	// domain's auto-renew; the app answers it.
+	confirmingAutoRenew bool
 }
 
 func NewDetailView() *DetailView {
@@ -22,6 +32,33 @@ func NewDetailView() *DetailView {
 
 func (v *DetailView) SetDomain(d *api.Domain) {
 	v.domain = d
+	v.confirmingAutoRenew = false
+}
+
+// StartAutoRenewConfirmation arms the y/n prompt for turning the shown
+// domain's auto-renew the other way.
+func (v *DetailView) StartAutoRenewConfirmation() {
+	if v.domain != nil {
+		v.confirmingAutoRenew = true
+	}
+}
+
+func (v *DetailView) IsConfirming() bool {
+	return v.confirmingAutoRenew
+}
+
+func (v *DetailView) CancelAutoRenewConfirmation() {
+	v.confirmingAutoRenew = false
+}
+
+// SetDNSSEC sets the known DNSSEC status of each domain, by name.
+func (v *DetailView) SetDNSSEC(status map[string]bool) {
+	v.dnssec = status
+}
+
+// SetProviders sets the DNS provider of each domain, by name.
+func (v *DetailView) SetProviders(providers map[string]string) {
+	v.providers = providers
 }
 
 func (v *DetailView) SetSize(width, height int) {
@@ -44,6 +81,11 @@ func (v *DetailView) View() string {
 
 	// Domain info
 	daysUntil := int(time.Until(d.ExpireDate).Hours() / 24)
+	dnssecValue, dnssecStyle := dnssecBadge(v.dnssec, d.Name)
+	providerValue, providerStyle := "checking...", styles.HelpStyle
+	if p, ok := v.providers[d.Name]; ok {
+		providerValue, providerStyle = p, styles.ValueStyle
+	}
 
 	rows := []struct {
 		label string
@@ -58,6 +100,8 @@ func (v *DetailView) View() string {
 		{"Auto-Renew", boolToYesNo(d.AutoRenew), boolStyle(d.AutoRenew)},
 		{"Security Lock", boolToYesNo(d.SecurityLock), boolStyle(d.SecurityLock)},
 		{"WHOIS Privacy", boolToYesNo(d.WhoisPrivacy), boolStyle(d.WhoisPrivacy)},
+		{"DNSSEC", dnssecValue, dnssecStyle},
+		{"DNS Provider", providerValue, providerStyle},
 	}
 
 	labelWidth := 16 // Wide enough for "WHOIS Privacy:"
@@ -77,12 +121,27 @@ func (v *DetailView) View() string {
 	}
 
 	b.WriteString("\n")
-	b.WriteString(styles.HelpStyle.Render("  j/k: prev/next domain  d: DNS  n: nameservers  esc: back"))
+	if v.confirmingAutoRenew {
+		prompt := fmt.Sprintf("  Turn auto-renew %s for %s?", onOff(!d.AutoRenew), d.Name)
+		b.WriteString(styles.PremiumStyle.Render(prompt))
+		b.WriteString("\n")
+		b.WriteString(styles.HelpStyle.Render("  y confirm · n cancel"))
+		return b.String()
+	}
+	b.WriteString(styles.HelpStyle.Render("  j/k: prev/next domain  d: DNS  n: nameservers  s: SSL  f: forwards  A: auto-renew  esc: back"))
 
 	return b.String()
 }
 
 func (v *DetailView) HelpText() string {
+	if v.confirmingAutoRenew {
+		return lipgloss.JoinHorizontal(lipgloss.Top,
+			styles.HelpStyle.Render("y"),
+			" confirm  ",
+			styles.HelpStyle.Render("n/esc"),
+			" cancel",
+		)
+	}
 	return lipgloss.JoinHorizontal(lipgloss.Top,
 		styles.HelpStyle.Render("j/k"),
 		" prev/next  ",
@@ -90,6 +149,14 @@ func (v *DetailView) HelpText() string {
 		" dns  ",
 		styles.HelpStyle.Render("n"),
 		" nameservers  ",
+		styles.HelpStyle.Render("s"),
+		" ssl  ",
+		styles.HelpStyle.Render("f"),
+		" forwards  ",
+		styles.HelpStyle.Render("D"),
+		" dnssec  ",
+		styles.HelpStyle.Render("A"),
+		" auto-renew  ",
 		styles.HelpStyle.Render("esc"),
 		" back  ",
 		styles.HelpStyle.Render("q"),
@@ -97,6 +164,25 @@ func (v *DetailView) HelpText() string {
 	)
 }
 
+// dnssecBadge shows the domain's DNSSEC status once it has been checked.
+func dnssecBadge(status map[string]bool, name string) (string, lipgloss.Style) {
+	on, known := status[name]
+	switch {
+	case !known:
+		return "checking...", styles.HelpStyle
+	case on:
+		return "On", styles.AutoRenewOnStyle
+	}
+	return "Off", styles.AutoRenewOffStyle
+}
+
+func onOff(b bool) string {
+	if b {
+		return "on"
+	}
+	return "off"
+}
+
 func boolToYesNo(b bool) string {
 	if b {
 		return "Yes"
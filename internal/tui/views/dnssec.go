@@ -0,0 +1,319 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bc/porkbun-tui/internal/api"
+	"github.com/bc/porkbun-tui/internal/keys"
+	"github.com/bc/porkbun-tui/internal/styles"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type DNSSECViewMode int
+
+const (
+	DNSSECModeList DNSSECViewMode = iota
+	DNSSECModeAdd
+	DNSSECModeConfirmDelete
+)
+
+// DNSSECView lists the DS records published for a domain and adds one
+// pasted from the DNS provider, or deletes one. The app parses and sends
+// the requests, then reports back with SetSuccess or SetError.
+type DNSSECView struct {
+	domain  string
+	records []api.DSRecord
+	cursor  int
+	mode    DNSSECViewMode
+	width   int
+	height  int
+	loading bool
+	saving  bool
+	err     error
+	success string
+
+	input textinput.Model
+
+	// addRequested and deleteRequested are one-shot edges for the app;
+	// saving stays true while the request is in flight.
+	addRequested    bool
+	deleteRequested bool
+}
+
+func NewDNSSECView() *DNSSECView {
+	ti := textinput.New()
+	ti.Placeholder = "2371 13 2 1F987CC6583E92DF0890718C42..."
+	ti.CharLimit = 400
+	ti.Width = 70
+	return &DNSSECView{input: ti}
+}
+
+func (v *DNSSECView) SetDomain(domain string) {
+	v.domain = domain
+	v.records = nil
+	v.cursor = 0
+	v.mode = DNSSECModeList
+	v.loading = true
+	v.saving = false
+	v.err = nil
+	v.success = ""
+}
+
+// Domain is the domain the view shows.
+func (v *DNSSECView) Domain() string {
+	return v.domain
+}
+
+func (v *DNSSECView) SetRecords(records []api.DSRecord) {
+	v.records = records
+	v.loading = false
+	if v.cursor >= len(records) {
+		v.cursor = max(len(records)-1, 0)
+	}
+}
+
+// SetError reports a failed load or change. A failed add keeps the form
+// open so the pasted record can be fixed.
+func (v *DNSSECView) SetError(err error) {
+	v.err = err
+	v.loading = false
+	v.saving = false
+	if v.mode == DNSSECModeConfirmDelete {
+		v.mode = DNSSECModeList
+	}
+}
+
+// SetSuccess reports a completed change and returns to the list.
+func (v *DNSSECView) SetSuccess(msg string) {
+	v.success = msg
+	v.saving = false
+	v.mode = DNSSECModeList
+}
+
+func (v *DNSSECView) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+}
+
+func (v *DNSSECView) IsSaving() bool {
+	return v.saving
+}
+
+// IsEditing reports whether the paste-in form or the delete prompt is
+// open; it owns every printable key, and esc closes it rather than the
+// view.
+func (v *DNSSECView) IsEditing() bool {
+	return v.mode != DNSSECModeList
+}
+
+// TakeAddRequest returns the pasted DS record text, once per submission.
+func (v *DNSSECView) TakeAddRequest() (string, bool) {
+	if !v.addRequested {
+		return "", false
+	}
+	v.addRequested = false
+	return v.input.Value(), true
+}
+
+// TakeDeleteRequest returns the record to delete, once per confirmation.
+func (v *DNSSECView) TakeDeleteRequest() (api.DSRecord, bool) {
+	if !v.deleteRequested || v.cursor >= len(v.records) {
+		return api.DSRecord{}, false
+	}
+	v.deleteRequested = false
+	return v.records[v.cursor], true
+}
+
+func (v *DNSSECView) Update(msg tea.Msg) (*DNSSECView, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return v, nil
+	}
+	switch v.mode {
+	case DNSSECModeAdd:
+		return v.updateAdd(keyMsg)
+	case DNSSECModeConfirmDelete:
+		return v.updateConfirm(keyMsg)
+	default:
+		return v.updateList(keyMsg)
+	}
+}
+
+func (v *DNSSECView) updateList(msg tea.KeyMsg) (*DNSSECView, tea.Cmd) {
+	if v.loading || v.saving {
+		return v, nil
+	}
+	switch {
+	case key.Matches(msg, keys.Keys.Up):
+		if v.cursor > 0 {
+			v.cursor--
+		}
+	case key.Matches(msg, keys.Keys.Down):
+		if v.cursor < len(v.records)-1 {
+			v.cursor++
+		}
+	case msg.String() == "a":
+		v.mode = DNSSECModeAdd
+		v.err = nil
+		v.success = ""
+		v.input.SetValue("")
+		v.input.Focus()
+		return v, textinput.Blink
+	case msg.String() == "x":
+		if v.cursor < len(v.records) {
+			v.mode = DNSSECModeConfirmDelete
+			v.err = nil
+			v.success = ""
+		}
+	}
+	return v, nil
+}
+
+func (v *DNSSECView) updateAdd(msg tea.KeyMsg) (*DNSSECView, tea.Cmd) {
+	if v.saving {
+		return v, nil
+	}
+	switch {
+	case key.Matches(msg, keys.Keys.Back):
+		v.mode = DNSSECModeList
+		v.input.Blur()
+		v.err = nil
+		return v, nil
+	case msg.String() == "enter", msg.String() == "ctrl+s":
+		v.saving = true
+		v.addRequested = true
+		v.err = nil
+		return v, nil
+	}
+
+	var cmd tea.Cmd
+	v.input, cmd = v.input.Update(msg)
+	return v, cmd
+}
+
+func (v *DNSSECView) updateConfirm(msg tea.KeyMsg) (*DNSSECView, tea.Cmd) {
+	if v.saving {
+		return v, nil
+	}
+	switch msg.String() {
+	case "y":
+		v.saving = true
+		v.deleteRequested = true
+	case "n", "esc":
+		v.mode = DNSSECModeList
+	}
+	return v, nil
+}
+
+func (v *DNSSECView) View() string {
+	var b strings.Builder
+
+	title := styles.TitleStyle.Render(fmt.Sprintf(" DNSSEC: %s ", v.domain))
+	b.WriteString(title)
+	b.WriteString("\n\n")
+
+	if v.loading {
+		b.WriteString("  Loading DS records...")
+		return b.String()
+	}
+
+	if v.err != nil {
+		b.WriteString(styles.ErrorStyle.Render(fmt.Sprintf("  Error: %v", v.err)))
+		if isAPIAccessError(v.err) {
+			b.WriteString("\n")
+			b.WriteString(styles.HelpStyle.Render("  This domain needs API access enabled.\n"))
+			b.WriteString(styles.HelpStyle.Render("  Go to porkbun.com → Domain Management → " + v.domain + " → API Access → ON"))
+		}
+		b.WriteString("\n\n")
+	}
+	if v.success != "" {
+		b.WriteString(styles.SuccessStyle.Render(fmt.Sprintf("  %s\n\n", v.success)))
+	}
+
+	if v.mode == DNSSECModeAdd {
+		b.WriteString("  Paste the DS record from your DNS provider:\n\n  ")
+		b.WriteString(v.input.View())
+		b.WriteString("\n\n")
+		if v.saving {
+			b.WriteString(styles.SpinnerStyle.Render("  Adding..."))
+		} else {
+			b.WriteString(styles.HelpStyle.Render("  <key tag> <algorithm> <digest type> <digest>, or the whole DS line · enter to add, esc to cancel"))
+		}
+		return b.String()
+	}
+
+	if len(v.records) == 0 {
+		b.WriteString("  No DS records: DNSSEC is off at the registry.\n")
+	} else {
+		header := fmt.Sprintf("  %-8s  %-20s  %-12s  %s", "Key Tag", "Algorithm", "Digest Type", "Digest")
+		b.WriteString(styles.TableHeaderStyle.Render(header))
+		b.WriteString("\n")
+		for i, r := range v.records {
+			row := fmt.Sprintf("  %-8s  %-20s  %-12s  %s",
+				r.KeyTag,
+				fmt.Sprintf("%s (%s)", r.Algorithm, api.AlgorithmName(r.Algorithm)),
+				fmt.Sprintf("%s (%s)", r.DigestType, api.DigestTypeName(r.DigestType)),
+				r.Digest,
+			)
+			if i == v.cursor {
+				row = styles.TableSelectedStyle.Render(row)
+			}
+			b.WriteString(row)
+			b.WriteString("\n")
+		}
+	}
+
+	if v.mode == DNSSECModeConfirmDelete {
+		b.WriteString("\n")
+		prompt := fmt.Sprintf("  Delete DS record %s? Resolvers will fail validation if the zone is still signed with only this key.", v.records[v.cursor].KeyTag)
+		b.WriteString(styles.PremiumStyle.Render(prompt))
+		b.WriteString("\n")
+		if v.saving {
+			b.WriteString(styles.SpinnerStyle.Render("  Deleting..."))
+		} else {
+			b.WriteString(styles.HelpStyle.Render("  y confirm · n cancel"))
+		}
+	}
+
+	return b.String()
+}
+
+func (v *DNSSECView) HelpText() string {
+	switch v.mode {
+	case DNSSECModeAdd:
+		return lipgloss.JoinHorizontal(lipgloss.Top,
+			styles.HelpStyle.Render("enter"),
+			" add  ",
+			styles.HelpStyle.Render("esc"),
+			" cancel",
+		)
+	case DNSSECModeConfirmDelete:
+		return lipgloss.JoinHorizontal(lipgloss.Top,
+			styles.HelpStyle.Render("y"),
+			" delete  ",
+			styles.HelpStyle.Render("n/esc"),
+			" cancel",
+		)
+	default:
+		return lipgloss.JoinHorizontal(lipgloss.Top,
+			styles.HelpStyle.Render("j/k"),
+			" navigate  ",
+			styles.HelpStyle.Render("a"),
+			" add  ",
+			styles.HelpStyle.Render("x"),
+			" delete  ",
+			styles.HelpStyle.Render("esc"),
+			" back  ",
+			styles.HelpStyle.Render("q"),
+			" quit",
+		)
+	}
+}
+
+func (v *DNSSECView) StatusText() string {
+	return fmt.Sprintf("%d DS records", len(v.records))
+}
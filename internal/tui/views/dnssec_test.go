@@ -0,0 +1,87 @@
+package views
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/bc/porkbun-tui/internal/api"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func loadedDNSSECView() *DNSSECView {
+	v := NewDNSSECView()
+	v.SetSize(160, 40)
+	v.SetDomain("example.com")
+	v.SetRecords([]api.DSRecord{
+		{KeyTag: "2371", Algorithm: "13", DigestType: "2", Digest: "1F987CC6583E92DF0890718C42"},
+	})
+	return v
+}
+
+func TestDNSSECView_ListShowsRecordFields(t *testing.T) {
+	out := loadedDNSSECView().View()
+	for _, want := range []string{"2371", "13 (ECDSAP256SHA256)", "2 (SHA-256)", "1F987CC6583E92DF0890718C42"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("view missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestDNSSECView_EmptyListSaysOff(t *testing.T) {
+	v := NewDNSSECView()
+	v.SetDomain("example.com")
+	v.SetRecords(nil)
+	if out := v.View(); !strings.Contains(out, "DNSSEC is off") {
+		t.Errorf("empty list doesn't say DNSSEC is off:\n%s", out)
+	}
+}
+
+func TestDNSSECView_PastedRecordRaisesOneRequest(t *testing.T) {
+	v := loadedDNSSECView()
+	v, _ = v.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	if !v.IsEditing() {
+		t.Fatal("a did not open the paste-in form")
+	}
+	pasted := "example.com. 3600 IN DS 2371 13 2 1F98"
+	v, _ = v.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(pasted), Paste: true})
+	v, _ = v.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	text, ok := v.TakeAddRequest()
+	if !ok || text != pasted {
+		t.Fatalf("TakeAddRequest() = %q, %v; want the pasted line", text, ok)
+	}
+	if _, again := v.TakeAddRequest(); again {
+		t.Error("add request fired twice")
+	}
+
+	// A rejected record keeps the form open for correction.
+	v.SetError(errors.New("a SHA-256 digest is 64 hex characters"))
+	if !v.IsEditing() || v.IsSaving() {
+		t.Error("form closed or still saving after an error")
+	}
+}
+
+func TestDNSSECView_DeleteNeedsConfirmation(t *testing.T) {
+	v := loadedDNSSECView()
+	v, _ = v.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	if _, ok := v.TakeDeleteRequest(); ok {
+		t.Fatal("x deleted without confirmation")
+	}
+	v, _ = v.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	if v.IsEditing() {
+		t.Fatal("n did not cancel the delete prompt")
+	}
+
+	v, _ = v.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	v, _ = v.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	r, ok := v.TakeDeleteRequest()
+	if !ok || r.KeyTag != "2371" {
+		t.Errorf("TakeDeleteRequest() = %+v, %v; want key tag 2371", r, ok)
+	}
+
+	v.SetSuccess("DS record 2371 deleted.")
+	if v.IsEditing() || v.IsSaving() {
+		t.Error("view not back to the list after success")
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/bc/porkbun-tui/internal/alerts"
 	"github.com/bc/porkbun-tui/internal/api"
 	"github.com/bc/porkbun-tui/internal/keys"
 	"github.com/bc/porkbun-tui/internal/styles"
@@ -22,6 +23,16 @@ const (
 	SortByExpiration
 )
 
+// DNSSECFilter narrows the list by DNSSEC status. Domains whose status
+// hasn't been checked yet match neither DNSSECOn nor DNSSECOff.
+type DNSSECFilter int
+
+const (
+	DNSSECAny DNSSECFilter = iota
+	DNSSECOn
+	DNSSECOff
+)
+
 type DomainsView struct {
 	domains       []api.Domain
 	filtered      []api.Domain
@@ -33,6 +44,26 @@ type DomainsView struct {
 	searching     bool
 	sortField     SortField
 	sortAscending bool
+
+	// alertRules from config.yaml; alertHits indexes their hits by domain
+	// name for row highlighting.
+	alertRules []alerts.Rule
+	alertHits  map[string]alerts.Hit
+
+	// dnssec is the known DNSSEC status by domain name.
+	dnssec       map[string]bool
+	dnssecFilter DNSSECFilter
+
+	// providers is the DNS provider by domain name, from its nameservers.
+	providers map[string]string
+
+	// marked are the names picked for a bulk action. Marks survive
+	// filtering and refreshes of domains still in the list.
+	marked map[string]bool
+
+	// confirmingAutoRenew is the armed prompt for setting auto-renew on
+	// every shown domain; the app answers it.
+	confirmingAutoRenew bool
 }
 
 func NewDomainsView() *DomainsView {
@@ -51,8 +82,158 @@ func NewDomainsView() *DomainsView {
 
 func (v *DomainsView) SetDomains(domains []api.Domain) {
 	v.domains = domains
+	if len(v.marked) > 0 {
+		present := make(map[string]bool, len(domains))
+		for _, d := range domains {
+			present[d.Name] = true
+		}
+		for name := range v.marked {
+			if !present[name] {
+				delete(v.marked, name)
+			}
+		}
+	}
 	v.applyFilter()
 	v.sortDomains()
+	v.evaluateAlerts()
+}
+
+// SetAlertRules sets the alert rules whose hits are highlighted.
+func (v *DomainsView) SetAlertRules(rules []alerts.Rule) {
+	v.alertRules = rules
+	v.evaluateAlerts()
+}
+
+// AlertHit returns the alert rules the named domain trips, if any.
+func (v *DomainsView) AlertHit(name string) (alerts.Hit, bool) {
+	hit, ok := v.alertHits[name]
+	return hit, ok
+}
+
+func (v *DomainsView) evaluateAlerts() {
+	v.alertHits = make(map[string]alerts.Hit)
+	for _, h := range alerts.Evaluate(v.alertRules, v.domains, time.Now()) {
+		v.alertHits[h.Domain.Name] = h
+	}
+}
+
+// SetDNSSEC sets the known DNSSEC status of each domain, by name. The
+// cursor stays on the selected domain while the filter still shows it.
+func (v *DomainsView) SetDNSSEC(status map[string]bool) {
+	var selected string
+	if d := v.SelectedDomain(); d != nil {
+		selected = d.Name
+	}
+	v.dnssec = status
+	v.applyFilter()
+	v.sortDomains()
+	for i, d := range v.filtered {
+		if d.Name == selected {
+			v.setCursor(i)
+			break
+		}
+	}
+}
+
+// SetProviders sets the DNS provider of each domain, by name, for the DNS
+// column.
+func (v *DomainsView) SetProviders(providers map[string]string) {
+	v.providers = providers
+}
+
+func (v *DomainsView) DNSSECFilter() DNSSECFilter {
+	return v.dnssecFilter
+}
+
+// UncheckedDNSSEC lists the domains with no known DNSSEC status.
+func (v *DomainsView) UncheckedDNSSEC() []string {
+	var names []string
+	for _, d := range v.domains {
+		if _, ok := v.dnssec[d.Name]; !ok {
+			names = append(names, d.Name)
+		}
+	}
+	return names
+}
+
+// SetAutoRenew sets the auto-renew flag of the named domains in the list
+// and returns what each was before, for rolling back.
+func (v *DomainsView) SetAutoRenew(status map[string]bool) map[string]bool {
+	prev := make(map[string]bool, len(status))
+	for i := range v.domains {
+		if on, ok := status[v.domains[i].Name]; ok {
+			prev[v.domains[i].Name] = v.domains[i].AutoRenew
+			v.domains[i].AutoRenew = on
+		}
+	}
+	// filtered holds copies when a filter is active.
+	for i := range v.filtered {
+		if on, ok := status[v.filtered[i].Name]; ok {
+			v.filtered[i].AutoRenew = on
+		}
+	}
+	v.evaluateAlerts()
+	return prev
+}
+
+// Marked lists the marked domains, in list order.
+func (v *DomainsView) Marked() []string {
+	var names []string
+	shown := make(map[string]bool, len(v.filtered))
+	for _, d := range v.filtered {
+		shown[d.Name] = true
+		if v.marked[d.Name] {
+			names = append(names, d.Name)
+		}
+	}
+	// Marks hidden by the filter still count.
+	for _, d := range v.domains {
+		if v.marked[d.Name] && !shown[d.Name] {
+			names = append(names, d.Name)
+		}
+	}
+	return names
+}
+
+// ClearMarks unmarks every domain.
+func (v *DomainsView) ClearMarks() {
+	v.marked = nil
+}
+
+func (v *DomainsView) toggleMark(name string) {
+	if v.marked == nil {
+		v.marked = make(map[string]bool)
+	}
+	if v.marked[name] {
+		delete(v.marked, name)
+	} else {
+		v.marked[name] = true
+	}
+}
+
+// ShownDomains lists the names of the domains the list shows, in order.
+func (v *DomainsView) ShownDomains() []string {
+	names := make([]string, len(v.filtered))
+	for i, d := range v.filtered {
+		names[i] = d.Name
+	}
+	return names
+}
+
+// StartAutoRenewConfirmation arms the prompt for setting auto-renew on
+// every shown domain.
+func (v *DomainsView) StartAutoRenewConfirmation() {
+	if len(v.filtered) > 0 {
+		v.confirmingAutoRenew = true
+	}
+}
+
+func (v *DomainsView) IsConfirmingAutoRenew() bool {
+	return v.confirmingAutoRenew
+}
+
+func (v *DomainsView) CancelAutoRenewConfirmation() {
+	v.confirmingAutoRenew = false
 }
 
 func (v *DomainsView) SetSize(width, height int) {
@@ -65,12 +246,12 @@ func (v *DomainsView) SetSize(width, height int) {
 
 func (v *DomainsView) applyFilter() {
 	query := strings.ToLower(v.searchInput.Value())
-	if query == "" {
+	if query == "" && v.dnssecFilter == DNSSECAny {
 		v.filtered = v.domains
 	} else {
 		v.filtered = nil
 		for _, d := range v.domains {
-			if strings.Contains(strings.ToLower(d.Name), query) {
+			if strings.Contains(strings.ToLower(d.Name), query) && v.matchesDNSSEC(d.Name) {
 				v.filtered = append(v.filtered, d)
 			}
 		}
@@ -78,6 +259,17 @@ func (v *DomainsView) applyFilter() {
 	v.clampScroll()
 }
 
+func (v *DomainsView) matchesDNSSEC(name string) bool {
+	on, known := v.dnssec[name]
+	switch v.dnssecFilter {
+	case DNSSECOn:
+		return known && on
+	case DNSSECOff:
+		return known && !on
+	}
+	return true
+}
+
 // clampScroll keeps cursor and offset inside the filtered list whenever it
 // changes size — filtering while scrolled down, or a refresh shrinking the
 // list, otherwise leaves a stale offset that renders zero rows and a stale
@@ -114,6 +306,46 @@ func (v *DomainsView) SelectedDomain() *api.Domain {
 	return &v.filtered[v.cursor]
 }
 
+// SelectDomain moves the cursor onto the named domain, clearing the search
+// and DNSSEC filters if they hide it, so views that jump into DetailView from elsewhere
+// leave the list selection (and with it d/n/j/k in the detail view) on
+// the same domain. It reports whether the domain was found.
+func (v *DomainsView) SelectDomain(name string) bool {
+	find := func() int {
+		for i, d := range v.filtered {
+			if d.Name == name {
+				return i
+			}
+		}
+		return -1
+	}
+
+	i := find()
+	if i < 0 && (v.searchInput.Value() != "" || v.dnssecFilter != DNSSECAny) {
+		v.searchInput.SetValue("")
+		v.dnssecFilter = DNSSECAny
+		v.applyFilter()
+		v.sortDomains()
+		i = find()
+	}
+	if i < 0 {
+		return false
+	}
+
+	v.setCursor(i)
+	return true
+}
+
+// setCursor moves the cursor to row i, scrolling to keep it visible.
+func (v *DomainsView) setCursor(i int) {
+	v.cursor = i
+	if v.cursor < v.offset {
+		v.offset = v.cursor
+	} else if v.cursor >= v.offset+v.height {
+		v.offset = v.cursor - v.height + 1
+	}
+}
+
 func (v *DomainsView) GetDomains() []api.Domain {
 	return v.domains
 }
@@ -179,13 +411,37 @@ func (v *DomainsView) Update(msg tea.Msg) (*DomainsView, tea.Cmd) {
 			v.searchInput.Focus()
 			return v, textinput.Blink
 		case key.Matches(msg, keys.Keys.Back):
-			// Clear search filter if active
+			// Clear search filter if active, then the marks
 			if v.searchInput.Value() != "" {
 				v.searchInput.SetValue("")
 				v.applyFilter()
 				v.sortDomains()
+			} else {
+				v.ClearMarks()
 			}
 			return v, nil
+		case key.Matches(msg, keys.Keys.Mark):
+			if d := v.SelectedDomain(); d != nil {
+				v.toggleMark(d.Name)
+				if v.cursor < len(v.filtered)-1 {
+					v.setCursor(v.cursor + 1)
+				}
+			}
+		case key.Matches(msg, keys.Keys.MarkAll):
+			for _, d := range v.filtered {
+				if !v.marked[d.Name] {
+					v.toggleMark(d.Name)
+				}
+			}
+		case key.Matches(msg, keys.Keys.Invert):
+			for _, d := range v.filtered {
+				v.toggleMark(d.Name)
+			}
+		case key.Matches(msg, keys.Keys.DNSSEC):
+			// Cycle all → DNSSEC on → DNSSEC off → all
+			v.dnssecFilter = (v.dnssecFilter + 1) % 3
+			v.applyFilter()
+			v.sortDomains()
 		case key.Matches(msg, keys.Keys.SortName):
 			if v.sortField == SortByName {
 				v.sortAscending = !v.sortAscending
@@ -223,8 +479,34 @@ func (v *DomainsView) View() string {
 		b.WriteString("\n")
 	}
 
+	if v.dnssecFilter != DNSSECAny {
+		label := "on"
+		if v.dnssecFilter == DNSSECOff {
+			label = "off"
+		}
+		note := fmt.Sprintf("  DNSSEC: %s (D to change)", label)
+		if n := len(v.UncheckedDNSSEC()); n > 0 {
+			note += fmt.Sprintf(", %d not checked yet", n)
+		}
+		b.WriteString(styles.HelpStyle.Render(note))
+		b.WriteString("\n")
+	}
+
+	if n := len(v.marked); n > 0 {
+		b.WriteString(styles.SuccessStyle.Render(fmt.Sprintf("  %d marked", n)))
+		b.WriteString(styles.HelpStyle.Render(" (b bulk actions, esc to clear)"))
+		b.WriteString("\n")
+	}
+
+	if v.confirmingAutoRenew {
+		prompt := fmt.Sprintf("  Set auto-renew for the %d shown domains?", len(v.filtered))
+		b.WriteString(styles.PremiumStyle.Render(prompt))
+		b.WriteString(styles.HelpStyle.Render("  o on · f off · esc cancel"))
+		b.WriteString("\n")
+	}
+
 	if len(v.filtered) == 0 {
-		if v.searchInput.Value() != "" {
+		if v.searchInput.Value() != "" || v.dnssecFilter != DNSSECAny {
 			b.WriteString("  No domains match your search.")
 		} else {
 			b.WriteString("  No domains found.")
@@ -238,14 +520,16 @@ func (v *DomainsView) View() string {
 	daysWidth := 8
 	autoWidth := 10
 	statusWidth := 10
+	dnsWidth := 16
 
 	// Header
-	header := fmt.Sprintf("  %-*s  %-*s  %-*s  %-*s  %-*s",
+	header := fmt.Sprintf("  %-*s  %-*s  %-*s  %-*s  %-*s  %-*s",
 		nameWidth, v.sortIndicator("Domain", SortByName),
 		expWidth, v.sortIndicator("Expires", SortByExpiration),
 		daysWidth, "Days",
 		autoWidth, "AutoRenew",
 		statusWidth, "Status",
+		dnsWidth, "DNS",
 	)
 	b.WriteString(styles.TableHeaderStyle.Render(header))
 	b.WriteString("\n")
@@ -278,23 +562,43 @@ func (v *DomainsView) View() string {
 			status = "Active"
 		}
 
+		provider, ok := v.providers[d.Name]
+		if !ok {
+			provider = "-"
+		}
+
 		// Build row with fixed-width columns (style applied after padding)
 		namePad := fmt.Sprintf("%-*s", nameWidth, name)
 		expPad := fmt.Sprintf("%-*s", expWidth, expDate)
 		daysPad := fmt.Sprintf("%-*s", daysWidth, daysStr)
 		autoPad := fmt.Sprintf("%-*s", autoWidth, autoRenew)
 		statusPad := fmt.Sprintf("%-*s", statusWidth, status)
+		dnsPad := fmt.Sprintf("%-*s", dnsWidth, truncate(provider, dnsWidth))
 
 		// Apply colors to individual columns
 		daysStyled := styles.ExpirationStyle(daysUntil).Render(daysPad)
 		autoStyled := autoStyle.Render(autoPad)
 
-		row := fmt.Sprintf("  %s  %s  %s  %s  %s",
+		// Marks and alert hits are flagged in the left gutter
+		gutter := "  "
+		hit, alerted := v.alertHits[d.Name]
+		switch {
+		case v.marked[d.Name] && alerted:
+			gutter = styles.SuccessStyle.Render("●") + alertStyle(hit.Severity).Render("!")
+		case v.marked[d.Name]:
+			gutter = styles.SuccessStyle.Render("● ")
+		case alerted:
+			gutter = alertStyle(hit.Severity).Render("! ")
+		}
+
+		row := fmt.Sprintf("%s%s  %s  %s  %s  %s  %s",
+			gutter,
 			namePad,
 			expPad,
 			daysStyled,
 			autoStyled,
 			statusPad,
+			dnsPad,
 		)
 
 		if i == v.cursor {
@@ -311,6 +615,14 @@ func (v *DomainsView) View() string {
 		b.WriteString(styles.HelpStyle.Render(scrollInfo))
 	}
 
+	// Why the selected domain is flagged
+	if d := v.SelectedDomain(); d != nil {
+		if hit, ok := v.alertHits[d.Name]; ok {
+			b.WriteString("\n")
+			b.WriteString(alertStyle(hit.Severity).Render(fmt.Sprintf("  ! %s: %s", hit.Severity, hit.RuleNames())))
+		}
+	}
+
 	return b.String()
 }
 
@@ -325,14 +637,29 @@ func (v *DomainsView) sortIndicator(label string, field SortField) string {
 	return label + " " + arrow
 }
 
+// alertStyle colors an alert marker by severity.
+func alertStyle(s alerts.Severity) lipgloss.Style {
+	if s == alerts.Critical {
+		return styles.ErrorStyle
+	}
+	return lipgloss.NewStyle().Foreground(styles.ColorOrange).Bold(true)
+}
+
 func (v *DomainsView) StatusText() string {
 	total := len(v.domains)
 	filtered := len(v.filtered)
 
-	if v.searchInput.Value() != "" {
-		return fmt.Sprintf("%d/%d domains", filtered, total)
+	text := fmt.Sprintf("%d domains", total)
+	if v.searchInput.Value() != "" || v.dnssecFilter != DNSSECAny {
+		text = fmt.Sprintf("%d/%d domains", filtered, total)
+	}
+	if n := len(v.marked); n > 0 {
+		text += fmt.Sprintf(", %d marked", n)
+	}
+	if n := len(v.alertHits); n > 0 {
+		text += fmt.Sprintf(", %d alerts", n)
 	}
-	return fmt.Sprintf("%d domains", total)
+	return text
 }
 
 func (v *DomainsView) HelpText() string {
@@ -343,10 +670,18 @@ func (v *DomainsView) HelpText() string {
 		" details  ",
 		styles.HelpStyle.Render("/"),
 		" search  ",
+		styles.HelpStyle.Render("space"),
+		" mark  ",
+		styles.HelpStyle.Render("b"),
+		" bulk  ",
 		styles.HelpStyle.Render("d"),
 		" dns  ",
 		styles.HelpStyle.Render("n"),
 		" ns  ",
+		styles.HelpStyle.Render("D"),
+		" dnssec  ",
+		styles.HelpStyle.Render("A"),
+		" auto-renew  ",
 		styles.HelpStyle.Render("a"),
 		" avail  ",
 		styles.HelpStyle.Render("t"),
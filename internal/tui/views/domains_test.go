@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/bc/porkbun-tui/internal/alerts"
 	"github.com/bc/porkbun-tui/internal/api"
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -331,3 +332,157 @@ func TestTruncate(t *testing.T) {
 		}
 	}
 }
+
+func TestDomainsView_HighlightsAlertHits(t *testing.T) {
+	v := NewDomainsView()
+	v.SetSize(120, 30)
+	rule, err := alerts.ExpiresWithin("30d", alerts.Critical)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v.SetAlertRules([]alerts.Rule{rule})
+	v.SetDomains([]api.Domain{
+		{Name: "soon.com", ExpireDate: time.Now().AddDate(0, 0, 10)},
+		{Name: "later.com", ExpireDate: time.Now().AddDate(1, 0, 0)},
+	})
+
+	if _, ok := v.AlertHit("soon.com"); !ok {
+		t.Error("soon.com not flagged")
+	}
+	if _, ok := v.AlertHit("later.com"); ok {
+		t.Error("later.com flagged")
+	}
+	if got := v.StatusText(); got != "2 domains, 1 alerts" {
+		t.Errorf("StatusText() = %q", got)
+	}
+
+	for _, line := range strings.Split(v.View(), "\n") {
+		if strings.Contains(line, "soon.com") && !strings.Contains(line, "! ") {
+			t.Errorf("soon.com row not marked: %q", line)
+		}
+		if strings.Contains(line, "later.com") && strings.Contains(line, "!") {
+			t.Errorf("later.com row marked: %q", line)
+		}
+	}
+	// soon.com sorts first and is selected: the reason is shown.
+	if !strings.Contains(v.View(), "CRITICAL: expires within 30d") {
+		t.Error("selected domain's alert reason not shown")
+	}
+}
+
+func TestDomainsView_DNSSECFilter(t *testing.T) {
+	v := NewDomainsView()
+	v.SetDomains([]api.Domain{
+		{Name: "signed.com", ExpireDate: time.Now()},
+		{Name: "unsigned.com", ExpireDate: time.Now()},
+		{Name: "unknown.com", ExpireDate: time.Now()},
+	})
+	v.SetDNSSEC(map[string]bool{"signed.com": true, "unsigned.com": false})
+
+	if got := v.UncheckedDNSSEC(); len(got) != 1 || got[0] != "unknown.com" {
+		t.Errorf("UncheckedDNSSEC() = %v, want [unknown.com]", got)
+	}
+
+	D := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("D")}
+	for _, want := range []struct {
+		filter DNSSECFilter
+		names  []string
+	}{
+		{DNSSECOn, []string{"signed.com"}},
+		{DNSSECOff, []string{"unsigned.com"}},
+		{DNSSECAny, []string{"signed.com", "unsigned.com", "unknown.com"}},
+	} {
+		v, _ = v.Update(D)
+		if v.DNSSECFilter() != want.filter {
+			t.Fatalf("filter = %v, want %v", v.DNSSECFilter(), want.filter)
+		}
+		if len(v.filtered) != len(want.names) {
+			t.Errorf("filter %v shows %d domains, want %v", want.filter, len(v.filtered), want.names)
+		}
+	}
+}
+
+func TestDomainsView_SetDNSSECKeepsSelection(t *testing.T) {
+	v := NewDomainsView()
+	v.SetSize(120, 40)
+	v.SetDomains([]api.Domain{
+		{Name: "a.com", ExpireDate: time.Now()},
+		{Name: "b.com", ExpireDate: time.Now().Add(time.Hour)},
+		{Name: "c.com", ExpireDate: time.Now().Add(2 * time.Hour)},
+	})
+	v.SetDNSSEC(map[string]bool{"a.com": true, "b.com": true, "c.com": true})
+	v.dnssecFilter = DNSSECOn
+	v.applyFilter()
+	v.sortDomains()
+	v.SelectDomain("c.com")
+
+	// a.com drops out of the filter; the cursor follows c.com.
+	v.SetDNSSEC(map[string]bool{"a.com": false, "b.com": true, "c.com": true})
+	if d := v.SelectedDomain(); d == nil || d.Name != "c.com" {
+		t.Errorf("selected = %v after a status change, want c.com", d)
+	}
+}
+
+func TestDomainsView_SetAutoRenewReturnsPrevious(t *testing.T) {
+	v := NewDomainsView()
+	v.SetDomains([]api.Domain{
+		{Name: "a.com", AutoRenew: true, ExpireDate: time.Now()},
+		{Name: "b.com", ExpireDate: time.Now()},
+	})
+	v.searchInput.SetValue("a.com")
+	v.applyFilter()
+
+	prev := v.SetAutoRenew(map[string]bool{"a.com": false, "b.com": true})
+	if !prev["a.com"] || prev["b.com"] {
+		t.Errorf("prev = %v, want a.com on and b.com off", prev)
+	}
+	// The filtered copy and the full list both change.
+	if d := v.SelectedDomain(); d == nil || d.AutoRenew {
+		t.Errorf("selected = %+v, want a.com with auto-renew off", d)
+	}
+	if v.GetDomains()[1].AutoRenew != true {
+		t.Error("b.com not updated in the full list")
+	}
+}
+
+func TestDomainsView_MarkAllAndInvert(t *testing.T) {
+	v := NewDomainsView()
+	v.SetSize(120, 40)
+	v.SetDomains([]api.Domain{
+		{Name: "a.com", ExpireDate: time.Now()},
+		{Name: "b.com", ExpireDate: time.Now().Add(time.Hour)},
+		{Name: "c.org", ExpireDate: time.Now().Add(2 * time.Hour)},
+	})
+	press := func(s string) {
+		v, _ = v.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)})
+	}
+
+	press(" ") // marks a.com and moves on
+	if got := v.Marked(); len(got) != 1 || got[0] != "a.com" {
+		t.Fatalf("Marked() = %v after space, want [a.com]", got)
+	}
+	if d := v.SelectedDomain(); d.Name != "b.com" {
+		t.Errorf("cursor on %s after space, want b.com", d.Name)
+	}
+
+	// Invert and mark-all work on the shown domains only.
+	v.searchInput.SetValue(".com")
+	v.applyFilter()
+	press("i")
+	if got := v.Marked(); len(got) != 1 || got[0] != "b.com" {
+		t.Errorf("Marked() = %v after invert, want [b.com]", got)
+	}
+	press("*")
+	if got := v.Marked(); len(got) != 2 {
+		t.Errorf("Marked() = %v after mark all, want a.com and b.com", got)
+	}
+	if !strings.Contains(v.View(), "2 marked") || !strings.Contains(v.StatusText(), "2 marked") {
+		t.Error("marked count not shown")
+	}
+
+	// A refresh drops marks of domains that are gone.
+	v.SetDomains([]api.Domain{{Name: "b.com", ExpireDate: time.Now()}})
+	if got := v.Marked(); len(got) != 1 || got[0] != "b.com" {
+		t.Errorf("Marked() = %v after refresh, want [b.com]", got)
+	}
+}
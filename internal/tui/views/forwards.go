@@ -0,0 +1,489 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bc/porkbun-tui/internal/api"
+	"github.com/bc/porkbun-tui/internal/keys"
+	"github.com/bc/porkbun-tui/internal/styles"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type ForwardsViewMode int
+
+const (
+	ForwardsModeList ForwardsViewMode = iota
+	ForwardsModeAdd
+	ForwardsModeConfirmDelete
+)
+
+// Add form fields, in tab order. The first two are text inputs; the rest
+// toggle with space.
+const (
+	fwdFieldSubdomain = iota
+	fwdFieldLocation
+	fwdFieldType
+	fwdFieldIncludePath
+	fwdFieldWildcard
+	fwdFieldCount
+)
+
+// ForwardsView lists a domain's URL forwards and adds or deletes them.
+// Like the nameserver editor it only raises requests; the app validates
+// and performs them, then reports back with SetSuccess or SetError.
+type ForwardsView struct {
+	domain   string
+	forwards []api.URLForward
+	cursor   int
+	offset   int
+	mode     ForwardsViewMode
+	width    int
+	height   int
+	loading  bool
+	saving   bool
+	err      error
+	success  string
+
+	// Add form
+	inputs      [2]textinput.Model
+	field       int
+	permanent   bool
+	includePath bool
+	wildcard    bool
+
+	// addRequested and deleteRequested are one-shot edges for the app;
+	// saving stays true while the request is in flight.
+	addRequested    bool
+	deleteRequested bool
+	deleting        api.URLForward
+}
+
+func NewForwardsView() *ForwardsView {
+	v := &ForwardsView{}
+	placeholders := [2]string{"subdomain (empty for the domain itself)", "https://example.net"}
+	for i := range v.inputs {
+		ti := textinput.New()
+		ti.Placeholder = placeholders[i]
+		ti.CharLimit = 255
+		ti.Width = 40
+		v.inputs[i] = ti
+	}
+	return v
+}
+
+func (v *ForwardsView) SetDomain(domain string) {
+	v.domain = domain
+	v.forwards = nil
+	v.cursor = 0
+	v.offset = 0
+	v.mode = ForwardsModeList
+	v.loading = true
+	v.saving = false
+	v.err = nil
+	v.success = ""
+}
+
+func (v *ForwardsView) SetForwards(forwards []api.URLForward) {
+	v.forwards = forwards
+	v.loading = false
+	if v.cursor >= len(forwards) {
+		v.cursor = max(len(forwards)-1, 0)
+	}
+	if v.offset > v.cursor {
+		v.offset = v.cursor
+	}
+}
+
+// SetError reports a failed load, add or delete. A failed add keeps the
+// form open so the input can be corrected.
+func (v *ForwardsView) SetError(err error) {
+	v.err = err
+	v.loading = false
+	v.saving = false
+	if v.mode == ForwardsModeConfirmDelete {
+		v.mode = ForwardsModeList
+	}
+}
+
+// SetSuccess reports a completed add or delete and returns to the list.
+func (v *ForwardsView) SetSuccess(msg string) {
+	v.success = msg
+	v.saving = false
+	v.mode = ForwardsModeList
+}
+
+func (v *ForwardsView) SetSize(width, height int) {
+	v.width = width
+	v.height = height - 10
+	if v.height < 1 {
+		v.height = 1
+	}
+}
+
+func (v *ForwardsView) IsSaving() bool {
+	return v.saving
+}
+
+// IsEditing reports whether the add form or the delete prompt is open;
+// either owns every printable key, and esc closes it rather than the view.
+func (v *ForwardsView) IsEditing() bool {
+	return v.mode != ForwardsModeList
+}
+
+// TakeAddRequest returns the forward to add, once per submission of the
+// form.
+func (v *ForwardsView) TakeAddRequest() (api.URLForward, bool) {
+	if !v.addRequested {
+		return api.URLForward{}, false
+	}
+	v.addRequested = false
+	fwdType := api.ForwardTemporary
+	if v.permanent {
+		fwdType = api.ForwardPermanent
+	}
+	return api.URLForward{
+		Subdomain:   strings.ToLower(strings.TrimSpace(v.inputs[fwdFieldSubdomain].Value())),
+		Location:    strings.TrimSpace(v.inputs[fwdFieldLocation].Value()),
+		Type:        fwdType,
+		IncludePath: v.includePath,
+		Wildcard:    v.wildcard,
+	}, true
+}
+
+// TakeDeleteRequest returns the forward to delete, once per confirmation.
+func (v *ForwardsView) TakeDeleteRequest() (api.URLForward, bool) {
+	if !v.deleteRequested {
+		return api.URLForward{}, false
+	}
+	v.deleteRequested = false
+	return v.deleting, true
+}
+
+func (v *ForwardsView) Update(msg tea.Msg) (*ForwardsView, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return v, nil
+	}
+	switch v.mode {
+	case ForwardsModeAdd:
+		return v.updateAdd(keyMsg)
+	case ForwardsModeConfirmDelete:
+		return v.updateConfirm(keyMsg)
+	default:
+		return v.updateList(keyMsg)
+	}
+}
+
+func (v *ForwardsView) updateList(msg tea.KeyMsg) (*ForwardsView, tea.Cmd) {
+	if v.loading || v.saving {
+		return v, nil
+	}
+	switch {
+	case key.Matches(msg, keys.Keys.Up):
+		if v.cursor > 0 {
+			v.cursor--
+			if v.cursor < v.offset {
+				v.offset = v.cursor
+			}
+		}
+	case key.Matches(msg, keys.Keys.Down):
+		if v.cursor < len(v.forwards)-1 {
+			v.cursor++
+			if v.cursor >= v.offset+v.height {
+				v.offset = v.cursor - v.height + 1
+			}
+		}
+	case msg.String() == "a":
+		return v, v.openForm()
+	case msg.String() == "x":
+		if v.cursor < len(v.forwards) {
+			v.deleting = v.forwards[v.cursor]
+			v.mode = ForwardsModeConfirmDelete
+			v.err = nil
+			v.success = ""
+		}
+	}
+	return v, nil
+}
+
+// openForm starts a blank add form. New forwards default to permanent:
+// parked domains are the common case, and a 301 is what they want.
+func (v *ForwardsView) openForm() tea.Cmd {
+	v.mode = ForwardsModeAdd
+	v.err = nil
+	v.success = ""
+	for i := range v.inputs {
+		v.inputs[i].SetValue("")
+		v.inputs[i].Blur()
+	}
+	v.permanent, v.includePath, v.wildcard = true, false, false
+	v.field = fwdFieldSubdomain
+	return v.focusField()
+}
+
+func (v *ForwardsView) focusField() tea.Cmd {
+	for i := range v.inputs {
+		v.inputs[i].Blur()
+	}
+	if v.field < len(v.inputs) {
+		v.inputs[v.field].Focus()
+		return textinput.Blink
+	}
+	return nil
+}
+
+func (v *ForwardsView) updateAdd(msg tea.KeyMsg) (*ForwardsView, tea.Cmd) {
+	if v.saving {
+		return v, nil // the add is in flight; wait for its result
+	}
+	switch {
+	case key.Matches(msg, keys.Keys.Back):
+		v.mode = ForwardsModeList
+		v.err = nil
+		return v, nil
+	case msg.String() == "tab", msg.String() == "down":
+		v.field = (v.field + 1) % fwdFieldCount
+		return v, v.focusField()
+	case msg.String() == "shift+tab", msg.String() == "up":
+		v.field = (v.field - 1 + fwdFieldCount) % fwdFieldCount
+		return v, v.focusField()
+	case msg.String() == "enter", msg.String() == "ctrl+s":
+		v.saving = true
+		v.addRequested = true
+		v.err = nil
+		return v, nil
+	}
+
+	if v.field < len(v.inputs) {
+		var cmd tea.Cmd
+		v.inputs[v.field], cmd = v.inputs[v.field].Update(msg)
+		return v, cmd
+	}
+	if msg.String() == " " {
+		switch v.field {
+		case fwdFieldType:
+			v.permanent = !v.permanent
+		case fwdFieldIncludePath:
+			v.includePath = !v.includePath
+		case fwdFieldWildcard:
+			v.wildcard = !v.wildcard
+		}
+	}
+	return v, nil
+}
+
+func (v *ForwardsView) updateConfirm(msg tea.KeyMsg) (*ForwardsView, tea.Cmd) {
+	if v.saving {
+		return v, nil
+	}
+	switch msg.String() {
+	case "y":
+		v.saving = true
+		v.deleteRequested = true
+	case "n", "esc":
+		v.mode = ForwardsModeList
+	}
+	return v, nil
+}
+
+func (v *ForwardsView) View() string {
+	var b strings.Builder
+
+	title := styles.TitleStyle.Render(fmt.Sprintf(" URL Forwards: %s ", v.domain))
+	b.WriteString(title)
+	b.WriteString("\n\n")
+
+	if v.loading {
+		b.WriteString("  Loading URL forwards...")
+		return b.String()
+	}
+
+	if v.err != nil {
+		b.WriteString(styles.ErrorStyle.Render(fmt.Sprintf("  Error: %v", v.err)))
+		if isAPIAccessError(v.err) {
+			b.WriteString("\n")
+			b.WriteString(styles.HelpStyle.Render("  This domain needs API access enabled.\n"))
+			b.WriteString(styles.HelpStyle.Render("  Go to porkbun.com → Domain Management → " + v.domain + " → API Access → ON"))
+		}
+		b.WriteString("\n\n")
+	}
+	if v.success != "" {
+		b.WriteString(styles.SuccessStyle.Render(fmt.Sprintf("  %s\n\n", v.success)))
+	}
+
+	if v.mode == ForwardsModeAdd {
+		b.WriteString(v.formView())
+		return b.String()
+	}
+
+	b.WriteString(v.listView())
+
+	if v.mode == ForwardsModeConfirmDelete {
+		prompt := fmt.Sprintf("  Delete the forward from %s to %s?", v.source(v.deleting), v.deleting.Location)
+		b.WriteString("\n")
+		b.WriteString(styles.PremiumStyle.Render(prompt))
+		b.WriteString("\n")
+		if v.saving {
+			b.WriteString(styles.SpinnerStyle.Render("  Deleting..."))
+		} else {
+			b.WriteString(styles.HelpStyle.Render("  y confirm · n cancel"))
+		}
+	}
+
+	return b.String()
+}
+
+func (v *ForwardsView) listView() string {
+	var b strings.Builder
+
+	if len(v.forwards) == 0 {
+		b.WriteString("  No URL forwards.\n")
+		return b.String()
+	}
+
+	sourceWidth := 30
+	typeWidth := 14
+	flagWidth := 5
+
+	header := fmt.Sprintf("  %-*s  %-*s  %-*s  %-*s  %s",
+		sourceWidth, "From",
+		typeWidth, "Type",
+		flagWidth, "Path",
+		flagWidth, "Wild",
+		"To",
+	)
+	b.WriteString(styles.TableHeaderStyle.Render(header))
+	b.WriteString("\n")
+
+	visibleEnd := min(v.offset+v.height, len(v.forwards))
+	for i := v.offset; i < visibleEnd; i++ {
+		f := v.forwards[i]
+		row := fmt.Sprintf("  %-*s  %-*s  %-*s  %-*s  %s",
+			sourceWidth, truncate(v.source(f), sourceWidth),
+			typeWidth, forwardTypeLabel(f.Type),
+			flagWidth, boolToYesNo(f.IncludePath),
+			flagWidth, boolToYesNo(f.Wildcard),
+			f.Location,
+		)
+		if i == v.cursor {
+			row = styles.TableSelectedStyle.Render(row)
+		}
+		b.WriteString(row)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func (v *ForwardsView) formView() string {
+	var b strings.Builder
+
+	b.WriteString("  Add a URL forward:\n\n")
+	labels := [2]string{"Subdomain:", "Location:"}
+	for i, input := range v.inputs {
+		b.WriteString(v.fieldCursor(i))
+		b.WriteString(styles.LabelStyle.Render(labels[i]))
+		b.WriteString(input.View())
+		b.WriteString("\n")
+	}
+
+	fwdType := forwardTypeLabel(api.ForwardTemporary)
+	if v.permanent {
+		fwdType = forwardTypeLabel(api.ForwardPermanent)
+	}
+	toggles := []struct {
+		field int
+		label string
+		value string
+	}{
+		{fwdFieldType, "Type:", fwdType},
+		{fwdFieldIncludePath, "Include path:", boolToYesNo(v.includePath)},
+		{fwdFieldWildcard, "Wildcard:", boolToYesNo(v.wildcard)},
+	}
+	for _, t := range toggles {
+		b.WriteString(v.fieldCursor(t.field))
+		b.WriteString(styles.LabelStyle.Render(t.label))
+		b.WriteString(styles.ValueStyle.Render(t.value))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	if v.saving {
+		b.WriteString(styles.SpinnerStyle.Render("  Adding..."))
+	} else {
+		b.WriteString(styles.HelpStyle.Render("  tab to move, space to toggle, enter to add, esc to cancel"))
+	}
+	return b.String()
+}
+
+func (v *ForwardsView) fieldCursor(field int) string {
+	if field == v.field {
+		return "> "
+	}
+	return "  "
+}
+
+// source is the name a forward answers on, with the wildcard shown.
+func (v *ForwardsView) source(f api.URLForward) string {
+	name := v.domain
+	if f.Subdomain != "" {
+		name = f.Subdomain + "." + v.domain
+	}
+	if f.Wildcard {
+		name += " (+*)"
+	}
+	return name
+}
+
+func forwardTypeLabel(t string) string {
+	switch t {
+	case api.ForwardPermanent:
+		return "permanent 301"
+	case api.ForwardTemporary:
+		return "temporary 302"
+	}
+	return t
+}
+
+func (v *ForwardsView) HelpText() string {
+	switch v.mode {
+	case ForwardsModeAdd:
+		return lipgloss.JoinHorizontal(lipgloss.Top,
+			styles.HelpStyle.Render("tab"),
+			" next field  ",
+			styles.HelpStyle.Render("space"),
+			" toggle  ",
+			styles.HelpStyle.Render("enter"),
+			" add  ",
+			styles.HelpStyle.Render("esc"),
+			" cancel",
+		)
+	case ForwardsModeConfirmDelete:
+		return lipgloss.JoinHorizontal(lipgloss.Top,
+			styles.HelpStyle.Render("y"),
+			" delete  ",
+			styles.HelpStyle.Render("n/esc"),
+			" cancel",
+		)
+	default:
+		return lipgloss.JoinHorizontal(lipgloss.Top,
+			styles.HelpStyle.Render("j/k"),
+			" navigate  ",
+			styles.HelpStyle.Render("a"),
+			" add  ",
+			styles.HelpStyle.Render("x"),
+			" delete  ",
+			styles.HelpStyle.Render("esc"),
+			" back  ",
+			styles.HelpStyle.Render("q"),
+			" quit",
+		)
+	}
+}
+
+func (v *ForwardsView) StatusText() string {
+	return fmt.Sprintf("%d forwards", len(v.forwards))
+}
@@ -0,0 +1,108 @@
+package views
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/bc/porkbun-tui/internal/api"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func typeKeys(v *ForwardsView, s string) *ForwardsView {
+	for _, r := range s {
+		v, _ = v.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	return v
+}
+
+func loadedForwardsView() *ForwardsView {
+	v := NewForwardsView()
+	v.SetSize(120, 40)
+	v.SetDomain("parked.com")
+	v.SetForwards([]api.URLForward{
+		{ID: "1", Location: "https://example.com", Type: api.ForwardPermanent, Wildcard: true},
+		{ID: "2", Subdomain: "old", Location: "https://example.com/old", Type: api.ForwardTemporary, IncludePath: true},
+	})
+	return v
+}
+
+func TestForwardsView_ListShowsTypeAndFlags(t *testing.T) {
+	out := loadedForwardsView().View()
+	for _, want := range []string{"parked.com (+*)", "permanent 301", "old.parked.com", "temporary 302", "https://example.com/old"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("view missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestForwardsView_AddFormRaisesOneRequest(t *testing.T) {
+	v := loadedForwardsView()
+	v = typeKeys(v, "a")
+	if !v.IsEditing() {
+		t.Fatal("a did not open the add form")
+	}
+
+	v = typeKeys(v, "www")
+	v, _ = v.Update(tea.KeyMsg{Type: tea.KeyTab})
+	v = typeKeys(v, "https://quit.example")
+	v, _ = v.Update(tea.KeyMsg{Type: tea.KeyTab})
+	v, _ = v.Update(tea.KeyMsg{Type: tea.KeyTab})
+	v = typeKeys(v, " ") // include path on
+	v, _ = v.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	f, ok := v.TakeAddRequest()
+	if !ok {
+		t.Fatal("enter raised no add request")
+	}
+	want := api.URLForward{Subdomain: "www", Location: "https://quit.example", Type: api.ForwardPermanent, IncludePath: true}
+	if f != want {
+		t.Errorf("request = %+v, want %+v", f, want)
+	}
+	if _, again := v.TakeAddRequest(); again {
+		t.Error("add request raised twice")
+	}
+	if !v.IsSaving() {
+		t.Error("not saving while the add is in flight")
+	}
+}
+
+func TestForwardsView_FailedAddKeepsForm(t *testing.T) {
+	v := loadedForwardsView()
+	v = typeKeys(v, "a")
+	v, _ = v.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	v.TakeAddRequest()
+
+	v.SetError(errors.New("forward location must be a URL"))
+	if !v.IsEditing() || v.IsSaving() {
+		t.Error("a failed add closed the form or stayed saving")
+	}
+
+	v.SetSuccess("added")
+	if v.IsEditing() {
+		t.Error("form still open after success")
+	}
+}
+
+func TestForwardsView_DeleteNeedsConfirmation(t *testing.T) {
+	v := loadedForwardsView()
+	v, _ = v.Update(tea.KeyMsg{Type: tea.KeyDown})
+	v = typeKeys(v, "x")
+	if _, ok := v.TakeDeleteRequest(); ok {
+		t.Fatal("x deleted without confirmation")
+	}
+	if !strings.Contains(v.View(), "Delete the forward from old.parked.com") {
+		t.Errorf("no prompt for the selected forward:\n%s", v.View())
+	}
+
+	v = typeKeys(v, "n")
+	if v.IsEditing() {
+		t.Error("n left the prompt open")
+	}
+
+	v = typeKeys(v, "xy")
+	f, ok := v.TakeDeleteRequest()
+	if !ok || f.ID != "2" {
+		t.Errorf("delete request = %+v, %v; want forward 2", f, ok)
+	}
+}
@@ -0,0 +1,395 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bc/porkbun-tui/internal/api"
+	"github.com/bc/porkbun-tui/internal/keys"
+	"github.com/bc/porkbun-tui/internal/styles"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type GlueViewMode int
+
+const (
+	GlueModeList GlueViewMode = iota
+	GlueModeAdd
+	GlueModeEdit
+	GlueModeConfirmDelete
+)
+
+// GlueRequest is a glue change for the app to validate and send. Host is
+// as typed; IPs is the raw address list.
+type GlueRequest struct {
+	Host   string
+	IPs    string
+	Update bool // replace the addresses of an existing host
+	Delete bool
+}
+
+// GlueView lists the glue hosts under a domain and edits them. It warns
+// about nameservers under the domain that have no glue, which leaves the
+// domain unresolvable once the registry delegates to them.
+type GlueView struct {
+	domain      string
+	nameservers []string
+	hosts       []api.GlueHost
+	cursor      int
+	mode        GlueViewMode
+	width       int
+	height      int
+	loading     bool
+	saving      bool
+	err         error
+	success     string
+
+	hostInput textinput.Model
+	ipsInput  textinput.Model
+	field     int // 0 host, 1 addresses
+
+	// request is the one-shot edge for the app; saving stays true while
+	// it is in flight.
+	request *GlueRequest
+}
+
+func NewGlueView() *GlueView {
+	host := textinput.New()
+	host.Placeholder = "ns1"
+	host.CharLimit = 253
+	host.Width = 30
+	ips := textinput.New()
+	ips.Placeholder = "192.0.2.1, 2001:db8::1"
+	ips.CharLimit = 500
+	ips.Width = 50
+	return &GlueView{hostInput: host, ipsInput: ips}
+}
+
+// SetDomain opens the view for domain; nameservers are its current
+// delegation, checked for missing glue.
+func (v *GlueView) SetDomain(domain string, nameservers []string) {
+	v.domain = domain
+	v.nameservers = nameservers
+	v.hosts = nil
+	v.cursor = 0
+	v.mode = GlueModeList
+	v.loading = true
+	v.saving = false
+	v.err = nil
+	v.success = ""
+}
+
+func (v *GlueView) SetHosts(hosts []api.GlueHost) {
+	v.hosts = hosts
+	v.loading = false
+	if v.cursor >= len(hosts) {
+		v.cursor = max(len(hosts)-1, 0)
+	}
+}
+
+// SetError reports a failed load or change. A failed add or edit keeps
+// the form open so the input can be corrected.
+func (v *GlueView) SetError(err error) {
+	v.err = err
+	v.loading = false
+	v.saving = false
+	if v.mode == GlueModeConfirmDelete {
+		v.mode = GlueModeList
+	}
+}
+
+// SetSuccess reports a completed change and returns to the list.
+func (v *GlueView) SetSuccess(msg string) {
+	v.success = msg
+	v.saving = false
+	v.mode = GlueModeList
+}
+
+func (v *GlueView) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+}
+
+func (v *GlueView) IsSaving() bool {
+	return v.saving
+}
+
+// IsEditing reports whether a form or the delete prompt is open; it owns
+// every printable key, and esc closes it rather than the view.
+func (v *GlueView) IsEditing() bool {
+	return v.mode != GlueModeList
+}
+
+// TakeRequest returns the pending change, once per submission.
+func (v *GlueView) TakeRequest() (GlueRequest, bool) {
+	if v.request == nil {
+		return GlueRequest{}, false
+	}
+	r := *v.request
+	v.request = nil
+	return r, true
+}
+
+func (v *GlueView) Update(msg tea.Msg) (*GlueView, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return v, nil
+	}
+	switch v.mode {
+	case GlueModeAdd, GlueModeEdit:
+		return v.updateForm(keyMsg)
+	case GlueModeConfirmDelete:
+		return v.updateConfirm(keyMsg)
+	default:
+		return v.updateList(keyMsg)
+	}
+}
+
+func (v *GlueView) updateList(msg tea.KeyMsg) (*GlueView, tea.Cmd) {
+	if v.loading || v.saving {
+		return v, nil
+	}
+	switch {
+	case key.Matches(msg, keys.Keys.Up):
+		if v.cursor > 0 {
+			v.cursor--
+		}
+	case key.Matches(msg, keys.Keys.Down):
+		if v.cursor < len(v.hosts)-1 {
+			v.cursor++
+		}
+	case msg.String() == "a":
+		// Offer the first nameserver that lacks glue, the usual reason
+		// for being here.
+		host := ""
+		if missing := api.MissingGlue(v.domain, v.nameservers, v.hosts); len(missing) > 0 {
+			host = missing[0]
+		}
+		return v, v.openForm(GlueModeAdd, host, "")
+	case msg.String() == "e":
+		if v.cursor < len(v.hosts) {
+			h := v.hosts[v.cursor]
+			return v, v.openForm(GlueModeEdit, h.Host, strings.Join(h.IPs(), ", "))
+		}
+	case msg.String() == "x":
+		if v.cursor < len(v.hosts) {
+			v.mode = GlueModeConfirmDelete
+			v.err = nil
+			v.success = ""
+		}
+	}
+	return v, nil
+}
+
+// openForm starts the add or edit form. Editing keeps the host fixed and
+// focuses the addresses.
+func (v *GlueView) openForm(mode GlueViewMode, host, ips string) tea.Cmd {
+	v.mode = mode
+	v.err = nil
+	v.success = ""
+	v.hostInput.SetValue(host)
+	v.ipsInput.SetValue(ips)
+	v.ipsInput.CursorEnd()
+	v.field = 0
+	if mode == GlueModeEdit || host != "" {
+		v.field = 1
+	}
+	return v.focusField()
+}
+
+func (v *GlueView) focusField() tea.Cmd {
+	v.hostInput.Blur()
+	v.ipsInput.Blur()
+	if v.field == 0 {
+		v.hostInput.Focus()
+	} else {
+		v.ipsInput.Focus()
+	}
+	return textinput.Blink
+}
+
+func (v *GlueView) updateForm(msg tea.KeyMsg) (*GlueView, tea.Cmd) {
+	if v.saving {
+		return v, nil
+	}
+	switch {
+	case key.Matches(msg, keys.Keys.Back):
+		v.mode = GlueModeList
+		v.err = nil
+		return v, nil
+	case msg.String() == "tab", msg.String() == "shift+tab", msg.String() == "up", msg.String() == "down":
+		if v.mode == GlueModeAdd {
+			v.field = 1 - v.field
+		}
+		return v, v.focusField()
+	case msg.String() == "enter", msg.String() == "ctrl+s":
+		v.saving = true
+		v.err = nil
+		v.request = &GlueRequest{
+			Host:   v.hostInput.Value(),
+			IPs:    v.ipsInput.Value(),
+			Update: v.mode == GlueModeEdit,
+		}
+		return v, nil
+	}
+
+	var cmd tea.Cmd
+	if v.field == 0 {
+		v.hostInput, cmd = v.hostInput.Update(msg)
+	} else {
+		v.ipsInput, cmd = v.ipsInput.Update(msg)
+	}
+	return v, cmd
+}
+
+func (v *GlueView) updateConfirm(msg tea.KeyMsg) (*GlueView, tea.Cmd) {
+	if v.saving {
+		return v, nil
+	}
+	switch msg.String() {
+	case "y":
+		v.saving = true
+		v.request = &GlueRequest{Host: v.hosts[v.cursor].Host, Delete: true}
+	case "n", "esc":
+		v.mode = GlueModeList
+	}
+	return v, nil
+}
+
+func (v *GlueView) View() string {
+	var b strings.Builder
+
+	title := styles.TitleStyle.Render(fmt.Sprintf(" Glue Records: %s ", v.domain))
+	b.WriteString(title)
+	b.WriteString("\n\n")
+
+	if v.loading {
+		b.WriteString("  Loading glue records...")
+		return b.String()
+	}
+
+	if v.err != nil {
+		b.WriteString(styles.ErrorStyle.Render(fmt.Sprintf("  Error: %v", v.err)))
+		b.WriteString("\n\n")
+	}
+	if v.success != "" {
+		b.WriteString(styles.SuccessStyle.Render(fmt.Sprintf("  %s\n\n", v.success)))
+	}
+
+	if missing := api.MissingGlue(v.domain, v.nameservers, v.hosts); len(missing) > 0 {
+		for _, ns := range missing {
+			b.WriteString(styles.PremiumStyle.Render(fmt.Sprintf("  ⚠ %s is a nameserver under %s but has no glue record", ns, v.domain)))
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+	}
+
+	switch v.mode {
+	case GlueModeAdd, GlueModeEdit:
+		b.WriteString(v.formView())
+		return b.String()
+	}
+
+	if len(v.hosts) == 0 {
+		b.WriteString("  No glue records.\n")
+	} else {
+		hostWidth := 30
+		header := fmt.Sprintf("  %-*s  %s", hostWidth, "Host", "Addresses")
+		b.WriteString(styles.TableHeaderStyle.Render(header))
+		b.WriteString("\n")
+		for i, h := range v.hosts {
+			row := fmt.Sprintf("  %-*s  %s", hostWidth, truncate(h.Host, hostWidth), strings.Join(h.IPs(), ", "))
+			if i == v.cursor {
+				row = styles.TableSelectedStyle.Render(row)
+			}
+			b.WriteString(row)
+			b.WriteString("\n")
+		}
+	}
+
+	if v.mode == GlueModeConfirmDelete {
+		b.WriteString("\n")
+		b.WriteString(styles.PremiumStyle.Render(fmt.Sprintf("  Delete the glue record for %s?", v.hosts[v.cursor].Host)))
+		b.WriteString("\n")
+		if v.saving {
+			b.WriteString(styles.SpinnerStyle.Render("  Deleting..."))
+		} else {
+			b.WriteString(styles.HelpStyle.Render("  y confirm · n cancel"))
+		}
+	}
+
+	return b.String()
+}
+
+func (v *GlueView) formView() string {
+	var b strings.Builder
+
+	if v.mode == GlueModeEdit {
+		b.WriteString(fmt.Sprintf("  Edit addresses of %s:\n\n", v.hostInput.Value()))
+	} else {
+		b.WriteString("  Add a glue record:\n\n")
+		b.WriteString(v.fieldCursor(0))
+		b.WriteString(styles.LabelStyle.Render("Host:"))
+		b.WriteString(v.hostInput.View())
+		b.WriteString("\n")
+	}
+	b.WriteString(v.fieldCursor(1))
+	b.WriteString(styles.LabelStyle.Render("Addresses:"))
+	b.WriteString(v.ipsInput.View())
+	b.WriteString("\n\n")
+
+	if v.saving {
+		b.WriteString(styles.SpinnerStyle.Render("  Saving..."))
+	} else {
+		b.WriteString(styles.HelpStyle.Render("  IPv4 and IPv6, comma-separated · enter to save, esc to cancel"))
+	}
+	return b.String()
+}
+
+func (v *GlueView) fieldCursor(field int) string {
+	if field == v.field {
+		return "> "
+	}
+	return "  "
+}
+
+func (v *GlueView) HelpText() string {
+	switch v.mode {
+	case GlueModeAdd, GlueModeEdit:
+		return lipgloss.JoinHorizontal(lipgloss.Top,
+			styles.HelpStyle.Render("tab"),
+			" next field  ",
+			styles.HelpStyle.Render("enter"),
+			" save  ",
+			styles.HelpStyle.Render("esc"),
+			" cancel",
+		)
+	case GlueModeConfirmDelete:
+		return lipgloss.JoinHorizontal(lipgloss.Top,
+			styles.HelpStyle.Render("y"),
+			" delete  ",
+			styles.HelpStyle.Render("n/esc"),
+			" cancel",
+		)
+	default:
+		return lipgloss.JoinHorizontal(lipgloss.Top,
+			styles.HelpStyle.Render("j/k"),
+			" navigate  ",
+			styles.HelpStyle.Render("a"),
+			" add  ",
+			styles.HelpStyle.Render("e"),
+			" edit  ",
+			styles.HelpStyle.Render("x"),
+			" delete  ",
+			styles.HelpStyle.Render("esc"),
+			" back",
+		)
+	}
+}
+
+func (v *GlueView) StatusText() string {
+	return fmt.Sprintf("%d glue records", len(v.hosts))
+}
@@ -0,0 +1,77 @@
+package views
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bc/porkbun-tui/internal/api"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func loadedGlueView() *GlueView {
+	v := NewGlueView()
+	v.SetSize(120, 40)
+	v.SetDomain("example.com", []string{"ns1.example.com", "ns2.example.com", "ns.other.net"})
+	v.SetHosts([]api.GlueHost{{Host: "ns1.example.com", IPv4: []string{"192.0.2.1"}, IPv6: []string{"2001:db8::1"}}})
+	return v
+}
+
+func TestGlueView_WarnsOnlyAboutInDomainNameserversWithoutGlue(t *testing.T) {
+	out := loadedGlueView().View()
+	if !strings.Contains(out, "ns2.example.com is a nameserver under example.com but has no glue") {
+		t.Errorf("missing glue not flagged:\n%s", out)
+	}
+	for _, quiet := range []string{"ns1.example.com is a nameserver", "ns.other.net is a nameserver"} {
+		if strings.Contains(out, quiet) {
+			t.Errorf("unexpected warning %q", quiet)
+		}
+	}
+	if !strings.Contains(out, "192.0.2.1, 2001:db8::1") {
+		t.Errorf("addresses not listed:\n%s", out)
+	}
+}
+
+func TestGlueView_AddOffersMissingHost(t *testing.T) {
+	v := loadedGlueView()
+	v = typeGlueKeys(v, "a192.0.2.2")
+	v, _ = v.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	r, ok := v.TakeRequest()
+	if !ok {
+		t.Fatal("enter raised no request")
+	}
+	if r.Host != "ns2.example.com" || r.IPs != "192.0.2.2" || r.Update || r.Delete {
+		t.Errorf("request = %+v", r)
+	}
+	if _, again := v.TakeRequest(); again {
+		t.Error("request raised twice")
+	}
+}
+
+func TestGlueView_EditAndDelete(t *testing.T) {
+	v := loadedGlueView()
+	v = typeGlueKeys(v, "e")
+	v, _ = v.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	v, _ = v.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	r, _ := v.TakeRequest()
+	if !r.Update || r.Host != "ns1.example.com" || r.IPs != "192.0.2.1, 2001:db8::" {
+		t.Errorf("edit request = %+v", r)
+	}
+	v.SetSuccess("updated")
+
+	v = typeGlueKeys(v, "x")
+	if _, ok := v.TakeRequest(); ok {
+		t.Fatal("x deleted without confirmation")
+	}
+	v = typeGlueKeys(v, "y")
+	if r, ok := v.TakeRequest(); !ok || !r.Delete || r.Host != "ns1.example.com" {
+		t.Errorf("delete request = %+v, %v", r, ok)
+	}
+}
+
+func typeGlueKeys(v *GlueView, s string) *GlueView {
+	for _, r := range s {
+		v, _ = v.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	return v
+}
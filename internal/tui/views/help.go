@@ -56,6 +56,11 @@ func (v *HelpView) View() string {
 				{"/", "Search/filter domains"},
 				{"1", "Sort by name"},
 				{"2", "Sort by expiration date"},
+				{"D", "Filter by DNSSEC (on, off, all)"},
+				{"A", "Set auto-renew for the shown domains"},
+				{"Space", "Mark / unmark domain"},
+				{"* / i", "Mark all shown / invert marks on shown"},
+				{"b", "Bulk actions on marked domains"},
 				{"r", "Refresh domain list"},
 			},
 		},
@@ -67,9 +72,18 @@ func (v *HelpView) View() string {
 			}{
 				{"d", "View DNS records"},
 				{"n", "View/edit nameservers"},
+				{"s (in details)", "SSL certificate from Porkbun"},
+				{"f (in details)", "URL forwards (a add, x delete)"},
+				{"D (in details)", "DNSSEC DS records (a add, x delete)"},
+				{"A (in details)", "Toggle auto-renew"},
 				{"a", "Domain availability checker"},
 				{"t", "TLD breakdown (costs by TLD)"},
+				{"p (in TLD view)", "Pricing explorer for all TLDs"},
+				{"f (in TLD view)", "Multi-year renewal forecast"},
+				{"P", "DNS providers (r refetches nameservers)"},
 				{"c", "Calendar view (by expiration)"},
+				{"g (in calendar)", "Toggle month grid (h/l day, j/k week, [/] month)"},
+				{"x (in calendar)", "Export expirations as .ics"},
 			},
 		},
 		{
@@ -79,7 +93,11 @@ func (v *HelpView) View() string {
 				desc string
 			}{
 				{"e", "Edit nameservers"},
-				{"p", "Apply preset (Cloudflare, etc.)"},
+				{"p", "Apply a preset, or copy from another domain"},
+				{"u", "Undo the last change (y to confirm)"},
+				{"g", "Glue records (a add, e edit, x delete)"},
+				{"Ctrl+N/X", "Add / remove a row (2-13)"},
+				{"Shift+↑/↓", "Move a row up / down"},
 				{"Ctrl+S", "Save changes"},
 			},
 		},
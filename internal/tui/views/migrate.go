@@ -0,0 +1,536 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bc/porkbun-tui/internal/keys"
+	"github.com/bc/porkbun-tui/internal/nsmigrate"
+	"github.com/bc/porkbun-tui/internal/nspreset"
+	"github.com/bc/porkbun-tui/internal/styles"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type MigrateViewMode int
+
+const (
+	MigrateModeTarget MigrateViewMode = iota
+	MigrateModeCustom
+	MigrateModeLoading
+	MigrateModePreview
+	MigrateModeApply
+)
+
+type migrateState int
+
+const (
+	migrateSkip      migrateState = iota // already on the target
+	migrateLoadError                     // current nameservers unknown
+	migratePending
+	migrateApplying
+	migrateDone
+	migrateFailed
+	migrateStopped
+	migrateChanged // changed since the preview, left alone
+)
+
+type migrateRow struct {
+	domain  string
+	current []string
+	state   migrateState
+	err     error
+}
+
+// MigrateView moves a set of domains to one set of nameservers: pick the
+// target, preview each domain's before and after, then apply. The app
+// fetches and writes, reporting back with SetCurrent and SetResult.
+type MigrateView struct {
+	domains []string
+	presets []nspreset.Preset
+	target  []string
+	rows    []migrateRow
+	mode    MigrateViewMode
+	cursor  int
+	offset  int
+	width   int
+	height  int
+	err     error
+
+	input textinput.Model
+
+	running  bool
+	stopped  bool
+	rollback string // path of the rollback file, once written
+
+	// targetRequested and applyRequested are one-shot edges for the app.
+	targetRequested bool
+	applyRequested  bool
+}
+
+func NewMigrateView() *MigrateView {
+	ti := textinput.New()
+	ti.Placeholder = "ada.ns.cloudflare.com, bob.ns.cloudflare.com"
+	ti.CharLimit = 500
+	ti.Width = 60
+	return &MigrateView{input: ti, presets: nspreset.Builtin}
+}
+
+// SetPresets sets the presets on offer as targets.
+func (v *MigrateView) SetPresets(presets []nspreset.Preset) {
+	v.presets = presets
+}
+
+// Open starts over at the target picker for domains.
+func (v *MigrateView) Open(domains []string) {
+	v.domains = domains
+	v.target = nil
+	v.rows = nil
+	v.mode = MigrateModeTarget
+	v.cursor = 0
+	v.offset = 0
+	v.err = nil
+	v.running = false
+	v.stopped = false
+	v.rollback = ""
+	v.targetRequested = false
+	v.applyRequested = false
+}
+
+func (v *MigrateView) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+}
+
+// TakeTargetRequest returns the chosen nameservers as given, once. The
+// app validates them and calls SetTarget or SetError.
+func (v *MigrateView) TakeTargetRequest() ([]string, bool) {
+	if !v.targetRequested {
+		return nil, false
+	}
+	v.targetRequested = false
+	if v.mode == MigrateModeCustom {
+		return strings.FieldsFunc(v.input.Value(), func(r rune) bool {
+			return r == ',' || r == ' ' || r == '\t'
+		}), true
+	}
+	return v.presets[v.cursor].NS, true
+}
+
+// SetTarget accepts the validated target and waits for the domains'
+// current nameservers.
+func (v *MigrateView) SetTarget(ns []string) {
+	v.target = ns
+	v.err = nil
+	v.mode = MigrateModeLoading
+	v.input.Blur()
+}
+
+// Domains are the domains being migrated.
+func (v *MigrateView) Domains() []string {
+	return v.domains
+}
+
+// Target is the validated target nameservers.
+func (v *MigrateView) Target() []string {
+	return v.target
+}
+
+// SetCurrent fills the preview with each domain's current nameservers;
+// errs has the domains whose lookup failed.
+func (v *MigrateView) SetCurrent(current map[string][]string, errs map[string]error) {
+	v.rows = make([]migrateRow, len(v.domains))
+	for i, d := range v.domains {
+		row := migrateRow{domain: d, current: current[d], state: migratePending}
+		switch {
+		case errs[d] != nil:
+			row.state, row.err = migrateLoadError, errs[d]
+		case nsmigrate.Same(row.current, v.target):
+			row.state = migrateSkip
+		}
+		v.rows[i] = row
+	}
+	v.mode = MigrateModePreview
+	v.offset = 0
+}
+
+func (v *MigrateView) SetError(err error) {
+	v.err = err
+	if v.mode == MigrateModeLoading {
+		v.mode = MigrateModeTarget
+	}
+}
+
+// TakeApplyRequest returns the domains to change, once per confirmation.
+func (v *MigrateView) TakeApplyRequest() ([]string, bool) {
+	if !v.applyRequested {
+		return nil, false
+	}
+	v.applyRequested = false
+	var domains []string
+	for _, r := range v.rows {
+		if r.state == migratePending {
+			domains = append(domains, r.domain)
+		}
+	}
+	return domains, true
+}
+
+// Current returns the nameservers domain had in the preview.
+func (v *MigrateView) Current(domain string) []string {
+	for _, r := range v.rows {
+		if r.domain == domain {
+			return r.current
+		}
+	}
+	return nil
+}
+
+// SetApplying marks domain as in flight.
+func (v *MigrateView) SetApplying(domain string) {
+	v.setState(domain, migrateApplying, nil)
+}
+
+// SetResult records how the change went for domain.
+func (v *MigrateView) SetResult(domain string, err error) {
+	if err != nil {
+		v.setState(domain, migrateFailed, err)
+		return
+	}
+	v.setState(domain, migrateDone, nil)
+}
+
+// SetChanged marks domain as left alone because its nameservers changed
+// to current since the preview. err is set when that couldn't be noted in
+// the rollback file.
+func (v *MigrateView) SetChanged(domain string, current []string, err error) {
+	for i := range v.rows {
+		if v.rows[i].domain == domain {
+			v.rows[i].current = current
+		}
+	}
+	v.setState(domain, migrateChanged, err)
+}
+
+func (v *MigrateView) setState(domain string, s migrateState, err error) {
+	for i := range v.rows {
+		if v.rows[i].domain == domain {
+			v.rows[i].state = s
+			v.rows[i].err = err
+			return
+		}
+	}
+}
+
+// Finish ends the run. rollback is the rollback file's path, or err why
+// it couldn't be written; both are empty when nothing changed.
+func (v *MigrateView) Finish(rollback string, err error) {
+	v.running = false
+	v.rollback = rollback
+	v.err = err
+	for i := range v.rows {
+		if v.rows[i].state == migratePending {
+			v.rows[i].state = migrateStopped
+		}
+	}
+}
+
+// IsEditing reports whether esc goes back a step inside the view (the
+// custom input, the preview) rather than leaving it.
+func (v *MigrateView) IsEditing() bool {
+	return v.mode == MigrateModeCustom || v.mode == MigrateModePreview
+}
+
+// IsBusy reports whether nameservers are being fetched or changed.
+func (v *MigrateView) IsBusy() bool {
+	return v.mode == MigrateModeLoading || v.running
+}
+
+// IsStopped reports whether the user asked to stop; the app starts no
+// further domains.
+func (v *MigrateView) IsStopped() bool {
+	return v.stopped
+}
+
+func (v *MigrateView) Update(msg tea.Msg) (*MigrateView, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return v, nil
+	}
+	switch v.mode {
+	case MigrateModeTarget:
+		return v, v.updateTarget(keyMsg)
+	case MigrateModeCustom:
+		return v.updateCustom(keyMsg)
+	case MigrateModePreview:
+		v.updatePreview(keyMsg)
+	case MigrateModeApply:
+		if key.Matches(keyMsg, keys.Keys.Back) && v.running {
+			v.stopped = true
+		}
+		v.scroll(keyMsg)
+	}
+	return v, nil
+}
+
+func (v *MigrateView) updateTarget(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, keys.Keys.Up):
+		if v.cursor > 0 {
+			v.cursor--
+		}
+	case key.Matches(msg, keys.Keys.Down):
+		if v.cursor < len(v.presets) {
+			v.cursor++
+		}
+	case key.Matches(msg, keys.Keys.Enter):
+		v.err = nil
+		if v.cursor == len(v.presets) {
+			v.mode = MigrateModeCustom
+			v.input.Focus()
+			return textinput.Blink
+		}
+		v.targetRequested = true
+	}
+	return nil
+}
+
+func (v *MigrateView) updateCustom(msg tea.KeyMsg) (*MigrateView, tea.Cmd) {
+	switch {
+	case key.Matches(msg, keys.Keys.Back):
+		v.mode = MigrateModeTarget
+		v.input.Blur()
+		v.err = nil
+		return v, nil
+	case key.Matches(msg, keys.Keys.Enter):
+		v.err = nil
+		v.targetRequested = true
+		return v, nil
+	}
+	var cmd tea.Cmd
+	v.input, cmd = v.input.Update(msg)
+	return v, cmd
+}
+
+func (v *MigrateView) updatePreview(msg tea.KeyMsg) {
+	switch {
+	case key.Matches(msg, keys.Keys.Back):
+		v.mode = MigrateModeTarget
+		v.rows = nil
+	case msg.String() == "y":
+		if v.count(migratePending) > 0 {
+			v.mode = MigrateModeApply
+			v.running = true
+			v.applyRequested = true
+		}
+	default:
+		v.scroll(msg)
+	}
+}
+
+func (v *MigrateView) scroll(msg tea.KeyMsg) {
+	switch {
+	case key.Matches(msg, keys.Keys.Up):
+		if v.offset > 0 {
+			v.offset--
+		}
+	case key.Matches(msg, keys.Keys.Down):
+		if v.offset < len(v.rows)-v.visibleRows() {
+			v.offset++
+		}
+	}
+}
+
+func (v *MigrateView) visibleRows() int {
+	return max(v.height-14, 5)
+}
+
+func (v *MigrateView) count(s migrateState) int {
+	n := 0
+	for _, r := range v.rows {
+		if r.state == s {
+			n++
+		}
+	}
+	return n
+}
+
+func (v *MigrateView) View() string {
+	var b strings.Builder
+
+	title := styles.TitleStyle.Render(fmt.Sprintf(" Migrate nameservers: %d domains ", len(v.domains)))
+	b.WriteString(title)
+	b.WriteString("\n\n")
+
+	if v.err != nil {
+		b.WriteString(styles.ErrorStyle.Render(fmt.Sprintf("  Error: %v", v.err)))
+		b.WriteString("\n\n")
+	}
+
+	switch v.mode {
+	case MigrateModeTarget:
+		b.WriteString("  Move to:\n\n")
+		for i, p := range v.presets {
+			b.WriteString(pickRow(p.Name, i == v.cursor))
+		}
+		b.WriteString(pickRow("Custom...", v.cursor == len(v.presets)))
+		if v.cursor < len(v.presets) {
+			b.WriteString("\n")
+			b.WriteString(styles.HelpStyle.Render("  " + strings.Join(v.presets[v.cursor].NS, ", ")))
+		}
+	case MigrateModeCustom:
+		b.WriteString("  Target nameservers, comma-separated:\n\n  ")
+		b.WriteString(v.input.View())
+	case MigrateModeLoading:
+		b.WriteString(fmt.Sprintf("  Fetching the current nameservers of %d domains...", len(v.domains)))
+	case MigrateModePreview, MigrateModeApply:
+		b.WriteString(v.tableView())
+	}
+
+	return b.String()
+}
+
+func (v *MigrateView) tableView() string {
+	var b strings.Builder
+
+	b.WriteString(fmt.Sprintf("  Target: %s\n\n", strings.Join(v.target, ", ")))
+
+	nameWidth := 28
+	nsWidth := max((v.width-nameWidth-24)/2, 20)
+	header := fmt.Sprintf("  %-*s  %-*s  %-*s  %s", nameWidth, "Domain", nsWidth, "Before", nsWidth, "After", "")
+	b.WriteString(styles.TableHeaderStyle.Render(header))
+	b.WriteString("\n")
+
+	end := min(v.offset+v.visibleRows(), len(v.rows))
+	for _, r := range v.rows[v.offset:end] {
+		before := strings.Join(r.current, ", ")
+		after := strings.Join(v.target, ", ")
+		var note string
+		switch r.state {
+		case migrateSkip:
+			after = "(unchanged)"
+			note = styles.HelpStyle.Render("already on target")
+		case migrateLoadError:
+			before, after = "?", "(skipped)"
+			note = styles.ErrorStyle.Render(r.err.Error())
+		case migrateApplying:
+			note = styles.SpinnerStyle.Render("changing...")
+		case migrateDone:
+			note = styles.SuccessStyle.Render("✓ changed")
+		case migrateFailed:
+			note = styles.ErrorStyle.Render("✗ " + r.err.Error())
+		case migrateStopped:
+			note = styles.HelpStyle.Render("not changed (stopped)")
+		case migrateChanged:
+			after = "(skipped)"
+			note = styles.PremiumStyle.Render("changed since the preview, not touched")
+			if r.err != nil {
+				note = styles.ErrorStyle.Render("changed since the preview: " + r.err.Error())
+			}
+		}
+		b.WriteString(fmt.Sprintf("  %-*s  %-*s  %-*s  %s\n",
+			nameWidth, truncate(r.domain, nameWidth),
+			nsWidth, truncate(before, nsWidth),
+			nsWidth, truncate(after, nsWidth),
+			note))
+	}
+	if end-v.offset < len(v.rows) {
+		b.WriteString(styles.HelpStyle.Render(fmt.Sprintf("  %d-%d of %d (j/k to scroll)", v.offset+1, end, len(v.rows))))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	if v.mode == MigrateModePreview {
+		summary := fmt.Sprintf("  %d to change, %d already on target", v.count(migratePending), v.count(migrateSkip))
+		if n := v.count(migrateLoadError); n > 0 {
+			summary += fmt.Sprintf(", %d couldn't be read", n)
+		}
+		b.WriteString(summary)
+		b.WriteString("\n")
+		if v.count(migratePending) > 0 {
+			b.WriteString(styles.PremiumStyle.Render("  y apply · esc back"))
+		} else {
+			b.WriteString(styles.HelpStyle.Render("  Nothing to change · esc back"))
+		}
+		return b.String()
+	}
+
+	done, failed := v.count(migrateDone), v.count(migrateFailed)
+	summary := fmt.Sprintf("  %d changed, %d failed", done, failed)
+	if n := v.count(migrateChanged); n > 0 {
+		summary += fmt.Sprintf(", %d skipped (changed since the preview)", n)
+	}
+	if v.running {
+		summary += fmt.Sprintf(", %d to go", v.count(migratePending)+v.count(migrateApplying))
+		if v.stopped {
+			summary += " · stopping"
+		}
+		b.WriteString(styles.SpinnerStyle.Render(summary))
+	} else {
+		b.WriteString(summary)
+	}
+	b.WriteString("\n")
+	if v.rollback != "" {
+		b.WriteString(styles.SuccessStyle.Render("  Rollback file: " + v.rollback))
+		b.WriteString("\n")
+		b.WriteString(styles.HelpStyle.Render("  porkbun-tui ns-rollback " + v.rollback + " restores the previous nameservers"))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func (v *MigrateView) HelpText() string {
+	switch {
+	case v.mode == MigrateModeCustom:
+		return lipgloss.JoinHorizontal(lipgloss.Top,
+			styles.HelpStyle.Render("enter"),
+			" preview  ",
+			styles.HelpStyle.Render("esc"),
+			" back",
+		)
+	case v.mode == MigrateModePreview:
+		return lipgloss.JoinHorizontal(lipgloss.Top,
+			styles.HelpStyle.Render("j/k"),
+			" scroll  ",
+			styles.HelpStyle.Render("y"),
+			" apply  ",
+			styles.HelpStyle.Render("esc"),
+			" back",
+		)
+	case v.running:
+		return lipgloss.JoinHorizontal(lipgloss.Top,
+			styles.HelpStyle.Render("j/k"),
+			" scroll  ",
+			styles.HelpStyle.Render("esc"),
+			" stop",
+		)
+	case v.mode == MigrateModeApply:
+		return lipgloss.JoinHorizontal(lipgloss.Top,
+			styles.HelpStyle.Render("j/k"),
+			" scroll  ",
+			styles.HelpStyle.Render("esc"),
+			" back to the list",
+		)
+	default:
+		return lipgloss.JoinHorizontal(lipgloss.Top,
+			styles.HelpStyle.Render("j/k"),
+			" navigate  ",
+			styles.HelpStyle.Render("enter"),
+			" preview  ",
+			styles.HelpStyle.Render("esc"),
+			" back",
+		)
+	}
+}
+
+func (v *MigrateView) StatusText() string {
+	switch v.mode {
+	case MigrateModePreview:
+		return fmt.Sprintf("%d to change", v.count(migratePending))
+	case MigrateModeApply:
+		return fmt.Sprintf("%d changed, %d failed", v.count(migrateDone), v.count(migrateFailed))
+	}
+	return fmt.Sprintf("%d marked domains", len(v.domains))
+}
@@ -0,0 +1,51 @@
+package views
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/bc/porkbun-tui/internal/nspreset"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestMigrateView_StopMarksUnstartedDomains(t *testing.T) {
+	v := NewMigrateView()
+	v.Open([]string{"a.com", "b.com", "c.com"})
+	v, _ = v.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	ns, ok := v.TakeTargetRequest()
+	if !ok || len(ns) != len(nspreset.Builtin[0].NS) {
+		t.Fatalf("TakeTargetRequest() = %v, %v", ns, ok)
+	}
+	v.SetTarget(ns)
+	v.SetCurrent(map[string][]string{
+		"a.com": {"ns1.example.net"},
+		"b.com": {"ns1.example.net"},
+		"c.com": {"ns1.example.net"},
+	}, nil)
+
+	v, _ = v.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	domains, ok := v.TakeApplyRequest()
+	if !ok || len(domains) != 3 {
+		t.Fatalf("TakeApplyRequest() = %v, %v", domains, ok)
+	}
+	v.SetApplying("a.com")
+	v.SetApplying("b.com")
+	v, _ = v.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if !v.IsStopped() {
+		t.Fatal("esc while applying did not stop")
+	}
+	v.SetResult("a.com", nil)
+	v.SetResult("b.com", errors.New("porkbun: Invalid domain."))
+	v.Finish("/tmp/rollback.json", nil)
+
+	out := v.View()
+	for _, want := range []string{"✓ changed", "✗ porkbun: Invalid domain.", "not changed (stopped)", "1 changed, 1 failed", "/tmp/rollback.json"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("view missing %q:\n%s", want, out)
+		}
+	}
+	if v.IsBusy() {
+		t.Error("still busy after Finish")
+	}
+}
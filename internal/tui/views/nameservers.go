@@ -3,8 +3,11 @@ package views
 import (
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/bc/porkbun-tui/internal/api"
 	"github.com/bc/porkbun-tui/internal/keys"
+	"github.com/bc/porkbun-tui/internal/nspreset"
 	"github.com/bc/porkbun-tui/internal/styles"
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/textinput"
@@ -18,27 +21,20 @@ const (
 	NSViewModeView NSViewMode = iota
 	NSViewModeEdit
 	NSViewModePreset
+	NSViewModeConfirmUndo
+	NSViewModeConflict
+	NSViewModeCopy
 )
 
-type NSPreset struct {
-	Name string
-	NS   []string
-}
+// copyVisible is how many domains the copy picker lists at once.
+const copyVisible = 10
 
-var NSPresets = []NSPreset{
-	{
-		Name: "Porkbun Default",
-		NS:   []string{"maceio.ns.porkbun.com", "curitiba.ns.porkbun.com", "salvador.ns.porkbun.com", "fortaleza.ns.porkbun.com"},
-	},
-	{
-		Name: "Cloudflare",
-		NS:   []string{"ns1.cloudflare.com", "ns2.cloudflare.com"},
-	},
-	{
-		Name: "Google Cloud DNS",
-		NS:   []string{"ns-cloud-a1.googledomains.com", "ns-cloud-a2.googledomains.com", "ns-cloud-a3.googledomains.com", "ns-cloud-a4.googledomains.com"},
-	},
-}
+// The editor keeps between nsMinRows and nsMaxRows nameserver rows, the
+// range api.NormalizeNameservers accepts.
+const (
+	nsMinRows = api.MinNameservers
+	nsMaxRows = api.MaxNameservers
+)
 
 type NameserversView struct {
 	domain      string
@@ -46,12 +42,37 @@ type NameserversView struct {
 	inputs      []textinput.Model
 	cursor      int
 	mode        NSViewMode
-	presetIdx   int
+	presets     []nspreset.Preset
+	presetIdx   int // len(presets) is "Copy from another domain"
 	loading     bool
 	saving      bool
 	// saveRequested is the one-shot edge for the app to fire the actual
 	// save; saving stays true for the whole in-flight window.
 	saveRequested bool
+	// glueRequested is the one-shot edge for the app to open the glue
+	// records of this domain.
+	glueRequested bool
+	// undo is what the domain had before its last change, if known.
+	undo   []string
+	undoAt time.Time
+	// undoRequested is the one-shot edge for the app to restore undo.
+	undoRequested bool
+	// conflictCurrent is what Porkbun had at save time when it no longer
+	// matched what was loaded; conflictYours is what was being saved.
+	conflictCurrent []string
+	conflictYours   []string
+	// overwriteRequested is the one-shot edge for the app to save
+	// conflictYours over conflictCurrent.
+	overwriteRequested bool
+	// domainNames are the domains nameservers can be copied from.
+	domainNames []string
+	copyFilter  textinput.Model
+	copyIdx     int
+	copyFrom    string
+	// copyRequested is the one-shot edge for the app to fetch copyFrom's
+	// nameservers; copying stays true until they arrive.
+	copyRequested bool
+	copying       bool
 	err           error
 	success       string
 	width         int
@@ -59,11 +80,26 @@ type NameserversView struct {
 }
 
 func NewNameserversView() *NameserversView {
+	filter := textinput.New()
+	filter.Placeholder = "filter domains"
+	filter.CharLimit = 100
 	return &NameserversView{
-		mode: NSViewModeView,
+		mode:       NSViewModeView,
+		presets:    nspreset.Builtin,
+		copyFilter: filter,
 	}
 }
 
+// SetPresets sets the presets on offer.
+func (v *NameserversView) SetPresets(presets []nspreset.Preset) {
+	v.presets = presets
+}
+
+// SetDomainNames sets the domains whose nameservers can be copied.
+func (v *NameserversView) SetDomainNames(names []string) {
+	v.domainNames = names
+}
+
 func (v *NameserversView) SetDomain(domain string) {
 	v.domain = domain
 	v.nameservers = nil
@@ -71,6 +107,18 @@ func (v *NameserversView) SetDomain(domain string) {
 	v.err = nil
 	v.success = ""
 	v.mode = NSViewModeView
+	v.undo = nil
+}
+
+func (v *NameserversView) Domain() string {
+	return v.domain
+}
+
+// SetUndo sets the nameservers the last change replaced, and when it was
+// made; nil when there is nothing to undo.
+func (v *NameserversView) SetUndo(previous []string, changedAt time.Time) {
+	v.undo = previous
+	v.undoAt = changedAt
 }
 
 func (v *NameserversView) SetNameservers(ns []string) {
@@ -83,6 +131,33 @@ func (v *NameserversView) SetError(err error) {
 	v.err = err
 	v.loading = false
 	v.saving = false
+	v.copying = false
+}
+
+// TakeCopyRequest returns the domain to copy nameservers from, once per
+// pick.
+func (v *NameserversView) TakeCopyRequest() (string, bool) {
+	if !v.copyRequested {
+		return "", false
+	}
+	v.copyRequested = false
+	return v.copyFrom, true
+}
+
+// SetCopied puts from's nameservers in the editor, ready to save.
+func (v *NameserversView) SetCopied(from string, ns []string) {
+	v.copying = false
+	v.copyFilter.Blur()
+	v.success = fmt.Sprintf("Copied from %s; ctrl+s to save.", from)
+	v.fillInputs(ns)
+	v.mode = NSViewModeEdit
+	v.inputs[0].Focus()
+}
+
+// IsPickingDomain reports whether the copy picker is open; esc goes back to
+// the presets rather than leaving the view.
+func (v *NameserversView) IsPickingDomain() bool {
+	return v.mode == NSViewModeCopy
 }
 
 func (v *NameserversView) SetSuccess(msg string) {
@@ -96,19 +171,69 @@ func (v *NameserversView) SetSize(width, height int) {
 }
 
 func (v *NameserversView) initInputs() {
-	v.inputs = make([]textinput.Model, 4)
-	for i := 0; i < 4; i++ {
-		ti := textinput.New()
-		ti.Placeholder = fmt.Sprintf("ns%d.example.com", i+1)
-		ti.CharLimit = 100
-		if i < len(v.nameservers) {
-			ti.SetValue(v.nameservers[i])
+	v.fillInputs(v.nameservers)
+}
+
+// fillInputs replaces the rows with one per nameserver, padded to
+// nsMinRows. Anything past nsMaxRows is dropped with an error.
+func (v *NameserversView) fillInputs(ns []string) {
+	if len(ns) > nsMaxRows {
+		v.err = fmt.Errorf("only %d nameservers fit; dropped %s", nsMaxRows, strings.Join(ns[nsMaxRows:], ", "))
+		ns = ns[:nsMaxRows]
+	}
+	v.inputs = make([]textinput.Model, max(len(ns), nsMinRows))
+	for i := range v.inputs {
+		v.inputs[i] = newNSInput()
+		if i < len(ns) {
+			v.inputs[i].SetValue(ns[i])
 		}
-		v.inputs[i] = ti
 	}
 	v.cursor = 0
 }
 
+func newNSInput() textinput.Model {
+	ti := textinput.New()
+	ti.Placeholder = "ns.example.com"
+	ti.CharLimit = 253
+	return ti
+}
+
+// rowProblems has, for each row, why its hostname won't be accepted; ""
+// for blank and good rows.
+func (v *NameserversView) rowProblems() []string {
+	problems := make([]string, len(v.inputs))
+	first := make(map[string]int)
+	for i, input := range v.inputs {
+		host := nsHost(input.Value())
+		if host == "" {
+			continue
+		}
+		if err := api.CheckNameserver(host); err != nil {
+			problems[i] = err.Error()
+			continue
+		}
+		if j, dup := first[host]; dup {
+			problems[i] = fmt.Sprintf("same as NS%d", j+1)
+			continue
+		}
+		first[host] = i
+	}
+	return problems
+}
+
+// validate is the first reason the rows can't be saved, if any.
+func (v *NameserversView) validate() error {
+	for i, p := range v.rowProblems() {
+		if p != "" {
+			return fmt.Errorf("NS%d: %s", i+1, p)
+		}
+	}
+	if n := len(v.GetNameservers()); n < nsMinRows {
+		return fmt.Errorf("at least %d nameservers are required", nsMinRows)
+	}
+	return nil
+}
+
 func (v *NameserversView) GetNameservers() []string {
 	var ns []string
 	for _, input := range v.inputs {
@@ -119,6 +244,20 @@ func (v *NameserversView) GetNameservers() []string {
 	return ns
 }
 
+// Nameservers returns the nameservers as loaded, not as being edited.
+func (v *NameserversView) Nameservers() []string {
+	return v.nameservers
+}
+
+// TakeGlueRequest returns true exactly once per g press.
+func (v *NameserversView) TakeGlueRequest() bool {
+	if v.glueRequested {
+		v.glueRequested = false
+		return true
+	}
+	return false
+}
+
 func (v *NameserversView) IsSaving() bool {
 	return v.saving
 }
@@ -126,7 +265,42 @@ func (v *NameserversView) IsSaving() bool {
 // IsEditing reports whether a text input is focused; the app must not let
 // global key bindings (q, ?) steal printable keys while it is true.
 func (v *NameserversView) IsEditing() bool {
-	return v.mode == NSViewModeEdit
+	return v.mode == NSViewModeEdit || v.mode == NSViewModeCopy
+}
+
+// IsConfirming reports whether the undo or overwrite confirmation is
+// open; it captures every key.
+func (v *NameserversView) IsConfirming() bool {
+	return v.mode == NSViewModeConfirmUndo || v.mode == NSViewModeConflict
+}
+
+// SetConflict stops a save that found the nameservers changed since they
+// were loaded, and asks whether to overwrite current with yours.
+func (v *NameserversView) SetConflict(current, yours []string) {
+	v.saving = false
+	v.conflictCurrent = current
+	v.conflictYours = yours
+	v.mode = NSViewModeConflict
+}
+
+// TakeOverwriteRequest returns the confirmed overwrite, once: the
+// nameservers Porkbun had and the ones to save over them.
+func (v *NameserversView) TakeOverwriteRequest() (current, yours []string, ok bool) {
+	if !v.overwriteRequested {
+		return nil, nil, false
+	}
+	v.overwriteRequested = false
+	return v.conflictCurrent, v.conflictYours, true
+}
+
+// TakeUndoRequest returns the nameservers to restore, once per confirmed
+// undo.
+func (v *NameserversView) TakeUndoRequest() ([]string, bool) {
+	if !v.undoRequested {
+		return nil, false
+	}
+	v.undoRequested = false
+	return v.undo, true
 }
 
 func (v *NameserversView) StartSaving() {
@@ -154,6 +328,12 @@ func (v *NameserversView) Update(msg tea.Msg) (*NameserversView, tea.Cmd) {
 		return v.updateEdit(msg)
 	case NSViewModePreset:
 		return v.updatePreset(msg)
+	case NSViewModeConfirmUndo:
+		return v.updateConfirmUndo(msg)
+	case NSViewModeConflict:
+		return v.updateConflict(msg)
+	case NSViewModeCopy:
+		return v.updateCopy(msg)
 	default:
 		return v.updateView(msg)
 	}
@@ -170,6 +350,47 @@ func (v *NameserversView) updateView(msg tea.Msg) (*NameserversView, tea.Cmd) {
 		case "p":
 			v.mode = NSViewModePreset
 			v.presetIdx = 0
+		case "g":
+			if !v.loading {
+				v.glueRequested = true
+			}
+		case "u":
+			if len(v.undo) > 0 && !v.loading && !v.saving {
+				v.mode = NSViewModeConfirmUndo
+			}
+		}
+	}
+	return v, nil
+}
+
+func (v *NameserversView) updateConfirmUndo(msg tea.Msg) (*NameserversView, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "y":
+			v.mode = NSViewModeView
+			v.StartSaving()
+			v.saveRequested = false // restored from undo, not the inputs
+			v.undoRequested = true
+		case "n", "esc":
+			v.mode = NSViewModeView
+		}
+	}
+	return v, nil
+}
+
+func (v *NameserversView) updateConflict(msg tea.Msg) (*NameserversView, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "y":
+			v.mode = NSViewModeView
+			v.StartSaving()
+			v.saveRequested = false // saved from the conflict, not the inputs
+			v.overwriteRequested = true
+		case "n", "esc":
+			// Drop the edit and show what Porkbun has now.
+			v.SetNameservers(v.conflictCurrent)
+			v.mode = NSViewModeView
+			v.success = "Not saved; these are the nameservers as they are now."
 		}
 	}
 	return v, nil
@@ -178,22 +399,47 @@ func (v *NameserversView) updateView(msg tea.Msg) (*NameserversView, tea.Cmd) {
 func (v *NameserversView) updateEdit(msg tea.Msg) (*NameserversView, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch {
-		case key.Matches(msg, keys.Keys.Back):
+		// Rows are moved between with tab and the arrows only: j and k
+		// belong in hostnames.
+		switch msg.String() {
+		case "esc":
 			v.mode = NSViewModeView
 			v.inputs[v.cursor].Blur()
 			return v, nil
-		case key.Matches(msg, keys.Keys.Tab), key.Matches(msg, keys.Keys.Down):
-			v.inputs[v.cursor].Blur()
-			v.cursor = (v.cursor + 1) % len(v.inputs)
-			v.inputs[v.cursor].Focus()
-			return v, textinput.Blink
-		case key.Matches(msg, keys.Keys.Up):
-			v.inputs[v.cursor].Blur()
-			v.cursor = (v.cursor - 1 + len(v.inputs)) % len(v.inputs)
-			v.inputs[v.cursor].Focus()
-			return v, textinput.Blink
-		case msg.String() == "ctrl+s":
+		case "tab", "down":
+			return v, v.focusRow((v.cursor + 1) % len(v.inputs))
+		case "shift+tab", "up":
+			return v, v.focusRow((v.cursor - 1 + len(v.inputs)) % len(v.inputs))
+		case "ctrl+n":
+			if len(v.inputs) < nsMaxRows {
+				v.inputs = append(v.inputs[:v.cursor+1], append([]textinput.Model{newNSInput()}, v.inputs[v.cursor+1:]...)...)
+				return v, v.focusRow(v.cursor + 1)
+			}
+			return v, nil
+		case "ctrl+x":
+			if len(v.inputs) > nsMinRows {
+				v.inputs = append(v.inputs[:v.cursor], v.inputs[v.cursor+1:]...)
+				return v, v.focusRow(min(v.cursor, len(v.inputs)-1))
+			}
+			return v, nil
+		case "shift+up", "alt+up":
+			if v.cursor > 0 {
+				v.inputs[v.cursor-1], v.inputs[v.cursor] = v.inputs[v.cursor], v.inputs[v.cursor-1]
+				v.cursor--
+			}
+			return v, nil
+		case "shift+down", "alt+down":
+			if v.cursor < len(v.inputs)-1 {
+				v.inputs[v.cursor+1], v.inputs[v.cursor] = v.inputs[v.cursor], v.inputs[v.cursor+1]
+				v.cursor++
+			}
+			return v, nil
+		case "ctrl+s":
+			if err := v.validate(); err != nil {
+				v.err = err
+				v.success = ""
+				return v, nil
+			}
 			v.StartSaving()
 			return v, nil // App will handle the actual save
 		}
@@ -204,6 +450,16 @@ func (v *NameserversView) updateEdit(msg tea.Msg) (*NameserversView, tea.Cmd) {
 	return v, nil
 }
 
+// focusRow moves the cursor to row i.
+func (v *NameserversView) focusRow(i int) tea.Cmd {
+	for j := range v.inputs {
+		v.inputs[j].Blur()
+	}
+	v.cursor = i
+	v.inputs[i].Focus()
+	return textinput.Blink
+}
+
 func (v *NameserversView) updatePreset(msg tea.Msg) (*NameserversView, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
@@ -215,19 +471,20 @@ func (v *NameserversView) updatePreset(msg tea.Msg) (*NameserversView, tea.Cmd)
 				v.presetIdx--
 			}
 		case key.Matches(msg, keys.Keys.Down):
-			if v.presetIdx < len(NSPresets)-1 {
+			if v.presetIdx < len(v.presets) {
 				v.presetIdx++
 			}
 		case key.Matches(msg, keys.Keys.Enter):
-			// Apply preset
-			preset := NSPresets[v.presetIdx]
-			for i := range v.inputs {
-				if i < len(preset.NS) {
-					v.inputs[i].SetValue(preset.NS[i])
-				} else {
-					v.inputs[i].SetValue("")
-				}
+			if v.presetIdx == len(v.presets) {
+				v.mode = NSViewModeCopy
+				v.copyFilter.SetValue("")
+				v.copyIdx = 0
+				v.err = nil
+				v.copyFilter.Focus()
+				return v, textinput.Blink
 			}
+			// Apply preset
+			v.fillInputs(v.presets[v.presetIdx].NS)
 			v.mode = NSViewModeEdit
 			v.inputs[0].Focus()
 			return v, textinput.Blink
@@ -236,6 +493,58 @@ func (v *NameserversView) updatePreset(msg tea.Msg) (*NameserversView, tea.Cmd)
 	return v, nil
 }
 
+func (v *NameserversView) updateCopy(msg tea.Msg) (*NameserversView, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return v, nil
+	}
+	if v.copying {
+		return v, nil // wait for the nameservers
+	}
+	matches := v.copyMatches()
+	// j/k would be typed into the filter, so only the arrows move.
+	switch keyMsg.Type {
+	case tea.KeyEsc:
+		v.mode = NSViewModePreset
+		v.copyFilter.Blur()
+		return v, nil
+	case tea.KeyUp:
+		if v.copyIdx > 0 {
+			v.copyIdx--
+		}
+		return v, nil
+	case tea.KeyDown:
+		if v.copyIdx < len(matches)-1 {
+			v.copyIdx++
+		}
+		return v, nil
+	case tea.KeyEnter:
+		if v.copyIdx < len(matches) {
+			v.copyFrom = matches[v.copyIdx]
+			v.copyRequested = true
+			v.copying = true
+			v.err = nil
+		}
+		return v, nil
+	}
+	var cmd tea.Cmd
+	v.copyFilter, cmd = v.copyFilter.Update(msg)
+	v.copyIdx = 0
+	return v, cmd
+}
+
+// copyMatches are the other domains whose names contain the filter.
+func (v *NameserversView) copyMatches() []string {
+	filter := strings.ToLower(strings.TrimSpace(v.copyFilter.Value()))
+	var out []string
+	for _, name := range v.domainNames {
+		if name != v.domain && strings.Contains(strings.ToLower(name), filter) {
+			out = append(out, name)
+		}
+	}
+	return out
+}
+
 func (v *NameserversView) View() string {
 	var b strings.Builder
 
@@ -266,25 +575,60 @@ func (v *NameserversView) View() string {
 	switch v.mode {
 	case NSViewModePreset:
 		b.WriteString("  Select a preset:\n\n")
-		for i, preset := range NSPresets {
+		names := make([]string, 0, len(v.presets)+1)
+		for _, preset := range v.presets {
+			names = append(names, preset.Name)
+		}
+		names = append(names, "Copy from another domain...")
+		for i, name := range names {
 			cursor := "  "
 			if i == v.presetIdx {
 				cursor = "> "
 			}
-			row := fmt.Sprintf("%s%s", cursor, preset.Name)
+			row := fmt.Sprintf("%s%s", cursor, name)
 			if i == v.presetIdx {
 				row = styles.TableSelectedStyle.Render(row)
 			}
 			b.WriteString(row)
 			b.WriteString("\n")
 		}
+		if v.presetIdx < len(v.presets) {
+			b.WriteString("\n")
+			b.WriteString(styles.HelpStyle.Render("  " + strings.Join(v.presets[v.presetIdx].NS, ", ")))
+			b.WriteString("\n")
+		}
 		b.WriteString("\n")
 		b.WriteString(styles.HelpStyle.Render("  enter to apply, esc to cancel"))
 
+	case NSViewModeCopy:
+		b.WriteString("  Copy the nameservers of:\n\n  ")
+		b.WriteString(v.copyFilter.View())
+		b.WriteString("\n\n")
+		matches := v.copyMatches()
+		if len(matches) == 0 {
+			b.WriteString("  No other domain matches.\n")
+		}
+		start := max(min(v.copyIdx-copyVisible/2, len(matches)-copyVisible), 0)
+		end := min(start+copyVisible, len(matches))
+		for i := start; i < end; i++ {
+			b.WriteString(pickRow(matches[i], i == v.copyIdx))
+		}
+		if end-start < len(matches) {
+			b.WriteString(styles.HelpStyle.Render(fmt.Sprintf("  %d-%d of %d", start+1, end, len(matches))))
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+		if v.copying {
+			b.WriteString(styles.SpinnerStyle.Render(fmt.Sprintf("  Fetching the nameservers of %s...", v.copyFrom)))
+		} else {
+			b.WriteString(styles.HelpStyle.Render("  type to filter, ↑/↓ to choose, enter to copy, esc to go back"))
+		}
+
 	case NSViewModeEdit:
-		b.WriteString("  Edit nameservers:\n\n")
+		b.WriteString(fmt.Sprintf("  Edit nameservers (%d of %d rows):\n\n", len(v.inputs), nsMaxRows))
+		problems := v.rowProblems()
 		for i, input := range v.inputs {
-			label := fmt.Sprintf("  NS%d: ", i+1)
+			label := fmt.Sprintf("  NS%-2d ", i+1)
 			b.WriteString(styles.LabelStyle.Render(label))
 			cursor := "  "
 			if i == v.cursor {
@@ -292,15 +636,36 @@ func (v *NameserversView) View() string {
 			}
 			b.WriteString(cursor)
 			b.WriteString(input.View())
+			if problems[i] != "" {
+				b.WriteString("  ")
+				b.WriteString(styles.ErrorStyle.Render("✗ " + problems[i]))
+			}
 			b.WriteString("\n")
 		}
 		b.WriteString("\n")
 		if v.saving {
 			b.WriteString(styles.SpinnerStyle.Render("  Saving..."))
 		} else {
-			b.WriteString(styles.HelpStyle.Render("  tab/j/k to navigate, ctrl+s to save, esc to cancel"))
+			b.WriteString(styles.HelpStyle.Render("  tab/↑/↓ to navigate, ctrl+n add row, ctrl+x remove row, shift+↑/↓ move row"))
+			b.WriteString("\n")
+			b.WriteString(styles.HelpStyle.Render("  ctrl+s to save, esc to cancel"))
 		}
 
+	case NSViewModeConfirmUndo:
+		b.WriteString(styles.PremiumStyle.Render(fmt.Sprintf("  Undo the change made %s?", v.undoAt.Format("2006-01-02 15:04"))))
+		b.WriteString("\n\n")
+		b.WriteString(v.nsList("Now", v.nameservers))
+		b.WriteString(v.nsList("Restore", v.undo))
+		b.WriteString("\n")
+		b.WriteString(styles.HelpStyle.Render("  y restore · n cancel"))
+
+	case NSViewModeConflict:
+		b.WriteString(styles.PremiumStyle.Render("  The nameservers changed since they were loaded."))
+		b.WriteString("\n\n")
+		b.WriteString(v.conflictView())
+		b.WriteString("\n")
+		b.WriteString(styles.HelpStyle.Render("  y overwrite with yours · n keep theirs"))
+
 	default:
 		b.WriteString("  Current nameservers:\n\n")
 		if len(v.nameservers) == 0 {
@@ -311,18 +676,113 @@ func (v *NameserversView) View() string {
 			}
 		}
 		b.WriteString("\n")
-		b.WriteString(styles.HelpStyle.Render("  e to edit, p for presets, esc to go back"))
+		if len(v.undo) > 0 {
+			b.WriteString(styles.HelpStyle.Render(fmt.Sprintf("  Before the change on %s: %s",
+				v.undoAt.Format("2006-01-02 15:04"), strings.Join(v.undo, ", "))))
+			b.WriteString("\n\n")
+		}
+		switch {
+		case v.saving:
+			b.WriteString(styles.SpinnerStyle.Render("  Saving..."))
+		case len(v.undo) > 0:
+			b.WriteString(styles.HelpStyle.Render("  e to edit, p for presets, u to undo the last change, g for glue records, esc to go back"))
+		default:
+			b.WriteString(styles.HelpStyle.Render("  e to edit, p for presets, g for glue records, esc to go back"))
+		}
 	}
 
 	return b.String()
 }
 
+// conflictView is a three-way diff: every host in the loaded, current or
+// new set, and which of them have it.
+func (v *NameserversView) conflictView() string {
+	sets := [][]string{v.nameservers, v.conflictCurrent, v.conflictYours}
+	var hosts []string
+	seen := make(map[string]bool)
+	for _, set := range [][]string{v.conflictYours, v.conflictCurrent, v.nameservers} {
+		for _, h := range set {
+			if h = nsHost(h); !seen[h] {
+				seen[h] = true
+				hosts = append(hosts, h)
+			}
+		}
+	}
+	width := 30
+	for _, h := range hosts {
+		width = max(width, len(h))
+	}
+
+	var b strings.Builder
+	b.WriteString(styles.TableHeaderStyle.Render(fmt.Sprintf("  %-*s  %-8s  %-8s  %-8s", width, "Nameserver", "Loaded", "Now", "Yours")))
+	b.WriteString("\n")
+	for _, h := range hosts {
+		b.WriteString(fmt.Sprintf("  %-*s", width, h))
+		for _, set := range sets {
+			// The position, not just a tick: order matters to the save,
+			// so a reorder has to show.
+			mark := "-"
+			if i := hostIndex(set, h); i >= 0 {
+				mark = fmt.Sprintf("NS%d", i+1)
+			}
+			b.WriteString(fmt.Sprintf("  %-8s", mark))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func nsHost(h string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(h)), ".")
+}
+
+// hostIndex is h's position in set, or -1.
+func hostIndex(set []string, h string) int {
+	for i, x := range set {
+		if nsHost(x) == h {
+			return i
+		}
+	}
+	return -1
+}
+
+func (v *NameserversView) nsList(label string, ns []string) string {
+	return fmt.Sprintf("  %s %s\n", styles.LabelStyle.Render(fmt.Sprintf("%-8s", label+":")), strings.Join(ns, ", "))
+}
+
 func (v *NameserversView) HelpText() string {
 	switch v.mode {
+	case NSViewModeCopy:
+		return lipgloss.JoinHorizontal(lipgloss.Top,
+			styles.HelpStyle.Render("↑/↓"),
+			" choose  ",
+			styles.HelpStyle.Render("enter"),
+			" copy  ",
+			styles.HelpStyle.Render("esc"),
+			" back",
+		)
+	case NSViewModeConflict:
+		return lipgloss.JoinHorizontal(lipgloss.Top,
+			styles.HelpStyle.Render("y"),
+			" overwrite  ",
+			styles.HelpStyle.Render("n/esc"),
+			" keep theirs",
+		)
+	case NSViewModeConfirmUndo:
+		return lipgloss.JoinHorizontal(lipgloss.Top,
+			styles.HelpStyle.Render("y"),
+			" restore  ",
+			styles.HelpStyle.Render("n/esc"),
+			" cancel",
+		)
 	case NSViewModeEdit:
 		return lipgloss.JoinHorizontal(lipgloss.Top,
-			styles.HelpStyle.Render("tab/j/k"),
+			styles.HelpStyle.Render("tab/↑/↓"),
 			" navigate  ",
+			styles.HelpStyle.Render("ctrl+n/ctrl+x"),
+			" add/remove  ",
+			styles.HelpStyle.Render("shift+↑/↓"),
+			" move  ",
 			styles.HelpStyle.Render("ctrl+s"),
 			" save  ",
 			styles.HelpStyle.Render("esc"),
@@ -338,16 +798,24 @@ func (v *NameserversView) HelpText() string {
 			" cancel",
 		)
 	default:
-		return lipgloss.JoinHorizontal(lipgloss.Top,
+		parts := []string{
 			styles.HelpStyle.Render("e"),
 			" edit  ",
 			styles.HelpStyle.Render("p"),
 			" presets  ",
+		}
+		if len(v.undo) > 0 {
+			parts = append(parts, styles.HelpStyle.Render("u"), " undo  ")
+		}
+		parts = append(parts,
+			styles.HelpStyle.Render("g"),
+			" glue  ",
 			styles.HelpStyle.Render("esc"),
 			" back  ",
 			styles.HelpStyle.Render("q"),
 			" quit",
 		)
+		return lipgloss.JoinHorizontal(lipgloss.Top, parts...)
 	}
 }
 
@@ -0,0 +1,108 @@
+package views
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func nsKey(v *NameserversView, s string) *NameserversView {
+	var msg tea.KeyMsg
+	switch s {
+	case "ctrl+n":
+		msg = tea.KeyMsg{Type: tea.KeyCtrlN}
+	case "ctrl+x":
+		msg = tea.KeyMsg{Type: tea.KeyCtrlX}
+	case "ctrl+s":
+		msg = tea.KeyMsg{Type: tea.KeyCtrlS}
+	case "tab":
+		msg = tea.KeyMsg{Type: tea.KeyTab}
+	case "shift+up":
+		msg = tea.KeyMsg{Type: tea.KeyShiftUp}
+	case "backspace":
+		msg = tea.KeyMsg{Type: tea.KeyBackspace}
+	default:
+		msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+	}
+	v, _ = v.Update(msg)
+	return v
+}
+
+func nsType(v *NameserversView, s string) *NameserversView {
+	for _, r := range s {
+		v = nsKey(v, string(r))
+	}
+	return v
+}
+
+func TestNameserversView_AddRemoveAndReorderRows(t *testing.T) {
+	v := NewNameserversView()
+	v.SetDomain("example.com")
+	v.SetNameservers([]string{"ns1.example.net", "ns2.example.net"})
+	v = nsKey(v, "e")
+
+	v = nsKey(v, "tab")
+	v = nsKey(v, "ctrl+n")
+	v = nsType(v, "jk.example.net") // j and k are typed, not navigation
+	v = nsKey(v, "shift+up")
+	want := []string{"ns1.example.net", "jk.example.net", "ns2.example.net"}
+	if got := v.GetNameservers(); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("rows = %v, want %v", got, want)
+	}
+
+	v = nsKey(v, "ctrl+x")
+	v = nsKey(v, "ctrl+x") // two rows is the minimum
+	if got := v.GetNameservers(); len(got) != 2 || got[1] != "ns2.example.net" {
+		t.Errorf("rows after removing = %v", got)
+	}
+
+	for range nsMaxRows + 2 {
+		v = nsKey(v, "ctrl+n")
+	}
+	if len(v.inputs) != nsMaxRows {
+		t.Errorf("%d rows, want at most %d", len(v.inputs), nsMaxRows)
+	}
+}
+
+func TestNameserversView_ValidationStopsSave(t *testing.T) {
+	for _, tc := range []struct {
+		ns   []string
+		want string
+	}{
+		{[]string{"ns1.example.net"}, "at least 2 nameservers"},
+		{[]string{"ns1.example.net", "192.0.2.53"}, "IP address"},
+		{[]string{"ns1.example.net", "ns2.example.net;"}, "not a valid nameserver hostname"},
+		{[]string{"ns1.example.net", "NS1.example.net."}, "same as NS1"},
+	} {
+		v := NewNameserversView()
+		v.SetDomain("example.com")
+		v.SetNameservers(tc.ns)
+		v = nsKey(v, "e")
+		v = nsKey(v, "ctrl+s")
+		if v.TakeSaveRequest() || v.IsSaving() {
+			t.Errorf("%v: saved despite the problem", tc.ns)
+		}
+		if !strings.Contains(v.View(), tc.want) {
+			t.Errorf("%v: view missing %q:\n%s", tc.ns, tc.want, v.View())
+		}
+	}
+}
+
+func TestNameserversView_ConflictShowsReorder(t *testing.T) {
+	v := NewNameserversView()
+	v.SetSize(120, 40)
+	v.SetDomain("example.com")
+	v.SetNameservers([]string{"ns1.example.net", "ns2.example.net"})
+	v.SetConflict([]string{"ns2.example.net", "ns1.example.net"}, []string{"ns1.example.net", "ns2.example.net"})
+
+	out := v.View()
+	for _, want := range []string{
+		"ns1.example.net                 NS1       NS2       NS1",
+		"ns2.example.net                 NS2       NS1       NS2",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("conflict table missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,284 @@
+package views
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bc/porkbun-tui/internal/api"
+	"github.com/bc/porkbun-tui/internal/keys"
+	"github.com/bc/porkbun-tui/internal/styles"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ProviderGroup is the domains whose DNS one provider hosts.
+type ProviderGroup struct {
+	Name    string
+	Domains []string
+}
+
+// ProviderView groups the portfolio by DNS provider, like TLDView groups
+// it by TLD.
+type ProviderView struct {
+	groups    []ProviderGroup
+	total     int // domains in the portfolio
+	unchecked int // domains whose nameservers aren't known yet
+	cursor    int // Which provider group is selected
+	offset    int // Line offset for scrolling
+	height    int
+	width     int
+	expanded  map[string]bool
+
+	scanning bool
+	// rescanRequested is the one-shot edge for the app to fetch every
+	// domain's nameservers again.
+	rescanRequested bool
+}
+
+func NewProviderView() *ProviderView {
+	return &ProviderView{expanded: make(map[string]bool)}
+}
+
+// SetData groups domains by their provider, by name, most domains first.
+// The cursor stays on the selected provider.
+func (v *ProviderView) SetData(domains []api.Domain, providers map[string]string) {
+	var selected string
+	if v.cursor < len(v.groups) {
+		selected = v.groups[v.cursor].Name
+	}
+
+	index := make(map[string]int) // group by provider name
+	v.groups = nil
+	v.total = len(domains)
+	v.unchecked = 0
+	for _, d := range domains {
+		p, ok := providers[d.Name]
+		if !ok || p == "" {
+			v.unchecked++
+			continue
+		}
+		i, ok := index[p]
+		if !ok {
+			i = len(v.groups)
+			index[p] = i
+			v.groups = append(v.groups, ProviderGroup{Name: p})
+		}
+		v.groups[i].Domains = append(v.groups[i].Domains, d.Name)
+	}
+	for i := range v.groups {
+		sort.Strings(v.groups[i].Domains)
+	}
+	sort.Slice(v.groups, func(i, j int) bool {
+		if len(v.groups[i].Domains) != len(v.groups[j].Domains) {
+			return len(v.groups[i].Domains) > len(v.groups[j].Domains)
+		}
+		return v.groups[i].Name < v.groups[j].Name
+	})
+
+	v.cursor = 0
+	for i, g := range v.groups {
+		if g.Name == selected {
+			v.cursor = i
+			break
+		}
+	}
+	v.adjustOffset()
+}
+
+// Groups returns the provider groups, most domains first.
+func (v *ProviderView) Groups() []ProviderGroup {
+	return v.groups
+}
+
+// SetScanning shows whether nameservers are being fetched.
+func (v *ProviderView) SetScanning(scanning bool) {
+	v.scanning = scanning
+}
+
+// TakeRescanRequest reports, once, that the user asked to fetch every
+// domain's nameservers again.
+func (v *ProviderView) TakeRescanRequest() bool {
+	req := v.rescanRequested
+	v.rescanRequested = false
+	return req
+}
+
+func (v *ProviderView) SetSize(width, height int) {
+	v.width = width
+	v.height = height - 8
+	if v.height < 1 {
+		v.height = 1
+	}
+}
+
+// getLineForCursor returns the line number where the cursor's group header is
+func (v *ProviderView) getLineForCursor() int {
+	line := 0
+	for i := 0; i < v.cursor && i < len(v.groups); i++ {
+		line++ // group header line
+		if v.expanded[v.groups[i].Name] {
+			line += len(v.groups[i].Domains)
+		}
+	}
+	return line
+}
+
+// getTotalLines returns the total number of content lines
+func (v *ProviderView) getTotalLines() int {
+	lines := 0
+	for _, g := range v.groups {
+		lines++ // group header
+		if v.expanded[g.Name] {
+			lines += len(g.Domains)
+		}
+	}
+	return lines
+}
+
+func (v *ProviderView) adjustOffset() {
+	cursorLine := v.getLineForCursor()
+	if cursorLine < v.offset {
+		v.offset = cursorLine
+	}
+	if cursorLine >= v.offset+v.height {
+		v.offset = cursorLine - v.height + 1
+	}
+	if totalLines := v.getTotalLines(); v.offset > totalLines-v.height {
+		v.offset = max(0, totalLines-v.height)
+	}
+}
+
+func (v *ProviderView) Update(msg tea.Msg) (*ProviderView, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, keys.Keys.Up):
+			if v.cursor > 0 {
+				v.cursor--
+				v.adjustOffset()
+			}
+		case key.Matches(msg, keys.Keys.Down):
+			if v.cursor < len(v.groups)-1 {
+				v.cursor++
+				v.adjustOffset()
+			}
+		case key.Matches(msg, keys.Keys.Enter):
+			if v.cursor < len(v.groups) {
+				name := v.groups[v.cursor].Name
+				v.expanded[name] = !v.expanded[name]
+				v.adjustOffset()
+			}
+		case key.Matches(msg, keys.Keys.Refresh):
+			if !v.scanning {
+				v.rescanRequested = true
+			}
+		}
+	}
+	return v, nil
+}
+
+func (v *ProviderView) View() string {
+	var b strings.Builder
+
+	title := styles.TitleStyle.Render(" DNS Providers ")
+	b.WriteString(title)
+	b.WriteString("\n\n")
+
+	if len(v.groups) == 0 {
+		switch {
+		case v.scanning:
+			b.WriteString("  Fetching nameservers...")
+		case v.total == 0:
+			b.WriteString("  No domains to display.")
+		default:
+			b.WriteString("  No nameservers known yet (r to fetch them).")
+		}
+		return b.String()
+	}
+
+	// Column widths
+	nameWidth := 20
+	countWidth := 8
+	shareWidth := 8
+
+	header := fmt.Sprintf("  %-*s  %*s  %*s",
+		nameWidth, "Provider",
+		countWidth, "Domains",
+		shareWidth, "Share",
+	)
+	b.WriteString(styles.TableHeaderStyle.Render(header))
+	b.WriteString("\n")
+
+	checked := v.total - v.unchecked
+	var lines []string
+	for i, g := range v.groups {
+		expandIndicator := "▶"
+		if v.expanded[g.Name] {
+			expandIndicator = "▼"
+		}
+		share := float64(len(g.Domains)) / float64(checked) * 100
+		row := fmt.Sprintf("%s %-*s  %*d  %*.1f%%",
+			expandIndicator,
+			nameWidth, truncate(g.Name, nameWidth),
+			countWidth, len(g.Domains),
+			shareWidth-1, share,
+		)
+		if i == v.cursor {
+			row = styles.TableSelectedStyle.Render(row)
+		}
+		lines = append(lines, row)
+
+		if v.expanded[g.Name] {
+			for _, name := range g.Domains {
+				lines = append(lines, styles.HelpStyle.Render(fmt.Sprintf("    %s", name)))
+			}
+		}
+	}
+
+	visibleEnd := min(v.offset+v.height, len(lines))
+	for i := v.offset; i < visibleEnd; i++ {
+		b.WriteString(lines[i])
+		b.WriteString("\n")
+	}
+	if len(lines) > v.height {
+		scrollInfo := fmt.Sprintf(" %d-%d of %d lines ", v.offset+1, visibleEnd, len(lines))
+		b.WriteString(styles.HelpStyle.Render(scrollInfo))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	totalLine := fmt.Sprintf("  Total: %d domains across %d providers", checked, len(v.groups))
+	b.WriteString(styles.ValueStyle.Render(totalLine))
+	switch {
+	case v.scanning:
+		b.WriteString(styles.HelpStyle.Render("  (fetching nameservers...)"))
+	case v.unchecked > 0:
+		b.WriteString(styles.HelpStyle.Render(fmt.Sprintf("  (%d not checked, r to fetch)", v.unchecked)))
+	}
+
+	return b.String()
+}
+
+func (v *ProviderView) HelpText() string {
+	return lipgloss.JoinHorizontal(lipgloss.Top,
+		styles.HelpStyle.Render("j/k"),
+		" navigate  ",
+		styles.HelpStyle.Render("enter"),
+		" expand  ",
+		styles.HelpStyle.Render("r"),
+		" refetch nameservers  ",
+		styles.HelpStyle.Render("esc"),
+		" back  ",
+		styles.HelpStyle.Render("q"),
+		" quit",
+	)
+}
+
+func (v *ProviderView) StatusText() string {
+	if v.scanning {
+		return fmt.Sprintf("%d providers, fetching nameservers...", len(v.groups))
+	}
+	return fmt.Sprintf("%d providers", len(v.groups))
+}
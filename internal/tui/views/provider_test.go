@@ -0,0 +1,57 @@
+package views
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bc/porkbun-tui/internal/api"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestProviderView_GroupsMostDomainsFirst(t *testing.T) {
+	v := NewProviderView()
+	v.SetSize(100, 30)
+
+	domains := []api.Domain{{Name: "a.com"}, {Name: "b.com"}, {Name: "c.com"}, {Name: "d.com"}}
+	v.SetData(domains, map[string]string{"a.com": "Porkbun", "b.com": "Cloudflare", "c.com": "Cloudflare"})
+
+	groups := v.Groups()
+	if len(groups) != 2 || groups[0].Name != "Cloudflare" || len(groups[0].Domains) != 2 {
+		t.Fatalf("groups = %+v, want Cloudflare with two domains first", groups)
+	}
+	out := v.View()
+	if !strings.Contains(out, "66.7%") || !strings.Contains(out, "1 not checked") {
+		t.Errorf("view missing share or unchecked count:\n%s", out)
+	}
+}
+
+func TestProviderView_CursorFollowsProviderAcrossUpdates(t *testing.T) {
+	v := NewProviderView()
+	v.SetSize(100, 30)
+	domains := []api.Domain{{Name: "a.com"}, {Name: "b.com"}, {Name: "c.com"}}
+	v.SetData(domains, map[string]string{"a.com": "Porkbun", "b.com": "Cloudflare", "c.com": "Cloudflare"})
+
+	v, _ = v.Update(tea.KeyMsg{Type: tea.KeyDown}) // Porkbun
+	v.SetData(domains, map[string]string{"a.com": "Porkbun", "b.com": "Porkbun", "c.com": "Cloudflare"})
+	if got := v.Groups()[v.cursor].Name; got != "Porkbun" {
+		t.Errorf("cursor on %s after regrouping, want Porkbun", got)
+	}
+}
+
+func TestProviderView_RescanIsOneShotAndWaitsForScan(t *testing.T) {
+	v := NewProviderView()
+	v.SetScanning(true)
+	v, _ = v.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	if v.TakeRescanRequest() {
+		t.Error("rescan requested while a scan was running")
+	}
+
+	v.SetScanning(false)
+	v, _ = v.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	if !v.TakeRescanRequest() {
+		t.Fatal("r requested no rescan")
+	}
+	if v.TakeRescanRequest() {
+		t.Error("the rescan request fired twice")
+	}
+}
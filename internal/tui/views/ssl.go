@@ -0,0 +1,154 @@
+package views
+
+import (
+	"crypto/x509"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bc/porkbun-tui/internal/certs"
+	"github.com/bc/porkbun-tui/internal/styles"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// SSLView shows the certificate chain of a domain's Porkbun SSL bundle.
+// It never sees the private key: the app drops it before the chain
+// reaches the view.
+type SSLView struct {
+	domain  string
+	chain   []*x509.Certificate
+	width   int
+	height  int
+	loading bool
+	err     error
+}
+
+func NewSSLView() *SSLView {
+	return &SSLView{}
+}
+
+func (v *SSLView) SetDomain(domain string) {
+	v.domain = domain
+	v.chain = nil
+	v.loading = true
+	v.err = nil
+}
+
+// SetChain takes the parsed chain, leaf first.
+func (v *SSLView) SetChain(chain []*x509.Certificate) {
+	v.chain = chain
+	v.loading = false
+}
+
+func (v *SSLView) SetError(err error) {
+	v.err = err
+	v.loading = false
+}
+
+func (v *SSLView) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+}
+
+func (v *SSLView) View() string {
+	var b strings.Builder
+
+	title := styles.TitleStyle.Render(fmt.Sprintf(" SSL Certificate: %s ", v.domain))
+	b.WriteString(title)
+	b.WriteString("\n\n")
+
+	if v.loading {
+		b.WriteString("  Retrieving SSL bundle...")
+		return b.String()
+	}
+
+	if v.err != nil {
+		b.WriteString(styles.ErrorStyle.Render(fmt.Sprintf("  Error: %v", v.err)))
+		if isAPIAccessError(v.err) {
+			b.WriteString("\n\n")
+			b.WriteString(styles.HelpStyle.Render("  This domain needs API access enabled.\n"))
+			b.WriteString(styles.HelpStyle.Render("  Go to porkbun.com → Domain Management → " + v.domain + " → API Access → ON"))
+		}
+		return b.String()
+	}
+
+	if len(v.chain) == 0 {
+		b.WriteString("  No certificate.")
+		return b.String()
+	}
+
+	now := time.Now()
+	leaf := v.chain[0]
+	daysLeft := certs.DaysLeft(leaf, now)
+
+	rows := []struct {
+		label string
+		value string
+		style lipgloss.Style
+	}{
+		{"Subject", leaf.Subject.CommonName, styles.ValueStyle},
+		{"Issuer", issuerName(leaf), styles.ValueStyle},
+		{"Names", strings.Join(certs.Names(leaf), ", "), styles.ValueStyle},
+		{"Not Before", leaf.NotBefore.Format("2006-01-02 15:04 MST"), notBeforeStyle(leaf, now)},
+		{"Not After", leaf.NotAfter.Format("2006-01-02 15:04 MST"), styles.ExpirationStyle(daysLeft)},
+		{"Days Left", fmt.Sprintf("%d", daysLeft), styles.ExpirationStyle(daysLeft)},
+		{"Serial", leaf.SerialNumber.Text(16), styles.ValueStyle},
+	}
+
+	labelWidth := 16
+	for _, row := range rows {
+		label := styles.LabelStyle.Render(fmt.Sprintf("%-*s", labelWidth, row.label+":"))
+		b.WriteString(fmt.Sprintf("  %s %s\n", label, row.style.Render(row.value)))
+	}
+
+	if len(v.chain) > 1 {
+		b.WriteString("\n")
+		b.WriteString(styles.TableHeaderStyle.Render(" Chain "))
+		b.WriteString("\n")
+		for _, c := range v.chain[1:] {
+			days := certs.DaysLeft(c, now)
+			b.WriteString(fmt.Sprintf("  %s  %s\n",
+				styles.ValueStyle.Render(c.Subject.CommonName),
+				styles.ExpirationStyle(days).Render("until "+c.NotAfter.Format("2006-01-02")),
+			))
+		}
+	}
+
+	b.WriteString("\n")
+	b.WriteString(styles.HelpStyle.Render("  porkbun-tui ssl " + v.domain + " --out DIR writes cert, chain and key files"))
+
+	return b.String()
+}
+
+func (v *SSLView) HelpText() string {
+	return lipgloss.JoinHorizontal(lipgloss.Top,
+		styles.HelpStyle.Render("esc"),
+		" back  ",
+		styles.HelpStyle.Render("q"),
+		" quit",
+	)
+}
+
+func (v *SSLView) StatusText() string {
+	if len(v.chain) == 0 {
+		return v.domain
+	}
+	return fmt.Sprintf("%s: %d certificates in chain", v.domain, len(v.chain))
+}
+
+// issuerName prefers the issuer's common name and falls back to its
+// organization, which is all some intermediates carry.
+func issuerName(c *x509.Certificate) string {
+	if c.Issuer.CommonName != "" {
+		return c.Issuer.CommonName
+	}
+	return strings.Join(c.Issuer.Organization, ", ")
+}
+
+// notBeforeStyle flags a certificate that isn't valid yet.
+func notBeforeStyle(c *x509.Certificate, now time.Time) lipgloss.Style {
+	if now.Before(c.NotBefore) {
+		return styles.ErrorStyle
+	}
+	return styles.ValueStyle
+}
@@ -5,20 +5,55 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/bc/porkbun-tui/internal/api"
 	"github.com/bc/porkbun-tui/internal/keys"
+	"github.com/bc/porkbun-tui/internal/portfolio"
 	"github.com/bc/porkbun-tui/internal/styles"
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
 
-type TLDGroup struct {
+type TLDGroup = portfolio.TLDTotal
+
+type TLDViewMode int
+
+const (
+	TLDModeOwned TLDViewMode = iota
+	TLDModeExplorer
+	TLDModeForecast
+)
+
+type PricingSortField int
+
+const (
+	PricingSortTLD PricingSortField = iota
+	PricingSortRegistration
+	PricingSortRenewal
+	PricingSortTransfer
+)
+
+// A TLD is a promo trap when its renewal is at least promoTrapRatio times
+// the first-year registration and the difference is at least
+// promoTrapMinDelta — the ratio alone would flag a $1 → $2 TLD nobody cares
+// about.
+const (
+	promoTrapRatio    = 2.0
+	promoTrapMinDelta = 5.0
+)
+
+// PricingRow is one TLD in the pricing explorer. Prices that are missing or
+// unparsable are zero.
+type PricingRow struct {
 	TLD          string
-	Domains      []api.Domain
-	RenewalPrice float64
-	TotalCost    float64
+	Registration float64
+	Renewal      float64
+	Transfer     float64
+	PromoTrap    bool
+	Owned        bool
 }
 
 type TLDView struct {
@@ -28,48 +63,190 @@ type TLDView struct {
 	height   int
 	width    int
 	expanded map[string]bool
+
+	// Pricing explorer: every TLD Porkbun sells, not just the ones we hold.
+	mode        TLDViewMode
+	pricingRows []PricingRow
+	explored    []PricingRow // pricingRows after search and trap filters
+	exCursor    int
+	exOffset    int
+	exSort      PricingSortField
+	exAscending bool
+	trapsOnly   bool
+	searchInput textinput.Model
+	searching   bool
+
+	// Renewal forecast over the next fcYears years.
+	domains    []api.Domain
+	pricing    map[string]api.TLDPricing
+	forecast   *portfolio.Forecast
+	fcYears    int
+	fcGrouping portfolio.Grouping
+	fcOffset   int
+	// exportRequested is the one-shot edge for the app to write the CSV.
+	exportRequested bool
+	exported        string
+	exportErr       error
 }
 
 func NewTLDView() *TLDView {
+	ti := textinput.New()
+	ti.Placeholder = "filter TLDs..."
+	ti.Prompt = ""
+	ti.CharLimit = 30
+	ti.Width = 20
+
 	return &TLDView{
-		expanded: make(map[string]bool),
+		expanded:    make(map[string]bool),
+		exSort:      PricingSortTLD,
+		exAscending: true,
+		searchInput: ti,
+		fcYears:     1,
+		fcGrouping:  portfolio.ByTLD,
 	}
 }
 
 func (v *TLDView) SetData(domains []api.Domain, pricing map[string]api.TLDPricing) {
-	// Group domains by TLD
-	groupMap := make(map[string][]api.Domain)
-	for _, d := range domains {
-		groupMap[d.TLD] = append(groupMap[d.TLD], d)
-	}
-
-	// Convert to slice and calculate costs
-	v.groups = make([]TLDGroup, 0, len(groupMap))
-	for tld, doms := range groupMap {
-		var renewalPrice float64
-		if p, ok := pricing[tld]; ok {
-			renewalPrice, _ = strconv.ParseFloat(p.Renewal, 64)
-		}
+	v.domains = domains
+	v.pricing = pricing
+	v.rebuildForecast()
 
-		v.groups = append(v.groups, TLDGroup{
+	v.groups = portfolio.CostByTLD(domains, pricing)
+	v.cursor = 0
+	v.offset = 0
+
+	owned := make(map[string]bool, len(v.groups))
+	for _, g := range v.groups {
+		owned[g.TLD] = true
+	}
+	v.setPricingRows(owned, pricing)
+}
+
+func (v *TLDView) setPricingRows(owned map[string]bool, pricing map[string]api.TLDPricing) {
+	v.pricingRows = make([]PricingRow, 0, len(pricing))
+	for tld, p := range pricing {
+		reg, _ := strconv.ParseFloat(p.Registration, 64)
+		renew, _ := strconv.ParseFloat(p.Renewal, 64)
+		transfer, _ := strconv.ParseFloat(p.Transfer, 64)
+		v.pricingRows = append(v.pricingRows, PricingRow{
 			TLD:          tld,
-			Domains:      doms,
-			RenewalPrice: renewalPrice,
-			TotalCost:    renewalPrice * float64(len(doms)),
+			Registration: reg,
+			Renewal:      renew,
+			Transfer:     transfer,
+			PromoTrap:    isPromoTrap(reg, renew),
+			Owned:        owned[tld],
 		})
 	}
+	v.applyPricingFilter()
+}
+
+func isPromoTrap(registration, renewal float64) bool {
+	return registration > 0 &&
+		renewal >= registration*promoTrapRatio &&
+		renewal-registration >= promoTrapMinDelta
+}
+
+// applyPricingFilter rebuilds the explorer list from the search query and
+// trap filter, then re-sorts and clamps the scroll position.
+func (v *TLDView) applyPricingFilter() {
+	query := strings.TrimPrefix(strings.ToLower(strings.TrimSpace(v.searchInput.Value())), ".")
+	v.explored = v.explored[:0]
+	for _, r := range v.pricingRows {
+		if query != "" && !strings.Contains(r.TLD, query) {
+			continue
+		}
+		if v.trapsOnly && !r.PromoTrap {
+			continue
+		}
+		v.explored = append(v.explored, r)
+	}
+	v.sortPricing()
+
+	if v.exCursor >= len(v.explored) {
+		v.exCursor = max(0, len(v.explored)-1)
+	}
+	if v.exOffset > v.exCursor {
+		v.exOffset = v.exCursor
+	}
+}
 
-	// Sort by total cost (highest first); tie-break on TLD so the order is
-	// deterministic even when prices are missing (groups come from a map).
-	sort.Slice(v.groups, func(i, j int) bool {
-		if v.groups[i].TotalCost != v.groups[j].TotalCost {
-			return v.groups[i].TotalCost > v.groups[j].TotalCost
+func (v *TLDView) sortPricing() {
+	sort.SliceStable(v.explored, func(i, j int) bool {
+		a, b := v.explored[i], v.explored[j]
+		var ka, kb float64
+		switch v.exSort {
+		case PricingSortRegistration:
+			ka, kb = a.Registration, b.Registration
+		case PricingSortRenewal:
+			ka, kb = a.Renewal, b.Renewal
+		case PricingSortTransfer:
+			ka, kb = a.Transfer, b.Transfer
+		default:
+			if v.exAscending {
+				return a.TLD < b.TLD
+			}
+			return a.TLD > b.TLD
+		}
+		if ka == kb {
+			return a.TLD < b.TLD // deterministic: pricing comes from a map
+		}
+		if v.exAscending {
+			return ka < kb
 		}
-		return v.groups[i].TLD < v.groups[j].TLD
+		return ka > kb
 	})
+}
 
-	v.cursor = 0
-	v.offset = 0
+func (v *TLDView) setPricingSort(field PricingSortField) {
+	if v.exSort == field {
+		v.exAscending = !v.exAscending
+	} else {
+		v.exSort = field
+		v.exAscending = true
+	}
+	v.sortPricing()
+}
+
+func (v *TLDView) rebuildForecast() {
+	v.forecast = portfolio.NewForecast(v.domains, v.pricing, time.Now(), v.fcYears)
+	v.fcOffset = 0
+}
+
+// Forecast returns the renewal forecast currently shown.
+func (v *TLDView) Forecast() *portfolio.Forecast {
+	return v.forecast
+}
+
+// ForecastGrouping returns the breakdown currently shown in the forecast.
+func (v *TLDView) ForecastGrouping() portfolio.Grouping {
+	return v.fcGrouping
+}
+
+// TakeExportRequest returns true exactly once per CSV export keypress.
+func (v *TLDView) TakeExportRequest() bool {
+	if v.exportRequested {
+		v.exportRequested = false
+		return true
+	}
+	return false
+}
+
+// SetExportResult records where the forecast CSV was written, or why not.
+func (v *TLDView) SetExportResult(path string, err error) {
+	v.exported = path
+	v.exportErr = err
+}
+
+// Mode reports whether the view shows the owned-TLD breakdown or the
+// pricing explorer.
+func (v *TLDView) Mode() TLDViewMode {
+	return v.mode
+}
+
+// IsSearching reports whether the explorer's filter input is focused; the
+// app must not let global key bindings (q, ?) steal printable keys.
+func (v *TLDView) IsSearching() bool {
+	return v.searching
 }
 
 func (v *TLDView) SetSize(width, height int) {
@@ -126,9 +303,21 @@ func (v *TLDView) adjustOffset() {
 }
 
 func (v *TLDView) Update(msg tea.Msg) (*TLDView, tea.Cmd) {
+	switch v.mode {
+	case TLDModeExplorer:
+		return v.updateExplorer(msg)
+	case TLDModeForecast:
+		return v.updateForecast(msg)
+	}
+
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch {
+		case msg.String() == "p":
+			v.mode = TLDModeExplorer
+		case msg.String() == "f":
+			v.mode = TLDModeForecast
+			v.exported, v.exportErr = "", nil
 		case key.Matches(msg, keys.Keys.Up):
 			if v.cursor > 0 {
 				v.cursor--
@@ -150,7 +339,104 @@ func (v *TLDView) Update(msg tea.Msg) (*TLDView, tea.Cmd) {
 	return v, nil
 }
 
+func (v *TLDView) updateExplorer(msg tea.Msg) (*TLDView, tea.Cmd) {
+	if v.searching {
+		if msg, ok := msg.(tea.KeyMsg); ok {
+			switch msg.String() {
+			case "enter", "esc":
+				v.searching = false
+				v.searchInput.Blur()
+				return v, nil
+			}
+		}
+		var cmd tea.Cmd
+		v.searchInput, cmd = v.searchInput.Update(msg)
+		v.applyPricingFilter()
+		return v, cmd
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case msg.String() == "p":
+			v.mode = TLDModeOwned
+		case key.Matches(msg, keys.Keys.Up):
+			if v.exCursor > 0 {
+				v.exCursor--
+				if v.exCursor < v.exOffset {
+					v.exOffset = v.exCursor
+				}
+			}
+		case key.Matches(msg, keys.Keys.Down):
+			if v.exCursor < len(v.explored)-1 {
+				v.exCursor++
+				if v.exCursor >= v.exOffset+v.height {
+					v.exOffset = v.exCursor - v.height + 1
+				}
+			}
+		case key.Matches(msg, keys.Keys.Search):
+			v.searching = true
+			v.searchInput.Focus()
+			return v, textinput.Blink
+		case msg.String() == "!":
+			v.trapsOnly = !v.trapsOnly
+			v.applyPricingFilter()
+		case msg.String() == "1":
+			v.setPricingSort(PricingSortTLD)
+		case msg.String() == "2":
+			v.setPricingSort(PricingSortRegistration)
+		case msg.String() == "3":
+			v.setPricingSort(PricingSortRenewal)
+		case msg.String() == "4":
+			v.setPricingSort(PricingSortTransfer)
+		}
+	}
+	return v, nil
+}
+
+func (v *TLDView) updateForecast(msg tea.Msg) (*TLDView, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case msg.String() == "f":
+			v.mode = TLDModeOwned
+		case key.Matches(msg, keys.Keys.Up):
+			if v.fcOffset > 0 {
+				v.fcOffset--
+			}
+		case key.Matches(msg, keys.Keys.Down):
+			if v.fcOffset < len(v.forecastLines())-v.forecastTableHeight() {
+				v.fcOffset++
+			}
+		case msg.String() == "+", msg.String() == "=":
+			if v.fcYears < portfolio.MaxForecastYears {
+				v.fcYears++
+				v.rebuildForecast()
+			}
+		case msg.String() == "-":
+			if v.fcYears > portfolio.MinForecastYears {
+				v.fcYears--
+				v.rebuildForecast()
+			}
+		case msg.String() == "g":
+			v.fcGrouping = v.nextGrouping()
+			v.fcOffset = 0
+		case msg.String() == "x":
+			v.exportRequested = true
+			v.exported, v.exportErr = "", nil
+		}
+	}
+	return v, nil
+}
+
 func (v *TLDView) View() string {
+	switch v.mode {
+	case TLDModeExplorer:
+		return v.explorerView()
+	case TLDModeForecast:
+		return v.forecastView()
+	}
+
 	var b strings.Builder
 
 	// Title
@@ -256,12 +542,254 @@ func (v *TLDView) View() string {
 	return b.String()
 }
 
+func (v *TLDView) explorerView() string {
+	var b strings.Builder
+
+	title := styles.TitleStyle.Render(" TLD Pricing Explorer ")
+	b.WriteString(title)
+	b.WriteString("\n\n")
+
+	if v.searching {
+		b.WriteString("  Search: ")
+		b.WriteString(v.searchInput.View())
+		b.WriteString("\n")
+	} else if v.searchInput.Value() != "" {
+		b.WriteString(styles.HelpStyle.Render(fmt.Sprintf("  Filter: \"%s\" (/ to edit)", v.searchInput.Value())))
+		b.WriteString("\n")
+	}
+	if v.trapsOnly {
+		b.WriteString(styles.PremiumStyle.Render("  Showing promo traps only (! to show all)"))
+		b.WriteString("\n")
+	}
+
+	if len(v.pricingRows) == 0 {
+		b.WriteString("  No pricing data loaded yet.")
+		return b.String()
+	}
+	if len(v.explored) == 0 {
+		b.WriteString("  No TLDs match.")
+		return b.String()
+	}
+
+	tldWidth := 14
+	priceWidth := 12
+
+	header := fmt.Sprintf("  %-*s  %*s  %*s  %*s  %s",
+		tldWidth, v.pricingSortIndicator("TLD", PricingSortTLD),
+		priceWidth, v.pricingSortIndicator("Register", PricingSortRegistration),
+		priceWidth, v.pricingSortIndicator("Renew", PricingSortRenewal),
+		priceWidth, v.pricingSortIndicator("Transfer", PricingSortTransfer),
+		"Notes",
+	)
+	b.WriteString(styles.TableHeaderStyle.Render(header))
+	b.WriteString("\n")
+
+	visibleEnd := min(v.exOffset+v.height, len(v.explored))
+	for i := v.exOffset; i < visibleEnd; i++ {
+		r := v.explored[i]
+
+		tld := "." + r.TLD
+		if r.Owned {
+			tld += " •"
+		}
+
+		row := fmt.Sprintf("  %-*s  %s  %s  %s  ",
+			tldWidth, truncate(tld, tldWidth),
+			formatPrice(r.Registration, priceWidth),
+			formatPrice(r.Renewal, priceWidth),
+			formatPrice(r.Transfer, priceWidth),
+		)
+
+		var note string
+		if r.PromoTrap {
+			note = fmt.Sprintf("promo trap: renews at %.1fx", r.Renewal/r.Registration)
+		}
+
+		if i == v.exCursor {
+			row = styles.TableSelectedStyle.Render(row + note)
+		} else if note != "" {
+			row += styles.PremiumStyle.Render(note)
+		}
+
+		b.WriteString(row)
+		b.WriteString("\n")
+	}
+
+	if len(v.explored) > v.height {
+		scrollInfo := fmt.Sprintf(" %d-%d of %d ", v.exOffset+1, visibleEnd, len(v.explored))
+		b.WriteString(styles.HelpStyle.Render(scrollInfo))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(styles.HelpStyle.Render("  • = a TLD you already hold"))
+
+	return b.String()
+}
+
+func (v *TLDView) forecastView() string {
+	var b strings.Builder
+
+	years := "year"
+	if v.fcYears > 1 {
+		years = fmt.Sprintf("%d years", v.fcYears)
+	}
+	title := styles.TitleStyle.Render(fmt.Sprintf(" Renewal Forecast: next %s ", years))
+	b.WriteString(title)
+	b.WriteString("\n\n")
+
+	f := v.forecast
+	if f == nil || len(f.Renewals) == 0 {
+		b.WriteString("  No renewals in this period.")
+		return b.String()
+	}
+
+	for i, y := range f.ByYear {
+		line := fmt.Sprintf("  Year %d (from %s)  %4d renewals  %10s",
+			i+1, y.Start.Format("2006-01-02"), y.Renewals, fmt.Sprintf("$%.2f", y.Cost))
+		b.WriteString(styles.ValueStyle.Render(line))
+		b.WriteString("\n")
+	}
+	if f.Unpriced > 0 {
+		b.WriteString(styles.PremiumStyle.Render(fmt.Sprintf("  %d renewals have no pricing and are counted as $0", f.Unpriced)))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	lines := v.forecastLines()
+	visibleEnd := min(v.fcOffset+v.forecastTableHeight(), len(lines))
+	for i := v.fcOffset; i < visibleEnd; i++ {
+		b.WriteString(lines[i])
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(styles.ValueStyle.Render(fmt.Sprintf("  Total: %d renewals, $%.2f", len(f.Renewals), f.Total)))
+
+	if v.exportErr != nil {
+		b.WriteString("\n")
+		b.WriteString(styles.ErrorStyle.Render(fmt.Sprintf("  Export failed: %v", v.exportErr)))
+	} else if v.exported != "" {
+		b.WriteString("\n")
+		b.WriteString(styles.SuccessStyle.Render("  Exported " + v.exported))
+	}
+
+	return b.String()
+}
+
+// forecastTableHeight is the viewport left for the breakdown table below
+// the per-year summary.
+func (v *TLDView) forecastTableHeight() int {
+	if v.forecast == nil {
+		return v.height
+	}
+	return max(1, v.height-len(v.forecast.ByYear)-2)
+}
+
+// forecastLines renders the month, TLD or label breakdown table, header
+// first, for the scrollable part of the forecast.
+func (v *TLDView) forecastLines() []string {
+	f := v.forecast
+	if f == nil {
+		return nil
+	}
+
+	var lines []string
+	if v.fcGrouping == portfolio.ByMonth {
+		header := fmt.Sprintf("  %-10s  %8s  %10s", "Month", "Renewals", "Cost")
+		lines = append(lines, styles.TableHeaderStyle.Render(header))
+		for _, m := range f.Months {
+			lines = append(lines, fmt.Sprintf("  %-10s  %8d  %10s",
+				m.Start.Format("Jan 2006"), m.Renewals, fmt.Sprintf("$%.2f", m.Cost)))
+		}
+		return lines
+	}
+
+	keyWidth := 16
+	header := fmt.Sprintf("  %-*s  %7s", keyWidth, strings.ToUpper(v.fcGrouping.String()), "Domains")
+	for i := range f.ByYear {
+		header += fmt.Sprintf("  %10s", fmt.Sprintf("Year %d", i+1))
+	}
+	header += fmt.Sprintf("  %10s", "Total")
+	lines = append(lines, styles.TableHeaderStyle.Render(header))
+
+	for _, g := range f.Groups(v.fcGrouping) {
+		row := fmt.Sprintf("  %-*s  %7d", keyWidth, truncate(g.Key, keyWidth), g.Domains)
+		for _, y := range g.Years {
+			row += fmt.Sprintf("  %10s", fmt.Sprintf("$%.2f", y))
+		}
+		row += fmt.Sprintf("  %10s", fmt.Sprintf("$%.2f", g.Total))
+		lines = append(lines, row)
+	}
+	return lines
+}
+
+func formatPrice(price float64, width int) string {
+	if price <= 0 {
+		return fmt.Sprintf("%*s", width, "N/A")
+	}
+	return fmt.Sprintf("%*s", width, fmt.Sprintf("$%.2f", price))
+}
+
+func (v *TLDView) pricingSortIndicator(label string, field PricingSortField) string {
+	if v.exSort != field {
+		return label
+	}
+	arrow := "▲"
+	if !v.exAscending {
+		arrow = "▼"
+	}
+	return label + " " + arrow
+}
+
 func (v *TLDView) HelpText() string {
+	if v.mode == TLDModeForecast {
+		return lipgloss.JoinHorizontal(lipgloss.Top,
+			styles.HelpStyle.Render("j/k"),
+			" scroll  ",
+			styles.HelpStyle.Render("+/-"),
+			" years  ",
+			styles.HelpStyle.Render("g"),
+			" group by "+v.nextGrouping().String()+"  ",
+			styles.HelpStyle.Render("x"),
+			" export CSV  ",
+			styles.HelpStyle.Render("f"),
+			" owned TLDs  ",
+			styles.HelpStyle.Render("esc"),
+			" back",
+		)
+	}
+	if v.mode == TLDModeExplorer {
+		if v.searching {
+			return lipgloss.JoinHorizontal(lipgloss.Top,
+				styles.HelpStyle.Render("enter/esc"),
+				" done",
+			)
+		}
+		return lipgloss.JoinHorizontal(lipgloss.Top,
+			styles.HelpStyle.Render("j/k"),
+			" navigate  ",
+			styles.HelpStyle.Render("/"),
+			" search  ",
+			styles.HelpStyle.Render("1-4"),
+			" sort  ",
+			styles.HelpStyle.Render("!"),
+			" promo traps  ",
+			styles.HelpStyle.Render("p"),
+			" owned TLDs  ",
+			styles.HelpStyle.Render("esc"),
+			" back",
+		)
+	}
 	return lipgloss.JoinHorizontal(lipgloss.Top,
 		styles.HelpStyle.Render("j/k"),
 		" navigate  ",
 		styles.HelpStyle.Render("enter"),
 		" expand  ",
+		styles.HelpStyle.Render("p"),
+		" all pricing  ",
+		styles.HelpStyle.Render("f"),
+		" forecast  ",
 		styles.HelpStyle.Render("esc"),
 		" back  ",
 		styles.HelpStyle.Render("q"),
@@ -269,6 +797,22 @@ func (v *TLDView) HelpText() string {
 	)
 }
 
+func (v *TLDView) nextGrouping() portfolio.Grouping {
+	return (v.fcGrouping + 1) % 3
+}
+
 func (v *TLDView) StatusText() string {
+	if v.mode == TLDModeForecast && v.forecast != nil {
+		return fmt.Sprintf("Forecast by %s, $%.2f over %d years", v.fcGrouping, v.forecast.Total, v.forecast.Years)
+	}
+	if v.mode == TLDModeExplorer {
+		traps := 0
+		for _, r := range v.explored {
+			if r.PromoTrap {
+				traps++
+			}
+		}
+		return fmt.Sprintf("%d/%d TLDs, %d promo traps", len(v.explored), len(v.pricingRows), traps)
+	}
 	return fmt.Sprintf("%d TLDs", len(v.groups))
 }
@@ -2,8 +2,10 @@ package views
 
 import (
 	"testing"
+	"time"
 
 	"github.com/bc/porkbun-tui/internal/api"
+	tea "github.com/charmbracelet/bubbletea"
 )
 
 func TestTLDView_SetData_GroupsByTLD(t *testing.T) {
@@ -253,3 +255,157 @@ func TestTLDView_ExpandedMap(t *testing.T) {
 		t.Error("com should be expanded after setting")
 	}
 }
+
+func explorerPricing() map[string]api.TLDPricing {
+	return map[string]api.TLDPricing{
+		"com":   {TLD: "com", Registration: "9.73", Renewal: "10.37", Transfer: "10.37"},
+		"xyz":   {TLD: "xyz", Registration: "2.00", Renewal: "12.00", Transfer: "12.00"},
+		"io":    {TLD: "io", Registration: "32.98", Renewal: "32.98", Transfer: "32.98"},
+		"cheap": {TLD: "cheap", Registration: "1.00", Renewal: "2.50", Transfer: "2.50"},
+	}
+}
+
+func TestTLDView_ExplorerListsAllPricedTLDs(t *testing.T) {
+	v := NewTLDView()
+	v.SetSize(120, 40)
+
+	// Only com is owned, but the explorer must list every priced TLD.
+	v.SetData([]api.Domain{{Name: "a.com", TLD: "com"}}, explorerPricing())
+	v.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
+
+	if v.Mode() != TLDModeExplorer {
+		t.Fatal("p did not switch to the pricing explorer")
+	}
+	if len(v.explored) != 4 {
+		t.Fatalf("explorer lists %d TLDs, want 4", len(v.explored))
+	}
+	for _, r := range v.explored {
+		if r.Owned != (r.TLD == "com") {
+			t.Errorf("%s: Owned = %v", r.TLD, r.Owned)
+		}
+	}
+}
+
+func TestTLDView_ExplorerWorksWithoutDomains(t *testing.T) {
+	v := NewTLDView()
+	v.SetData(nil, explorerPricing())
+
+	if len(v.explored) != 4 {
+		t.Errorf("explorer lists %d TLDs with no owned domains, want 4", len(v.explored))
+	}
+}
+
+func TestTLDView_ExplorerSortsByEachColumn(t *testing.T) {
+	v := NewTLDView()
+	v.SetData(nil, explorerPricing())
+	v.mode = TLDModeExplorer
+
+	v.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("3")}) // renewal ascending
+	if got := v.explored[0].TLD; got != "cheap" {
+		t.Errorf("cheapest renewal first = %q, want cheap", got)
+	}
+
+	v.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("3")}) // renewal descending
+	if got := v.explored[0].TLD; got != "io" {
+		t.Errorf("priciest renewal first = %q, want io", got)
+	}
+
+	v.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("2")}) // registration ascending
+	if got := v.explored[0].TLD; got != "cheap" {
+		t.Errorf("cheapest registration first = %q, want cheap", got)
+	}
+
+	v.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("1")}) // TLD ascending
+	if got := v.explored[0].TLD; got != "cheap" {
+		t.Errorf("alphabetical first = %q, want cheap", got)
+	}
+}
+
+func TestTLDView_ExplorerSearchFilters(t *testing.T) {
+	v := NewTLDView()
+	v.SetData(nil, explorerPricing())
+	v.mode = TLDModeExplorer
+
+	v.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	if !v.IsSearching() {
+		t.Fatal("/ did not start a search")
+	}
+	for _, r := range ".x" {
+		v.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+
+	if len(v.explored) != 1 || v.explored[0].TLD != "xyz" {
+		t.Errorf("search \".x\" matched %+v, want only xyz", v.explored)
+	}
+}
+
+func TestTLDView_ExplorerFlagsPromoTraps(t *testing.T) {
+	v := NewTLDView()
+	v.SetData(nil, explorerPricing())
+	v.mode = TLDModeExplorer
+
+	traps := map[string]bool{}
+	for _, r := range v.explored {
+		traps[r.TLD] = r.PromoTrap
+	}
+	if !traps["xyz"] {
+		t.Error("xyz ($2 → $12) not flagged as a promo trap")
+	}
+	if traps["com"] || traps["io"] {
+		t.Error("flat-priced TLD flagged as a promo trap")
+	}
+	if traps["cheap"] {
+		t.Error("cheap ($1 → $2.50) flagged; the increase is too small to matter")
+	}
+
+	v.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("!")})
+	if len(v.explored) != 1 || v.explored[0].TLD != "xyz" {
+		t.Errorf("traps-only filter shows %+v, want only xyz", v.explored)
+	}
+}
+
+func TestTLDView_ForecastModeAdjustsYearsAndGrouping(t *testing.T) {
+	v := NewTLDView()
+	v.SetSize(120, 40)
+	v.SetData([]api.Domain{
+		{Name: "a.com", TLD: "com", ExpireDate: time.Now().AddDate(0, 2, 0)},
+	}, map[string]api.TLDPricing{"com": {Renewal: "10.00"}})
+
+	v.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("f")})
+	if v.Mode() != TLDModeForecast {
+		t.Fatal("f did not open the forecast")
+	}
+	if got := v.Forecast().Total; got != 10 {
+		t.Errorf("one-year total = %.2f, want 10.00", got)
+	}
+
+	v.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("+")})
+	v.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("+")})
+	if got := v.Forecast().Years; got != 3 {
+		t.Errorf("years = %d after two +, want 3", got)
+	}
+	if got := v.Forecast().Total; got != 30 {
+		t.Errorf("three-year total = %.2f, want 30.00", got)
+	}
+
+	before := v.ForecastGrouping()
+	v.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("g")})
+	if v.ForecastGrouping() == before {
+		t.Error("g did not change the grouping")
+	}
+}
+
+func TestTLDView_ForecastExportRequestIsEdgeTriggered(t *testing.T) {
+	v := NewTLDView()
+	v.SetData(nil, nil)
+	v.mode = TLDModeForecast
+
+	v.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+
+	if !v.TakeExportRequest() {
+		t.Fatal("x did not request an export")
+	}
+	if v.TakeExportRequest() {
+		t.Error("export request fired twice for one keypress")
+	}
+}
@@ -0,0 +1,99 @@
+package watch
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// DedupeFile is the dedupe state's file name in the cache directory.
+const DedupeFile = "watch-sent.json"
+
+// Dedupe remembers when each event key was last delivered so a repeat
+// within the window is suppressed. The state survives restarts, so a
+// restarted daemon doesn't page again for what it already reported.
+type Dedupe struct {
+	mu     sync.Mutex
+	path   string // empty: in memory only
+	window time.Duration
+	sent   map[string]time.Time
+}
+
+// LoadDedupe reads the state at path. A missing file is an empty state;
+// an empty path keeps the state in memory.
+func LoadDedupe(path string, window time.Duration) (*Dedupe, error) {
+	d := &Dedupe{path: path, window: window, sent: make(map[string]time.Time)}
+	if path == "" {
+		return d, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return d, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &d.sent); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// ShouldSend reports whether key was not delivered within the window.
+func (d *Dedupe) ShouldSend(key string, now time.Time) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	last, ok := d.sent[key]
+	return !ok || now.Sub(last) >= d.window
+}
+
+// MarkSent records a delivery of key at now.
+func (d *Dedupe) MarkSent(key string, now time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.sent[key] = now
+}
+
+// Save drops entries older than the window and writes the rest. The file
+// is replaced atomically so a crash mid-write can't lose the state.
+func (d *Dedupe) Save(now time.Time) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for key, last := range d.sent {
+		if now.Sub(last) >= d.window {
+			delete(d.sent, key)
+		}
+	}
+	if d.path == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(d.sent, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(d.path, data)
+}
+
+// writeFileAtomic replaces path with data through a temporary file in the
+// same directory, so a crash mid-write leaves the old file intact.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+"-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,238 @@
+package watch
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bc/porkbun-tui/internal/alerts"
+	"github.com/bc/porkbun-tui/internal/api"
+	"github.com/bc/porkbun-tui/internal/portfolio"
+)
+
+// Event types, as sent in the "type" field.
+const (
+	EventAlert          = "alert"
+	EventDomainAdded    = "domain.added"
+	EventDomainRemoved  = "domain.removed"
+	EventDomainChanged  = "domain.changed"
+	EventPricingChanged = "pricing.changed"
+	EventDNSChanged     = "dns.changed"
+)
+
+// Event is the JSON body POSTed to webhooks.
+type Event struct {
+	Type       string    `json:"type"`
+	Domain     string    `json:"domain,omitempty"`
+	Severity   string    `json:"severity,omitempty"`
+	Rule       string    `json:"rule,omitempty"`
+	ExpireDate string    `json:"expire_date,omitempty"`
+	Message    string    `json:"message"`
+	Time       time.Time `json:"time"`
+
+	// Key identifies the event for deduplication: the same key within
+	// the dedupe window is delivered once.
+	Key string `json:"-"`
+}
+
+// AlertEvents is one event per rule a domain trips. The key includes the
+// expiration date, so a renewed domain that trips the rule again later
+// alerts again instead of being suppressed as a repeat.
+func AlertEvents(hit alerts.Hit, now time.Time) []Event {
+	d := hit.Domain
+	expires := d.ExpireDate.Format("2006-01-02")
+	days := int(d.ExpireDate.Sub(now).Hours() / 24)
+
+	events := make([]Event, 0, len(hit.Rules))
+	for _, r := range hit.Rules {
+		events = append(events, Event{
+			Type:       EventAlert,
+			Domain:     d.Name,
+			Severity:   r.Severity.String(),
+			Rule:       r.String(),
+			ExpireDate: expires,
+			Message:    fmt.Sprintf("%s expires %s (in %d days): %s", d.Name, expires, days, r),
+			Time:       now,
+			Key:        fmt.Sprintf("alert:%s:%s:%s", d.Name, r, expires),
+		})
+	}
+	return events
+}
+
+// DiffDomains reports domains added, removed, or changed between two
+// snapshots of the domain list.
+func DiffDomains(prev, cur []api.Domain, now time.Time) []Event {
+	before := make(map[string]api.Domain, len(prev))
+	for _, d := range prev {
+		before[d.Name] = d
+	}
+
+	var events []Event
+	seen := make(map[string]bool, len(cur))
+	for _, d := range cur {
+		seen[d.Name] = true
+		old, ok := before[d.Name]
+		if !ok {
+			events = append(events, Event{
+				Type:       EventDomainAdded,
+				Domain:     d.Name,
+				ExpireDate: d.ExpireDate.Format("2006-01-02"),
+				Message:    d.Name + " added to the account",
+				Time:       now,
+				Key:        "added:" + d.Name,
+			})
+			continue
+		}
+		for _, c := range domainChanges(old, d) {
+			events = append(events, Event{
+				Type:       EventDomainChanged,
+				Domain:     d.Name,
+				ExpireDate: d.ExpireDate.Format("2006-01-02"),
+				Message:    d.Name + ": " + c.message,
+				Time:       now,
+				Key:        fmt.Sprintf("changed:%s:%s:%s", d.Name, c.field, c.value),
+			})
+		}
+	}
+
+	for _, d := range prev {
+		if !seen[d.Name] {
+			events = append(events, Event{
+				Type:    EventDomainRemoved,
+				Domain:  d.Name,
+				Message: d.Name + " no longer in the account",
+				Time:    now,
+				Key:     "removed:" + d.Name,
+			})
+		}
+	}
+	return events
+}
+
+type domainChange struct {
+	field, value, message string
+}
+
+func domainChanges(old, cur api.Domain) []domainChange {
+	onOff := func(b bool) string {
+		if b {
+			return "on"
+		}
+		return "off"
+	}
+
+	var changes []domainChange
+	if !old.ExpireDate.Equal(cur.ExpireDate) {
+		date := cur.ExpireDate.Format("2006-01-02")
+		changes = append(changes, domainChange{"expires", date,
+			fmt.Sprintf("expiration moved from %s to %s", old.ExpireDate.Format("2006-01-02"), date)})
+	}
+	if old.AutoRenew != cur.AutoRenew {
+		changes = append(changes, domainChange{"auto_renew", onOff(cur.AutoRenew), "auto-renew turned " + onOff(cur.AutoRenew)})
+	}
+	if old.SecurityLock != cur.SecurityLock {
+		changes = append(changes, domainChange{"security_lock", onOff(cur.SecurityLock), "security lock turned " + onOff(cur.SecurityLock)})
+	}
+	if old.WhoisPrivacy != cur.WhoisPrivacy {
+		changes = append(changes, domainChange{"whois_privacy", onOff(cur.WhoisPrivacy), "WHOIS privacy turned " + onOff(cur.WhoisPrivacy)})
+	}
+	if old.Status != cur.Status {
+		changes = append(changes, domainChange{"status", cur.Status, fmt.Sprintf("status changed from %q to %q", old.Status, cur.Status)})
+	}
+	return changes
+}
+
+// DiffRenewalPrices reports renewal price changes for TLDs the account
+// owns domains in; price moves on other TLDs are noise here.
+func DiffRenewalPrices(prev, cur map[string]api.TLDPricing, domains []api.Domain, now time.Time) []Event {
+	owned := make(map[string]int)
+	for _, d := range domains {
+		owned[d.TLD]++
+	}
+	tlds := make([]string, 0, len(owned))
+	for tld := range owned {
+		tlds = append(tlds, tld)
+	}
+	sort.Strings(tlds)
+
+	var events []Event
+	for _, tld := range tlds {
+		before, after := portfolio.RenewalPrice(prev, tld), portfolio.RenewalPrice(cur, tld)
+		if before == 0 || after == 0 || before == after {
+			continue
+		}
+		events = append(events, Event{
+			Type:    EventPricingChanged,
+			Message: fmt.Sprintf(".%s renewal price changed from $%.2f to $%.2f (%d domains)", tld, before, after, owned[tld]),
+			Time:    now,
+			Key:     fmt.Sprintf("pricing:%s:%.2f", tld, after),
+		})
+	}
+	return events
+}
+
+// DiffDNS reports whether a domain's DNS records changed, summarizing the
+// added and removed records. Record IDs are ignored: an edit that leaves
+// the record identical is not a change.
+func DiffDNS(domain string, prev, cur []api.DNSRecord, now time.Time) (Event, bool) {
+	before := recordSet(prev)
+	after := recordSet(cur)
+
+	var added, removed []string
+	for sig := range after {
+		if !before[sig] {
+			added = append(added, sig)
+		}
+	}
+	for sig := range before {
+		if !after[sig] {
+			removed = append(removed, sig)
+		}
+	}
+	if len(added) == 0 && len(removed) == 0 {
+		return Event{}, false
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "%s DNS changed: %d added, %d removed", domain, len(added), len(removed))
+	for _, sig := range added {
+		msg.WriteString("\n+ " + sig)
+	}
+	for _, sig := range removed {
+		msg.WriteString("\n- " + sig)
+	}
+
+	// Keyed on the resulting record set, so flapping back to an earlier
+	// state within the window is reported once.
+	all := make([]string, 0, len(after))
+	for sig := range after {
+		all = append(all, sig)
+	}
+	sort.Strings(all)
+	sum := sha256.Sum256([]byte(strings.Join(all, "\n")))
+
+	return Event{
+		Type:    EventDNSChanged,
+		Domain:  domain,
+		Message: msg.String(),
+		Time:    now,
+		Key:     "dns:" + domain + ":" + hex.EncodeToString(sum[:8]),
+	}, true
+}
+
+func recordSet(records []api.DNSRecord) map[string]bool {
+	set := make(map[string]bool, len(records))
+	for _, r := range records {
+		sig := fmt.Sprintf("%s %s %s ttl=%s", r.Name, r.Type, r.Content, r.TTL)
+		if r.Priority != "" && r.Priority != "0" {
+			sig += " prio=" + r.Priority
+		}
+		set[sig] = true
+	}
+	return set
+}
@@ -0,0 +1,61 @@
+package watch
+
+import (
+	"encoding/json"
+	"os"
+	"time"
+
+	"github.com/bc/porkbun-tui/internal/api"
+)
+
+// SnapshotFile is the watcher's snapshot's file name in the cache
+// directory.
+const SnapshotFile = "watch-snapshot.json"
+
+// Snapshot is what the watcher saw on its last run, the baseline for the
+// next run's diffs. It is kept apart from the shared cache: the TUI, serve
+// and the other commands refresh that too, and diffing against it would
+// make their refreshes the baseline and swallow the changes they saw.
+// A zero time means that part was never taken.
+type Snapshot struct {
+	path string // empty: in memory only
+
+	Domains   []api.Domain               `json:"domains"`
+	DomainsAt time.Time                  `json:"domains_at"`
+	Pricing   map[string]api.TLDPricing  `json:"pricing"`
+	PricingAt time.Time                  `json:"pricing_at"`
+	DNS       map[string][]api.DNSRecord `json:"dns"` // by domain; absent: never taken
+}
+
+// LoadSnapshot reads the snapshot at path. A missing file is an empty
+// snapshot; an empty path keeps it in memory.
+func LoadSnapshot(path string) (*Snapshot, error) {
+	s := &Snapshot{path: path}
+	if path == "" {
+		return s, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return s, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Save writes the snapshot, replacing the file atomically.
+func (s *Snapshot) Save() error {
+	if s.path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(s.path, data)
+}
@@ -0,0 +1,205 @@
+// Package watch is the long-running refresh loop behind porkbun-tui watch.
+// Each run refreshes the cache from the API, turns alert rule hits and
+// changes since its own last run into events, and delivers them to
+// notifiers such as webhooks, suppressing repeats within a dedupe window.
+package watch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/bc/porkbun-tui/internal/alerts"
+	"github.com/bc/porkbun-tui/internal/api"
+)
+
+// Source is the slice of api.Client the watcher reads from.
+type Source interface {
+	ListDomains(ctx context.Context) ([]api.Domain, error)
+	GetPricing(ctx context.Context) (map[string]api.TLDPricing, error)
+	GetDNSRecords(ctx context.Context, domain string) ([]api.DNSRecord, error)
+}
+
+// Store is the slice of cache.Cache the watcher refreshes, so the TUI
+// opens on fresh data. Diffs use the Snapshot, not the Store.
+type Store interface {
+	SaveDomains(domains []api.Domain) error
+	SavePricing(pricing map[string]api.TLDPricing) error
+	SaveDNS(domain string, records []api.DNSRecord) error
+}
+
+// Notifier delivers events. ID distinguishes notifiers in the dedupe
+// state, so a new webhook still receives events another one already got.
+type Notifier interface {
+	ID() string
+	Notify(ctx context.Context, e Event) error
+}
+
+// Watcher runs the refresh loop. Source, Store, Snapshot and Dedupe are
+// required.
+type Watcher struct {
+	Source    Source
+	Store     Store
+	Snapshot  *Snapshot
+	Notifiers []Notifier
+	Rules     []alerts.Rule
+	Dedupe    *Dedupe
+	DNS       bool // also snapshot and diff each domain's DNS records
+
+	Now func() time.Time // defaults to time.Now
+	Log *log.Logger      // defaults to log.Default()
+}
+
+// Report summarizes one run.
+type Report struct {
+	Domains    int
+	Events     int
+	Sent       int
+	Suppressed int // already delivered within the dedupe window
+}
+
+func (w *Watcher) now() time.Time {
+	if w.Now != nil {
+		return w.Now()
+	}
+	return time.Now()
+}
+
+func (w *Watcher) logf(format string, args ...any) {
+	if w.Log != nil {
+		w.Log.Printf(format, args...)
+		return
+	}
+	log.Printf(format, args...)
+}
+
+// Run calls RunOnce now and then every interval until ctx is done. Failed
+// runs are logged and retried on the next tick.
+func (w *Watcher) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if report, err := w.RunOnce(ctx); err != nil {
+			w.logf("run failed: %v", err)
+		} else {
+			w.logf("checked %d domains: %d events, %d sent, %d suppressed",
+				report.Domains, report.Events, report.Sent, report.Suppressed)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce refreshes the snapshots and delivers the resulting events.
+// Failing to list domains aborts the run; pricing, DNS and delivery
+// failures are reported but don't stop the rest.
+func (w *Watcher) RunOnce(ctx context.Context) (Report, error) {
+	now := w.now()
+
+	prevDomains, prevAt := w.Snapshot.Domains, w.Snapshot.DomainsAt
+
+	domains, err := w.Source.ListDomains(ctx)
+	if err != nil {
+		return Report{}, fmt.Errorf("listing domains: %w", err)
+	}
+	if err := w.Store.SaveDomains(domains); err != nil {
+		w.logf("saving domains: %v", err)
+	}
+	w.Snapshot.Domains, w.Snapshot.DomainsAt = domains, now
+
+	var events []Event
+	// No diff on the first run: everything would read as added.
+	if !prevAt.IsZero() {
+		events = append(events, DiffDomains(prevDomains, domains, now)...)
+	}
+	events = append(events, w.pricingEvents(ctx, domains, now)...)
+	for _, hit := range alerts.Evaluate(w.Rules, domains, now) {
+		events = append(events, AlertEvents(hit, now)...)
+	}
+	if w.DNS {
+		events = append(events, w.dnsEvents(ctx, domains, now)...)
+	}
+
+	report := Report{Domains: len(domains), Events: len(events)}
+	var errs []error
+	for _, e := range events {
+		for _, n := range w.Notifiers {
+			key := n.ID() + "|" + e.Key
+			if !w.Dedupe.ShouldSend(key, now) {
+				report.Suppressed++
+				continue
+			}
+			if err := n.Notify(ctx, e); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %s %s: %w", n.ID(), e.Type, e.Domain, err))
+				continue
+			}
+			w.Dedupe.MarkSent(key, now)
+			report.Sent++
+		}
+	}
+	if err := w.Snapshot.Save(); err != nil {
+		errs = append(errs, fmt.Errorf("saving snapshot: %w", err))
+	}
+	if err := w.Dedupe.Save(now); err != nil {
+		errs = append(errs, fmt.Errorf("saving dedupe state: %w", err))
+	}
+
+	return report, errors.Join(errs...)
+}
+
+func (w *Watcher) pricingEvents(ctx context.Context, domains []api.Domain, now time.Time) []Event {
+	prev, prevAt := w.Snapshot.Pricing, w.Snapshot.PricingAt
+
+	pricing, err := w.Source.GetPricing(ctx)
+	if err != nil {
+		w.logf("fetching pricing: %v", err)
+		return nil
+	}
+	if err := w.Store.SavePricing(pricing); err != nil {
+		w.logf("saving pricing: %v", err)
+	}
+	w.Snapshot.Pricing, w.Snapshot.PricingAt = pricing, now
+
+	if prevAt.IsZero() {
+		return nil
+	}
+	return DiffRenewalPrices(prev, pricing, domains, now)
+}
+
+func (w *Watcher) dnsEvents(ctx context.Context, domains []api.Domain, now time.Time) []Event {
+	var events []Event
+	// Only the current domains are kept, so a domain that comes back isn't
+	// diffed against records from before it left.
+	snapshot := make(map[string][]api.DNSRecord, len(domains))
+	for _, d := range domains {
+		prev, taken := w.Snapshot.DNS[d.Name]
+
+		records, err := w.Source.GetDNSRecords(ctx, d.Name)
+		if err != nil {
+			w.logf("fetching DNS for %s: %v", d.Name, err)
+			if taken {
+				snapshot[d.Name] = prev
+			}
+			continue
+		}
+		if err := w.Store.SaveDNS(d.Name, records); err != nil {
+			w.logf("saving DNS for %s: %v", d.Name, err)
+		}
+		snapshot[d.Name] = records
+
+		if taken {
+			if e, ok := DiffDNS(d.Name, prev, records, now); ok {
+				events = append(events, e)
+			}
+		}
+	}
+	w.Snapshot.DNS = snapshot
+	return events
+}
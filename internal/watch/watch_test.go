@@ -0,0 +1,346 @@
+package watch
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bc/porkbun-tui/internal/alerts"
+	"github.com/bc/porkbun-tui/internal/api"
+)
+
+var now = time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+type fakeSource struct {
+	domains []api.Domain
+	pricing map[string]api.TLDPricing
+	dns     map[string][]api.DNSRecord
+	err     error
+}
+
+func (s *fakeSource) ListDomains(context.Context) ([]api.Domain, error) {
+	return s.domains, s.err
+}
+
+func (s *fakeSource) GetPricing(context.Context) (map[string]api.TLDPricing, error) {
+	return s.pricing, nil
+}
+
+func (s *fakeSource) GetDNSRecords(_ context.Context, domain string) ([]api.DNSRecord, error) {
+	return s.dns[domain], nil
+}
+
+// fakeStore is an in-memory cache; a zero time means "never saved".
+type fakeStore struct {
+	domains   []api.Domain
+	domainsAt time.Time
+	pricing   map[string]api.TLDPricing
+	pricingAt time.Time
+	dns       map[string][]api.DNSRecord
+}
+
+func (s *fakeStore) SaveDomains(d []api.Domain) error {
+	s.domains, s.domainsAt = d, now
+	return nil
+}
+
+func (s *fakeStore) SavePricing(p map[string]api.TLDPricing) error {
+	s.pricing, s.pricingAt = p, now
+	return nil
+}
+
+func (s *fakeStore) SaveDNS(domain string, records []api.DNSRecord) error {
+	if s.dns == nil {
+		s.dns = make(map[string][]api.DNSRecord)
+	}
+	s.dns[domain] = records
+	return nil
+}
+
+type recorder struct {
+	id     string
+	events []Event
+	err    error
+}
+
+func (r *recorder) ID() string { return r.id }
+
+func (r *recorder) Notify(_ context.Context, e Event) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.events = append(r.events, e)
+	return nil
+}
+
+func (r *recorder) types() []string {
+	var types []string
+	for _, e := range r.events {
+		types = append(types, e.Type+" "+e.Domain)
+	}
+	return types
+}
+
+func newWatcher(t *testing.T, src *fakeSource, store *fakeStore, n ...Notifier) *Watcher {
+	t.Helper()
+	dedupe, err := LoadDedupe("", 24*time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rule, _ := alerts.ExpiresWithin("30d", alerts.Critical)
+	return &Watcher{
+		Source:    src,
+		Store:     store,
+		Snapshot:  &Snapshot{},
+		Notifiers: n,
+		Rules:     []alerts.Rule{rule},
+		Dedupe:    dedupe,
+		Now:       func() time.Time { return now },
+		Log:       testLogger(t),
+	}
+}
+
+func TestRunOnceFirstRunOnlyAlerts(t *testing.T) {
+	src := &fakeSource{domains: []api.Domain{
+		{Name: "soon.com", ExpireDate: now.AddDate(0, 0, 10)},
+		{Name: "later.com", ExpireDate: now.AddDate(1, 0, 0)},
+	}}
+	store := &fakeStore{}
+	rec := &recorder{id: "rec"}
+
+	report, err := newWatcher(t, src, store, rec).RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("RunOnce: %v", err)
+	}
+
+	// No snapshot yet: domains are not "added", only alerts fire.
+	if got := strings.Join(rec.types(), ","); got != "alert soon.com" {
+		t.Errorf("events = %s, want only the soon.com alert", got)
+	}
+	if rec.events[0].Severity != "CRITICAL" {
+		t.Errorf("severity = %q", rec.events[0].Severity)
+	}
+	if report.Sent != 1 || report.Domains != 2 {
+		t.Errorf("report = %+v", report)
+	}
+	if store.domainsAt.IsZero() {
+		t.Error("domains not saved to the store")
+	}
+}
+
+func TestRunOnceDedupesAlertsAcrossRuns(t *testing.T) {
+	src := &fakeSource{domains: []api.Domain{{Name: "soon.com", ExpireDate: now.AddDate(0, 0, 10)}}}
+	rec := &recorder{id: "rec"}
+	w := newWatcher(t, src, &fakeStore{}, rec)
+
+	for i := 0; i < 3; i++ {
+		if _, err := w.RunOnce(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(rec.events) != 1 {
+		t.Errorf("alert delivered %d times in the window, want once", len(rec.events))
+	}
+
+	// A renewal moves the expiry: the same rule tripping later is a new
+	// alert, not a repeat.
+	src.domains[0].ExpireDate = now.AddDate(0, 0, 20)
+	if _, err := w.RunOnce(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(rec.events) < 2 {
+		t.Error("alert for a new expiry date was suppressed")
+	}
+}
+
+func TestRunOnceReportsDiffs(t *testing.T) {
+	snapshot := &Snapshot{
+		Domains: []api.Domain{
+			{Name: "kept.com", AutoRenew: true, ExpireDate: now.AddDate(1, 0, 0)},
+			{Name: "gone.com", ExpireDate: now.AddDate(1, 0, 0)},
+		},
+		DomainsAt: now.Add(-time.Hour),
+	}
+	src := &fakeSource{domains: []api.Domain{
+		{Name: "kept.com", AutoRenew: false, ExpireDate: now.AddDate(1, 0, 0)},
+		{Name: "new.com", ExpireDate: now.AddDate(1, 0, 0)},
+	}}
+	rec := &recorder{id: "rec"}
+	w := newWatcher(t, src, &fakeStore{}, rec)
+	w.Snapshot = snapshot
+
+	if _, err := w.RunOnce(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	got := strings.Join(rec.types(), ",")
+	for _, want := range []string{"domain.changed kept.com", "domain.added new.com", "domain.removed gone.com"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("events %s missing %s", got, want)
+		}
+	}
+	for _, e := range rec.events {
+		if e.Type == EventDomainChanged && !strings.Contains(e.Message, "auto-renew turned off") {
+			t.Errorf("change message = %q", e.Message)
+		}
+	}
+}
+
+func TestRunOnceDNSDiff(t *testing.T) {
+	store := &fakeStore{}
+	src := &fakeSource{
+		domains: []api.Domain{{Name: "a.com", ExpireDate: now.AddDate(1, 0, 0)}},
+		dns: map[string][]api.DNSRecord{
+			"a.com": {{ID: "2", Name: "a.com", Type: "A", Content: "192.0.2.9", TTL: "600"}},
+		},
+	}
+	rec := &recorder{id: "rec"}
+	w := newWatcher(t, src, store, rec)
+	w.Snapshot.DNS = map[string][]api.DNSRecord{
+		"a.com": {{ID: "1", Name: "a.com", Type: "A", Content: "192.0.2.1", TTL: "600"}},
+	}
+	w.DNS = true
+
+	if _, err := w.RunOnce(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(rec.events) != 1 || rec.events[0].Type != EventDNSChanged {
+		t.Fatalf("events = %v, want one dns.changed", rec.types())
+	}
+	msg := rec.events[0].Message
+	if !strings.Contains(msg, "+ a.com A 192.0.2.9") || !strings.Contains(msg, "- a.com A 192.0.2.1") {
+		t.Errorf("DNS diff message = %q", msg)
+	}
+	if got := store.dns["a.com"][0].Content; got != "192.0.2.9" {
+		t.Errorf("cached DNS not refreshed: %s", got)
+	}
+	if got := w.Snapshot.DNS["a.com"][0].Content; got != "192.0.2.9" {
+		t.Errorf("DNS snapshot not refreshed: %s", got)
+	}
+}
+
+func TestRunOnceFailedDeliveryIsRetriedNextRun(t *testing.T) {
+	src := &fakeSource{domains: []api.Domain{{Name: "soon.com", ExpireDate: now.AddDate(0, 0, 10)}}}
+	rec := &recorder{id: "rec", err: errors.New("receiver down")}
+	w := newWatcher(t, src, &fakeStore{}, rec)
+
+	if _, err := w.RunOnce(context.Background()); err == nil {
+		t.Fatal("delivery failure not reported")
+	}
+
+	rec.err = nil
+	if _, err := w.RunOnce(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(rec.events) != 1 {
+		t.Error("a failed delivery was marked sent and never retried")
+	}
+}
+
+func TestRunOnceDedupeIsPerNotifier(t *testing.T) {
+	src := &fakeSource{domains: []api.Domain{{Name: "soon.com", ExpireDate: now.AddDate(0, 0, 10)}}}
+	first := &recorder{id: "first"}
+	w := newWatcher(t, src, &fakeStore{}, first)
+	if _, err := w.RunOnce(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	second := &recorder{id: "second"}
+	w.Notifiers = append(w.Notifiers, second)
+	if _, err := w.RunOnce(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(first.events) != 1 || len(second.events) != 1 {
+		t.Errorf("deliveries = %d, %d; want 1, 1", len(first.events), len(second.events))
+	}
+}
+
+func TestRunOnceListFailureAbortsWithoutOverwritingSnapshot(t *testing.T) {
+	src := &fakeSource{err: errors.New("API down")}
+	w := newWatcher(t, src, &fakeStore{})
+	w.Snapshot.Domains, w.Snapshot.DomainsAt = []api.Domain{{Name: "a.com"}}, now.Add(-time.Hour)
+
+	if _, err := w.RunOnce(context.Background()); err == nil {
+		t.Fatal("list failure not returned")
+	}
+	if len(w.Snapshot.Domains) != 1 {
+		t.Error("snapshot overwritten after a failed fetch")
+	}
+}
+
+func TestRunOnceIgnoresOtherCacheWriters(t *testing.T) {
+	path := filepath.Join(t.TempDir(), SnapshotFile)
+	snapshot, err := LoadSnapshot(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	src := &fakeSource{domains: []api.Domain{{Name: "a.com", AutoRenew: true, ExpireDate: now.AddDate(1, 0, 0)}}}
+	store := &fakeStore{}
+	rec := &recorder{id: "rec"}
+	w := newWatcher(t, src, store, rec)
+	w.Snapshot = snapshot
+	if _, err := w.RunOnce(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	// Between ticks auto-renew goes off, and the TUI refreshes the shared
+	// cache before the watcher (restarted meanwhile) runs again.
+	src.domains = []api.Domain{{Name: "a.com", AutoRenew: false, ExpireDate: now.AddDate(1, 0, 0)}}
+	store.SaveDomains(src.domains)
+	if w.Snapshot, err = LoadSnapshot(path); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.RunOnce(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(rec.types(), ","); got != "domain.changed a.com" {
+		t.Errorf("events = %s, want the change the other writer saw first", got)
+	}
+}
+
+func TestDedupePersistsAndExpires(t *testing.T) {
+	path := filepath.Join(t.TempDir(), DedupeFile)
+
+	d, err := LoadDedupe(path, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.MarkSent("k", now)
+	if err := d.Save(now); err != nil {
+		t.Fatal(err)
+	}
+
+	d, err = LoadDedupe(path, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.ShouldSend("k", now.Add(30*time.Minute)) {
+		t.Error("repeat within the window allowed after a restart")
+	}
+	if !d.ShouldSend("k", now.Add(time.Hour)) {
+		t.Error("repeat after the window suppressed")
+	}
+
+	if err := d.Save(now.Add(2 * time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if len(d.sent) != 0 {
+		t.Error("expired entries not pruned")
+	}
+}
+
+func TestDiffRenewalPricesOnlyOwnedTLDs(t *testing.T) {
+	prev := map[string]api.TLDPricing{"com": {Renewal: "10.00"}, "io": {Renewal: "30.00"}}
+	cur := map[string]api.TLDPricing{"com": {Renewal: "11.50"}, "io": {Renewal: "40.00"}}
+	domains := []api.Domain{{Name: "a.com", TLD: "com"}}
+
+	events := DiffRenewalPrices(prev, cur, domains, now)
+
+	if len(events) != 1 || !strings.Contains(events[0].Message, ".com renewal price changed from $10.00 to $11.50") {
+		t.Errorf("events = %+v", events)
+	}
+}
@@ -0,0 +1,95 @@
+package watch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Webhook defaults.
+const (
+	DefaultRetries = 3
+	DefaultBackoff = 2 * time.Second
+)
+
+// Webhook POSTs each event as JSON to a URL. Network errors, 429 and 5xx
+// responses are retried with exponential backoff; other 4xx responses are
+// the receiver rejecting the event and are not.
+type Webhook struct {
+	URL     string
+	Client  *http.Client
+	Retries int           // attempts after the first
+	Backoff time.Duration // before the first retry, doubling after
+}
+
+// NewWebhook returns a Webhook with the default retry policy.
+func NewWebhook(url string) *Webhook {
+	return &Webhook{
+		URL:     url,
+		Client:  &http.Client{Timeout: 15 * time.Second},
+		Retries: DefaultRetries,
+		Backoff: DefaultBackoff,
+	}
+}
+
+func (w *Webhook) ID() string {
+	return w.URL
+}
+
+func (w *Webhook) Notify(ctx context.Context, e Event) error {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	backoff := w.Backoff
+	var lastErr error
+	for attempt := 0; attempt <= w.Retries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+
+		retry, err := w.post(ctx, body)
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+		if !retry {
+			break
+		}
+	}
+	return lastErr
+}
+
+// post makes one delivery attempt and reports whether a failure is worth
+// retrying.
+func (w *Webhook) post(ctx context.Context, body []byte) (retry bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "porkbun-tui-watch")
+
+	resp, err := w.Client.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10)) // drain for connection reuse
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	retry = resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+	return retry, fmt.Errorf("webhook returned %s", resp.Status)
+}
@@ -0,0 +1,126 @@
+package watch
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bc/porkbun-tui/internal/api"
+)
+
+func testLogger(t *testing.T) *log.Logger {
+	t.Helper()
+	return log.New(io.Discard, "", 0)
+}
+
+func testWebhook(url string) *Webhook {
+	w := NewWebhook(url)
+	w.Backoff = time.Millisecond
+	return w
+}
+
+func TestWebhookPostsJSON(t *testing.T) {
+	var got Event
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("request = %s %s", r.Method, r.Header.Get("Content-Type"))
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decoding body: %v", err)
+		}
+	}))
+	defer srv.Close()
+
+	e := Event{Type: EventAlert, Domain: "a.com", Severity: "WARNING", Message: "m", Time: now, Key: "secret-key"}
+	if err := testWebhook(srv.URL).Notify(context.Background(), e); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	if got.Type != EventAlert || got.Domain != "a.com" || got.Severity != "WARNING" {
+		t.Errorf("received %+v", got)
+	}
+	if got.Key != "" {
+		t.Error("dedupe key leaked into the payload")
+	}
+}
+
+func TestWebhookRetriesServerErrors(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer srv.Close()
+
+	if err := testWebhook(srv.URL).Notify(context.Background(), Event{}); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("%d attempts, want 3 (two 502s then success)", calls.Load())
+	}
+}
+
+func TestWebhookGivesUpAfterRetries(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	wh := testWebhook(srv.URL)
+	wh.Retries = 2
+	if err := wh.Notify(context.Background(), Event{}); err == nil {
+		t.Fatal("persistent 503 reported as delivered")
+	}
+	if calls.Load() != 3 {
+		t.Errorf("%d attempts, want 3", calls.Load())
+	}
+}
+
+func TestWebhookDoesNotRetryClientErrors(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	if err := testWebhook(srv.URL).Notify(context.Background(), Event{}); err == nil {
+		t.Fatal("400 reported as delivered")
+	}
+	if calls.Load() != 1 {
+		t.Errorf("%d attempts, want 1: a 400 won't succeed on retry", calls.Load())
+	}
+}
+
+func TestWatcherDeliversToLocalReceiver(t *testing.T) {
+	received := make(chan Event, 10)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var e Event
+		json.NewDecoder(r.Body).Decode(&e)
+		received <- e
+	}))
+	defer srv.Close()
+
+	src := &fakeSource{domains: []api.Domain{{Name: "soon.com", ExpireDate: now.AddDate(0, 0, 5)}}}
+	w := newWatcher(t, src, &fakeStore{}, testWebhook(srv.URL))
+
+	if _, err := w.RunOnce(context.Background()); err != nil {
+		t.Fatalf("RunOnce: %v", err)
+	}
+	select {
+	case e := <-received:
+		if e.Type != EventAlert || e.Domain != "soon.com" {
+			t.Errorf("received %+v", e)
+		}
+	default:
+		t.Fatal("receiver got nothing")
+	}
+}
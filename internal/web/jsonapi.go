@@ -0,0 +1,591 @@
+package web
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bc/porkbun-tui/internal/alerts"
+	"github.com/bc/porkbun-tui/internal/api"
+	"github.com/bc/porkbun-tui/internal/portfolio"
+)
+
+// defaultExpiringWithin is /v1/expiring's window without ?within.
+const defaultExpiringWithin = "30d"
+
+// maxRequestBody bounds JSON request bodies.
+const maxRequestBody = 64 << 10
+
+// Mutator makes the changes the JSON API allows, re-reading the current
+// state before each one; *api.Client satisfies it.
+type Mutator interface {
+	GetNameservers(ctx context.Context, domain string) ([]string, error)
+	GetDNSRecords(ctx context.Context, domain string) ([]api.DNSRecord, error)
+	UpdateNameservers(ctx context.Context, domain string, nameservers []string) error
+	CreateDNSRecord(ctx context.Context, domain string, r api.DNSRecord) (string, error)
+	EditDNSRecord(ctx context.Context, domain, id string, r api.DNSRecord) error
+	DeleteDNSRecord(ctx context.Context, domain, id string) error
+}
+
+func (s *Server) apiRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /v1/domains", s.apiDomains)
+	mux.HandleFunc("GET /v1/domains/{name}/dns", s.apiDNS)
+	mux.HandleFunc("GET /v1/pricing", s.apiPricing)
+	mux.HandleFunc("GET /v1/expiring", s.apiExpiring)
+
+	// The current state a change must match, read live from Porkbun.
+	mux.HandleFunc("GET /v1/domains/{name}/nameservers", s.mutation(s.apiNameservers))
+	mux.HandleFunc("GET /v1/domains/{name}/dns/{id}", s.mutation(s.apiRecord))
+
+	mux.HandleFunc("PUT /v1/domains/{name}/nameservers", s.mutation(s.apiSetNameservers))
+	mux.HandleFunc("POST /v1/domains/{name}/dns", s.mutation(s.apiCreateRecord))
+	mux.HandleFunc("PUT /v1/domains/{name}/dns/{id}", s.mutation(s.apiEditRecord))
+	mux.HandleFunc("DELETE /v1/domains/{name}/dns/{id}", s.mutation(s.apiDeleteRecord))
+
+	mux.HandleFunc("/v1/", func(w http.ResponseWriter, r *http.Request) {
+		apiError(w, http.StatusNotFound, "no such endpoint")
+	})
+}
+
+// apiAuth guards /v1. A bearer token, when sent, must be Token. Without
+// one, reads fall back to the dashboard's basic auth (open when unset).
+// Mutations check for the token themselves.
+func (s *Server) apiAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if token, ok := bearerToken(r); ok {
+			if !s.validToken(token) {
+				apiError(w, http.StatusUnauthorized, "invalid token")
+				return
+			}
+			next.ServeHTTP(w, r)
+			return
+		}
+		if s.Username != "" && !s.validBasicAuth(r) {
+			w.Header().Set("WWW-Authenticate", `Basic realm="porkbun-tui", charset="UTF-8"`)
+			apiError(w, http.StatusUnauthorized, "authentication required")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	return strings.TrimSpace(token), true
+}
+
+func (s *Server) validToken(token string) bool {
+	return s.Token != "" && subtle.ConstantTimeCompare([]byte(token), []byte(s.Token)) == 1
+}
+
+// mutation requires the API token and a Writer before running h. The
+// live reads changes are checked against go through it too, so they can't
+// be used to hammer Porkbun without the token.
+func (s *Server) mutation(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if s.Token == "" {
+			apiError(w, http.StatusForbidden, "changes are disabled; set serve.api_token to enable them")
+			return
+		}
+		if token, ok := bearerToken(r); !ok || !s.validToken(token) {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="porkbun-tui"`)
+			apiError(w, http.StatusUnauthorized, "changes need the API token")
+			return
+		}
+		if s.Writer == nil {
+			apiError(w, http.StatusServiceUnavailable, "read-only: the server has no API access (--cached)")
+			return
+		}
+		h(w, r)
+	}
+}
+
+type apiErrorBody struct {
+	Error string `json:"error"`
+}
+
+func apiError(w http.ResponseWriter, status int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(apiErrorBody{msg})
+}
+
+// writeJSON sends v with a strong ETag over the encoded body, answering a
+// matching If-None-Match with 304.
+func writeJSON(w http.ResponseWriter, r *http.Request, status int, v any) {
+	body, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		apiError(w, http.StatusInternalServerError, "encoding response failed")
+		return
+	}
+	body = append(body, '\n')
+
+	etag := bodyETag(body)
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "no-cache")
+	if r.Method == http.MethodGet && etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(body)
+}
+
+func bodyETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// ifMatch requires the request's If-Match to name the ETag a GET of
+// current would carry, so a change made since the client read the state
+// isn't overwritten blindly. It writes 428 or 412 and returns false
+// otherwise. Unlike If-None-Match, "*" and weak tags don't match.
+func ifMatch(w http.ResponseWriter, r *http.Request, current any) bool {
+	header := r.Header.Get("If-Match")
+	if header == "" {
+		apiError(w, http.StatusPreconditionRequired, "changes need If-Match with the ETag of the current state")
+		return false
+	}
+	body, err := json.MarshalIndent(current, "", "  ")
+	if err != nil {
+		apiError(w, http.StatusInternalServerError, "encoding current state failed")
+		return false
+	}
+	etag := bodyETag(append(body, '\n'))
+	for _, candidate := range strings.Split(header, ",") {
+		if strings.TrimSpace(candidate) == etag {
+			return true
+		}
+	}
+	w.Header().Set("ETag", etag)
+	apiError(w, http.StatusPreconditionFailed, "changed since it was read; fetch it again")
+	return false
+}
+
+// etagMatches is If-None-Match's weak comparison against etag.
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+func date(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
+
+type domainJSON struct {
+	Name         string   `json:"name"`
+	TLD          string   `json:"tld"`
+	Status       string   `json:"status"`
+	CreateDate   string   `json:"create_date"`
+	ExpireDate   string   `json:"expire_date"`
+	AutoRenew    bool     `json:"auto_renew"`
+	SecurityLock bool     `json:"security_lock"`
+	WhoisPrivacy bool     `json:"whois_privacy"`
+	Labels       []string `json:"labels"`
+}
+
+func toDomainJSON(d api.Domain) domainJSON {
+	labels := d.Labels
+	if labels == nil {
+		labels = []string{}
+	}
+	return domainJSON{
+		Name:         d.Name,
+		TLD:          d.TLD,
+		Status:       d.Status,
+		CreateDate:   date(d.CreateDate),
+		ExpireDate:   date(d.ExpireDate),
+		AutoRenew:    d.AutoRenew,
+		SecurityLock: d.SecurityLock,
+		WhoisPrivacy: d.WhoisPrivacy,
+		Labels:       labels,
+	}
+}
+
+type recordJSON struct {
+	ID       string `json:"id,omitempty"`
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Content  string `json:"content"`
+	TTL      string `json:"ttl,omitempty"`
+	Priority string `json:"priority,omitempty"`
+	Notes    string `json:"notes,omitempty"`
+}
+
+// apiDomainList is the cached domain list, or false after writing an error.
+func (s *Server) apiDomainList(w http.ResponseWriter) ([]api.Domain, time.Time, bool) {
+	domains, updated, err := s.Store.LoadDomains()
+	if err != nil {
+		s.logf("reading cached domains: %v", err)
+		apiError(w, http.StatusInternalServerError, "reading cached domains failed")
+		return nil, time.Time{}, false
+	}
+	if updated.IsZero() {
+		apiError(w, http.StatusServiceUnavailable, "no cached domains yet")
+		return nil, time.Time{}, false
+	}
+	return domains, updated, true
+}
+
+// apiDomain is the portfolio domain named in the path.
+func (s *Server) apiDomain(w http.ResponseWriter, r *http.Request) (api.Domain, bool) {
+	domains, _, ok := s.apiDomainList(w)
+	if !ok {
+		return api.Domain{}, false
+	}
+	d, found := findDomain(domains, r.PathValue("name"))
+	if !found {
+		apiError(w, http.StatusNotFound, "domain not in the portfolio")
+	}
+	return d, found
+}
+
+func (s *Server) apiDomains(w http.ResponseWriter, r *http.Request) {
+	domains, updated, ok := s.apiDomainList(w)
+	if !ok {
+		return
+	}
+	out := make([]domainJSON, len(domains))
+	for i, d := range domains {
+		out[i] = toDomainJSON(d)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	writeJSON(w, r, http.StatusOK, struct {
+		Updated time.Time    `json:"updated"`
+		Domains []domainJSON `json:"domains"`
+	}{updated, out})
+}
+
+func (s *Server) apiDNS(w http.ResponseWriter, r *http.Request) {
+	d, ok := s.apiDomain(w, r)
+	if !ok {
+		return
+	}
+	records, at, err := s.dnsRecords(r.Context(), d.Name, r.URL.Query().Get("refresh") != "")
+	if errors.Is(err, errFetchThrottled) {
+		w.Header().Set("Retry-After", fmt.Sprint(int(dnsRefreshEvery.Seconds())))
+		apiError(w, http.StatusTooManyRequests, err.Error())
+		return
+	}
+	if err != nil && at.IsZero() {
+		apiError(w, http.StatusBadGateway, "fetching DNS records: "+err.Error())
+		return
+	}
+	out := make([]recordJSON, len(records))
+	for i, rec := range records {
+		out[i] = recordJSON(rec)
+	}
+	writeJSON(w, r, http.StatusOK, struct {
+		Domain  string       `json:"domain"`
+		Updated time.Time    `json:"updated"`
+		Records []recordJSON `json:"records"`
+	}{d.Name, at, out})
+}
+
+type pricingJSON struct {
+	Registration string `json:"registration"`
+	Renewal      string `json:"renewal"`
+	Transfer     string `json:"transfer"`
+}
+
+func (s *Server) apiPricing(w http.ResponseWriter, r *http.Request) {
+	pricing, updated, err := s.Store.LoadPricing()
+	if err != nil {
+		s.logf("reading cached pricing: %v", err)
+		apiError(w, http.StatusInternalServerError, "reading cached pricing failed")
+		return
+	}
+	if updated.IsZero() {
+		apiError(w, http.StatusServiceUnavailable, "no cached pricing yet")
+		return
+	}
+	out := make(map[string]pricingJSON, len(pricing))
+	for tld, p := range pricing {
+		out[tld] = pricingJSON{Registration: p.Registration, Renewal: p.Renewal, Transfer: p.Transfer}
+	}
+	writeJSON(w, r, http.StatusOK, struct {
+		Updated time.Time              `json:"updated"`
+		Pricing map[string]pricingJSON `json:"pricing"`
+	}{updated, out})
+}
+
+type expiringJSON struct {
+	domainJSON
+	Days         int      `json:"days"`
+	RenewalPrice float64  `json:"renewal_price,omitempty"`
+	Severity     string   `json:"severity,omitempty"`
+	Rules        []string `json:"rules,omitempty"`
+}
+
+// apiExpiring lists domains expiring within ?within (default 30d),
+// including expired ones, annotated with the alert rules they trip.
+func (s *Server) apiExpiring(w http.ResponseWriter, r *http.Request) {
+	within := r.URL.Query().Get("within")
+	if within == "" {
+		within = defaultExpiringWithin
+	}
+	window, err := alerts.ParseDuration(within)
+	if err != nil {
+		apiError(w, http.StatusBadRequest, "within: "+err.Error())
+		return
+	}
+	domains, updated, ok := s.apiDomainList(w)
+	if !ok {
+		return
+	}
+	pricing, _, _ := s.Store.LoadPricing()
+	now := s.now()
+
+	hits := make(map[string]alerts.Hit)
+	for _, h := range alerts.Evaluate(s.Rules, domains, now) {
+		hits[h.Domain.Name] = h
+	}
+
+	out := []expiringJSON{}
+	for _, d := range domains {
+		if d.ExpireDate.After(now.Add(window)) {
+			continue
+		}
+		e := expiringJSON{
+			domainJSON:   toDomainJSON(d),
+			Days:         int(d.ExpireDate.Sub(now).Hours() / 24),
+			RenewalPrice: portfolio.RenewalPrice(pricing, d.TLD),
+		}
+		if h, ok := hits[d.Name]; ok {
+			e.Severity = h.Severity.String()
+			for _, rule := range h.Rules {
+				e.Rules = append(e.Rules, rule.String())
+			}
+		}
+		out = append(out, e)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].ExpireDate != out[j].ExpireDate {
+			return out[i].ExpireDate < out[j].ExpireDate
+		}
+		return out[i].Name < out[j].Name
+	})
+	writeJSON(w, r, http.StatusOK, struct {
+		Updated time.Time      `json:"updated"`
+		Within  string         `json:"within"`
+		Domains []expiringJSON `json:"domains"`
+	}{updated, within, out})
+}
+
+func decodeBody(w http.ResponseWriter, r *http.Request, v any) bool {
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBody))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		apiError(w, http.StatusBadRequest, "invalid JSON body: "+err.Error())
+		return false
+	}
+	return true
+}
+
+type nameserversJSON struct {
+	Domain      string   `json:"domain"`
+	Nameservers []string `json:"nameservers"`
+}
+
+// currentNameservers reads the domain's nameservers from Porkbun, or
+// returns false after writing an error.
+func (s *Server) currentNameservers(w http.ResponseWriter, r *http.Request, domain string) (nameserversJSON, bool) {
+	ns, err := s.Writer.GetNameservers(r.Context(), domain)
+	if err != nil {
+		apiError(w, http.StatusBadGateway, "fetching nameservers: "+err.Error())
+		return nameserversJSON{}, false
+	}
+	if ns == nil {
+		ns = []string{}
+	}
+	return nameserversJSON{domain, ns}, true
+}
+
+func (s *Server) apiNameservers(w http.ResponseWriter, r *http.Request) {
+	d, ok := s.apiDomain(w, r)
+	if !ok {
+		return
+	}
+	if current, ok := s.currentNameservers(w, r, d.Name); ok {
+		writeJSON(w, r, http.StatusOK, current)
+	}
+}
+
+func (s *Server) apiSetNameservers(w http.ResponseWriter, r *http.Request) {
+	d, ok := s.apiDomain(w, r)
+	if !ok {
+		return
+	}
+	var body struct {
+		Nameservers []string `json:"nameservers"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	ns, err := api.NormalizeNameservers(body.Nameservers)
+	if err != nil {
+		apiError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	current, ok := s.currentNameservers(w, r, d.Name)
+	if !ok || !ifMatch(w, r, current) {
+		return
+	}
+	if err := s.Writer.UpdateNameservers(r.Context(), d.Name, ns); err != nil {
+		apiError(w, http.StatusBadGateway, err.Error())
+		return
+	}
+	s.logf("%s: nameservers set to %s", d.Name, strings.Join(ns, ", "))
+	writeJSON(w, r, http.StatusOK, nameserversJSON{d.Name, ns})
+}
+
+// recordFromBody decodes and validates a record; the name may be relative
+// ("www") or the full name the GET endpoint lists.
+func recordFromBody(w http.ResponseWriter, r *http.Request, domain string) (api.DNSRecord, bool) {
+	var body recordJSON
+	if !decodeBody(w, r, &body) {
+		return api.DNSRecord{}, false
+	}
+	rec := api.DNSRecord{
+		Name:     api.Subdomain(strings.TrimSpace(body.Name), domain),
+		Type:     strings.ToUpper(strings.TrimSpace(body.Type)),
+		Content:  strings.TrimSpace(body.Content),
+		TTL:      body.TTL,
+		Priority: body.Priority,
+		Notes:    body.Notes,
+	}
+	if err := api.ValidateDNSRecord(rec); err != nil {
+		apiError(w, http.StatusBadRequest, err.Error())
+		return api.DNSRecord{}, false
+	}
+	return rec, true
+}
+
+func (s *Server) apiCreateRecord(w http.ResponseWriter, r *http.Request) {
+	d, ok := s.apiDomain(w, r)
+	if !ok {
+		return
+	}
+	rec, ok := recordFromBody(w, r, d.Name)
+	if !ok {
+		return
+	}
+	id, err := s.Writer.CreateDNSRecord(r.Context(), d.Name, rec)
+	if err != nil {
+		apiError(w, http.StatusBadGateway, err.Error())
+		return
+	}
+	s.logf("%s: created %s record %s (id %s)", d.Name, rec.Type, recordName(rec.Name, d.Name), id)
+	s.refreshDNS(r.Context(), d.Name)
+	rec.ID = id
+	writeJSON(w, r, http.StatusCreated, recordJSON(rec))
+}
+
+// currentRecord reads the record named in the path from Porkbun, or
+// returns false after writing an error.
+func (s *Server) currentRecord(w http.ResponseWriter, r *http.Request, domain string) (recordJSON, bool) {
+	records, err := s.Writer.GetDNSRecords(r.Context(), domain)
+	if err != nil {
+		apiError(w, http.StatusBadGateway, "fetching DNS records: "+err.Error())
+		return recordJSON{}, false
+	}
+	id := r.PathValue("id")
+	for _, rec := range records {
+		if rec.ID == id {
+			return recordJSON(rec), true
+		}
+	}
+	apiError(w, http.StatusNotFound, "no such record")
+	return recordJSON{}, false
+}
+
+func (s *Server) apiRecord(w http.ResponseWriter, r *http.Request) {
+	d, ok := s.apiDomain(w, r)
+	if !ok {
+		return
+	}
+	if current, ok := s.currentRecord(w, r, d.Name); ok {
+		writeJSON(w, r, http.StatusOK, current)
+	}
+}
+
+func (s *Server) apiEditRecord(w http.ResponseWriter, r *http.Request) {
+	d, ok := s.apiDomain(w, r)
+	if !ok {
+		return
+	}
+	rec, ok := recordFromBody(w, r, d.Name)
+	if !ok {
+		return
+	}
+	current, ok := s.currentRecord(w, r, d.Name)
+	if !ok || !ifMatch(w, r, current) {
+		return
+	}
+	rec.ID = current.ID
+	if err := s.Writer.EditDNSRecord(r.Context(), d.Name, rec.ID, rec); err != nil {
+		apiError(w, http.StatusBadGateway, err.Error())
+		return
+	}
+	s.logf("%s: edited record %s", d.Name, rec.ID)
+	s.refreshDNS(r.Context(), d.Name)
+	writeJSON(w, r, http.StatusOK, recordJSON(rec))
+}
+
+func (s *Server) apiDeleteRecord(w http.ResponseWriter, r *http.Request) {
+	d, ok := s.apiDomain(w, r)
+	if !ok {
+		return
+	}
+	current, ok := s.currentRecord(w, r, d.Name)
+	if !ok || !ifMatch(w, r, current) {
+		return
+	}
+	if err := s.Writer.DeleteDNSRecord(r.Context(), d.Name, current.ID); err != nil {
+		apiError(w, http.StatusBadGateway, err.Error())
+		return
+	}
+	s.logf("%s: deleted record %s", d.Name, current.ID)
+	s.refreshDNS(r.Context(), d.Name)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// refreshDNS re-reads a domain's records after a change so the cache, and
+// the next GET, reflect it. Changes need the token, so unlike ?refresh
+// the re-read isn't throttled; it still restarts the domain's window.
+func (s *Server) refreshDNS(ctx context.Context, domain string) {
+	if s.DNS == nil {
+		return
+	}
+	s.claimFetch(domain, true)
+	if _, err := s.fetchDNS(ctx, domain); err != nil {
+		s.logf("refreshing DNS for %s: %v", domain, err)
+	}
+}
+
+func recordName(sub, domain string) string {
+	if sub == "" {
+		return domain
+	}
+	return fmt.Sprintf("%s.%s", sub, domain)
+}
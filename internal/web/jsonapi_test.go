@@ -0,0 +1,314 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/bc/porkbun-tui/internal/api"
+)
+
+type fakeWriter struct {
+	calls   []string
+	ns      []string
+	records []api.DNSRecord
+	created api.DNSRecord
+	edited  api.DNSRecord
+}
+
+func (f *fakeWriter) GetNameservers(_ context.Context, domain string) ([]string, error) {
+	return f.ns, nil
+}
+
+func (f *fakeWriter) GetDNSRecords(_ context.Context, domain string) ([]api.DNSRecord, error) {
+	return f.records, nil
+}
+
+func (f *fakeWriter) UpdateNameservers(_ context.Context, domain string, ns []string) error {
+	f.calls = append(f.calls, "ns "+domain)
+	f.ns = ns
+	return nil
+}
+
+func (f *fakeWriter) CreateDNSRecord(_ context.Context, domain string, r api.DNSRecord) (string, error) {
+	f.calls = append(f.calls, "create "+domain)
+	f.created = r
+	r.ID = "123"
+	f.records = append(f.records, r)
+	return "123", nil
+}
+
+func (f *fakeWriter) EditDNSRecord(_ context.Context, domain, id string, r api.DNSRecord) error {
+	f.calls = append(f.calls, "edit "+domain+" "+id)
+	f.edited = r
+	return nil
+}
+
+func (f *fakeWriter) DeleteDNSRecord(_ context.Context, domain, id string) error {
+	f.calls = append(f.calls, "delete "+domain+" "+id)
+	f.records = slices.DeleteFunc(f.records, func(r api.DNSRecord) bool { return r.ID == id })
+	return nil
+}
+
+func do(t *testing.T, h http.Handler, method, target, body string, header ...string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	for i := 0; i+1 < len(header); i += 2 {
+		req.Header.Set(header[i], header[i+1])
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestAPIDomainsETag(t *testing.T) {
+	h := testServer(testStore()).Handler()
+
+	rec := do(t, h, http.MethodGet, "/v1/domains", "")
+	if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "application/json" {
+		t.Fatalf("status %d, %s", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	var body struct {
+		Domains []struct {
+			Name       string   `json:"name"`
+			ExpireDate string   `json:"expire_date"`
+			Labels     []string `json:"labels"`
+		} `json:"domains"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+	if len(body.Domains) != 3 || body.Domains[0].Name != "later.io" || body.Domains[0].ExpireDate != "2026-08-02" {
+		t.Errorf("domains = %+v", body.Domains)
+	}
+
+	etag := rec.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("no ETag")
+	}
+	if rec := do(t, h, http.MethodGet, "/v1/domains", "", "If-None-Match", etag); rec.Code != http.StatusNotModified || rec.Body.Len() != 0 {
+		t.Errorf("matching If-None-Match: status %d, %d bytes", rec.Code, rec.Body.Len())
+	}
+	if rec := do(t, h, http.MethodGet, "/v1/domains", "", "If-None-Match", `"stale"`); rec.Code != http.StatusOK {
+		t.Errorf("stale If-None-Match: status %d", rec.Code)
+	}
+}
+
+func TestAPIExpiring(t *testing.T) {
+	h := testServer(testStore()).Handler()
+
+	rec := do(t, h, http.MethodGet, "/v1/expiring?within=6mo", "")
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("bad window: status %d", rec.Code)
+	}
+
+	rec = do(t, h, http.MethodGet, "/v1/expiring", "")
+	var body struct {
+		Domains []struct {
+			Name         string   `json:"name"`
+			Days         int      `json:"days"`
+			RenewalPrice float64  `json:"renewal_price"`
+			Severity     string   `json:"severity"`
+			Rules        []string `json:"rules"`
+		} `json:"domains"`
+	}
+	json.Unmarshal(rec.Body.Bytes(), &body)
+	if len(body.Domains) != 1 {
+		t.Fatalf("expiring = %+v, want only soon.com", body.Domains)
+	}
+	d := body.Domains[0]
+	if d.Name != "soon.com" || d.Days != 10 || d.RenewalPrice != 10 || d.Severity != "CRITICAL" || len(d.Rules) != 1 {
+		t.Errorf("soon.com = %+v", d)
+	}
+}
+
+func TestAPIDNSAndPricing(t *testing.T) {
+	store := testStore()
+	store.dns = map[string][]api.DNSRecord{"soon.com": {{ID: "9", Name: "soon.com", Type: "A", Content: "192.0.2.1"}}}
+	h := testServer(store).Handler()
+
+	rec := do(t, h, http.MethodGet, "/v1/domains/soon.com/dns", "")
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"content": "192.0.2.1"`) {
+		t.Errorf("dns: status %d\n%s", rec.Code, rec.Body)
+	}
+	if rec := do(t, h, http.MethodGet, "/v1/domains/other.com/dns", ""); rec.Code != http.StatusNotFound {
+		t.Errorf("foreign domain: status %d", rec.Code)
+	}
+
+	rec = do(t, h, http.MethodGet, "/v1/pricing", "")
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"renewal": "30.00"`) {
+		t.Errorf("pricing: status %d\n%s", rec.Code, rec.Body)
+	}
+}
+
+func TestAPIReadAuth(t *testing.T) {
+	s := testServer(testStore())
+	s.Username, s.Password, s.Token = "team", "s3cret", "tok"
+	h := s.Handler()
+
+	if rec := do(t, h, http.MethodGet, "/v1/domains", ""); rec.Code != http.StatusUnauthorized {
+		t.Errorf("anonymous read: status %d", rec.Code)
+	}
+	if rec := do(t, h, http.MethodGet, "/v1/domains", "", "Authorization", "Bearer tok"); rec.Code != http.StatusOK {
+		t.Errorf("token read: status %d", rec.Code)
+	}
+	if rec := do(t, h, http.MethodGet, "/v1/domains", "", "Authorization", "Bearer nope"); rec.Code != http.StatusUnauthorized {
+		t.Errorf("bad token read: status %d", rec.Code)
+	}
+}
+
+func TestAPIMutationsNeedToken(t *testing.T) {
+	w := &fakeWriter{}
+	s := testServer(testStore())
+	s.Writer = w
+	body := `{"nameservers": ["ns1.example.net", "ns2.example.net"]}`
+
+	rec := do(t, s.Handler(), http.MethodPut, "/v1/domains/soon.com/nameservers", body)
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("no token configured: status %d", rec.Code)
+	}
+
+	s.Token = "tok"
+	h := s.Handler()
+	if rec := do(t, h, http.MethodPut, "/v1/domains/soon.com/nameservers", body); rec.Code != http.StatusUnauthorized {
+		t.Errorf("no token sent: status %d", rec.Code)
+	}
+	if len(w.calls) != 0 {
+		t.Fatalf("unauthorized change reached the API: %v", w.calls)
+	}
+
+	etag := do(t, h, http.MethodGet, "/v1/domains/soon.com/nameservers", "", "Authorization", "Bearer tok").Header().Get("ETag")
+	rec = do(t, h, http.MethodPut, "/v1/domains/soon.com/nameservers", body, "Authorization", "Bearer tok", "If-Match", etag)
+	if rec.Code != http.StatusOK || len(w.ns) != 2 {
+		t.Errorf("authorized change: status %d, ns %v", rec.Code, w.ns)
+	}
+}
+
+func TestAPIMutationsValidate(t *testing.T) {
+	w := &fakeWriter{}
+	s := testServer(testStore())
+	s.Writer, s.Token = w, "tok"
+	h := s.Handler()
+	auth := []string{"Authorization", "Bearer tok"}
+
+	for _, tc := range []struct{ method, target, body string }{
+		{http.MethodPut, "/v1/domains/soon.com/nameservers", `{"nameservers": ["ns1.example.net", "NS1.example.net."]}`},
+		{http.MethodPut, "/v1/domains/soon.com/nameservers", `{"ns": ["ns1.example.net"]}`},
+		{http.MethodPut, "/v1/domains/soon.com/nameservers", `{"nameservers": ["ns1.example.net"]}`},
+		{http.MethodPost, "/v1/domains/soon.com/dns", `{"type": "A", "name": "www", "content": "not-an-ip"}`},
+		{http.MethodPost, "/v1/domains/soon.com/dns", `{"type": "A", "content": "192.0.2.1", "ttl": "60"}`},
+	} {
+		if rec := do(t, h, tc.method, tc.target, tc.body, auth...); rec.Code != http.StatusBadRequest {
+			t.Errorf("%s %s: status %d, want 400", tc.method, tc.body, rec.Code)
+		}
+	}
+	if len(w.calls) != 0 {
+		t.Fatalf("invalid change reached the API: %v", w.calls)
+	}
+
+	rec := do(t, h, http.MethodPost, "/v1/domains/soon.com/dns", `{"type": "a", "name": "www.soon.com", "content": "192.0.2.7"}`, auth...)
+	if rec.Code != http.StatusCreated || !strings.Contains(rec.Body.String(), `"id": "123"`) {
+		t.Errorf("create: status %d\n%s", rec.Code, rec.Body)
+	}
+	if w.created.Name != "www" || w.created.Type != "A" {
+		t.Errorf("created %+v; want the relative name and upper-case type", w.created)
+	}
+
+	etag := do(t, h, http.MethodGet, "/v1/domains/soon.com/dns/123", "", auth...).Header().Get("ETag")
+	if rec := do(t, h, http.MethodDelete, "/v1/domains/soon.com/dns/123", "", append(auth, "If-Match", etag)...); rec.Code != http.StatusNoContent {
+		t.Errorf("delete: status %d", rec.Code)
+	}
+	if rec := do(t, h, http.MethodDelete, "/v1/domains/other.com/dns/1", "", auth...); rec.Code != http.StatusNotFound {
+		t.Errorf("delete on a foreign domain: status %d", rec.Code)
+	}
+}
+
+func TestAPIChangesNeedIfMatch(t *testing.T) {
+	w := &fakeWriter{
+		ns: []string{"maceio.ns.porkbun.com", "curitiba.ns.porkbun.com"},
+		records: []api.DNSRecord{
+			{ID: "7", Name: "www.soon.com", Type: "A", Content: "192.0.2.1", TTL: "600"},
+			{ID: "8", Name: "old.soon.com", Type: "A", Content: "192.0.2.8", TTL: "600"},
+			{ID: "9", Name: "mail.soon.com", Type: "MX", Content: "mx.example.net", TTL: "600", Priority: "10"},
+		},
+	}
+	s := testServer(testStore())
+	s.Writer, s.Token = w, "tok"
+	h := s.Handler()
+	auth := []string{"Authorization", "Bearer tok"}
+
+	for _, tc := range []struct {
+		method, target, body string
+		want                 int
+	}{
+		{http.MethodPut, "/v1/domains/soon.com/nameservers", `{"nameservers": ["ns1.example.net", "ns2.example.net"]}`, http.StatusOK},
+		{http.MethodPut, "/v1/domains/soon.com/dns/7", `{"type": "A", "name": "www", "content": "192.0.2.2"}`, http.StatusOK},
+		{http.MethodDelete, "/v1/domains/soon.com/dns/8", "", http.StatusNoContent},
+	} {
+		get := do(t, h, http.MethodGet, tc.target, "", auth...)
+		etag := get.Header().Get("ETag")
+		if get.Code != http.StatusOK || etag == "" {
+			t.Fatalf("GET %s: status %d, ETag %q", tc.target, get.Code, etag)
+		}
+
+		if rec := do(t, h, tc.method, tc.target, tc.body, auth...); rec.Code != http.StatusPreconditionRequired {
+			t.Errorf("%s %s without If-Match: status %d, want 428", tc.method, tc.target, rec.Code)
+		}
+		for _, stale := range []string{`"0123"`, "*", "W/" + etag} {
+			if rec := do(t, h, tc.method, tc.target, tc.body, append(auth, "If-Match", stale)...); rec.Code != http.StatusPreconditionFailed {
+				t.Errorf("%s %s with If-Match %s: status %d, want 412", tc.method, tc.target, stale, rec.Code)
+			}
+		}
+		if len(w.calls) != 0 {
+			t.Fatalf("change without a matching If-Match reached the API: %v", w.calls)
+		}
+
+		if rec := do(t, h, tc.method, tc.target, tc.body, append(auth, "If-Match", etag)...); rec.Code != tc.want {
+			t.Errorf("%s %s with the current ETag: status %d, want %d\n%s", tc.method, tc.target, rec.Code, tc.want, rec.Body)
+		}
+		w.calls = nil
+	}
+	if w.edited.Content != "192.0.2.2" {
+		t.Errorf("edited %+v", w.edited)
+	}
+
+	// Someone else changes the nameservers after the client read them.
+	etag := do(t, h, http.MethodGet, "/v1/domains/soon.com/nameservers", "", auth...).Header().Get("ETag")
+	w.ns = []string{"ada.ns.cloudflare.com", "bob.ns.cloudflare.com"}
+	rec := do(t, h, http.MethodPut, "/v1/domains/soon.com/nameservers", `{"nameservers": ["ns1.example.net", "ns2.example.net"]}`, append(auth, "If-Match", etag)...)
+	if rec.Code != http.StatusPreconditionFailed || len(w.calls) != 0 {
+		t.Errorf("stale write: status %d, calls %v", rec.Code, w.calls)
+	}
+
+	// And a record after the client read it.
+	etag = do(t, h, http.MethodGet, "/v1/domains/soon.com/dns/9", "", auth...).Header().Get("ETag")
+	w.records[len(w.records)-1].Content = "mx2.example.net"
+	rec = do(t, h, http.MethodDelete, "/v1/domains/soon.com/dns/9", "", append(auth, "If-Match", etag)...)
+	if rec.Code != http.StatusPreconditionFailed || len(w.calls) != 0 {
+		t.Errorf("stale delete: status %d, calls %v", rec.Code, w.calls)
+	}
+
+	if rec := do(t, h, http.MethodGet, "/v1/domains/soon.com/dns/99", "", auth...); rec.Code != http.StatusNotFound {
+		t.Errorf("GET of a missing record: status %d, want 404", rec.Code)
+	}
+	if rec := do(t, h, http.MethodDelete, "/v1/domains/soon.com/dns/99", "", append(auth, "If-Match", etag)...); rec.Code != http.StatusNotFound || len(w.calls) != 0 {
+		t.Errorf("DELETE of a missing record: status %d, calls %v; want 404", rec.Code, w.calls)
+	}
+	if rec := do(t, h, http.MethodGet, "/v1/domains/soon.com/nameservers", ""); rec.Code != http.StatusUnauthorized {
+		t.Errorf("live read without the token: status %d", rec.Code)
+	}
+}
+
+func TestAPIReadOnlyWithoutWriter(t *testing.T) {
+	s := testServer(testStore())
+	s.Token = "tok"
+
+	rec := do(t, s.Handler(), http.MethodDelete, "/v1/domains/soon.com/dns/1", "", "Authorization", "Bearer tok")
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("status %d, want 503", rec.Code)
+	}
+}
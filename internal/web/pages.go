@@ -0,0 +1,327 @@
+package web
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bc/porkbun-tui/internal/alerts"
+	"github.com/bc/porkbun-tui/internal/api"
+	"github.com/bc/porkbun-tui/internal/portfolio"
+)
+
+var pageNames = []string{"domains", "calendar", "tlds", "domain"}
+
+var funcs = template.FuncMap{
+	"date": func(t time.Time) string {
+		if t.IsZero() {
+			return "-"
+		}
+		return t.Format("2006-01-02")
+	},
+	"stamp": func(t time.Time) string { return t.Format("2006-01-02 15:04") },
+	"money": func(v float64) string {
+		if v == 0 {
+			return "-"
+		}
+		return fmt.Sprintf("$%.2f", v)
+	},
+	"onoff": func(b bool) string {
+		if b {
+			return "on"
+		}
+		return "off"
+	},
+	"lower": strings.ToLower,
+	"join":  strings.Join,
+}
+
+// parsePages pairs each page's "content" with the shared layout.
+func parsePages() map[string]*template.Template {
+	pages := make(map[string]*template.Template, len(pageNames))
+	for _, name := range pageNames {
+		pages[name] = template.Must(template.New("layout.html").Funcs(funcs).
+			ParseFS(templateFS, "templates/layout.html", "templates/"+name+".html"))
+	}
+	return pages
+}
+
+// page is what the layout renders; Data is the page's own view model.
+type page struct {
+	Title   string
+	Nav     string
+	Updated time.Time // of the cached domain list; zero when never cached
+	Data    any
+}
+
+func (s *Server) render(w http.ResponseWriter, name string, p page) {
+	// Render to a buffer so a template error becomes a 500, not half a page.
+	var buf bytes.Buffer
+	if err := s.pages[name].Execute(&buf, p); err != nil {
+		s.logf("rendering %s: %v", name, err)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	buf.WriteTo(w)
+}
+
+func (s *Server) loadDomains(w http.ResponseWriter) ([]api.Domain, time.Time, bool) {
+	domains, updated, err := s.Store.LoadDomains()
+	if err != nil {
+		s.logf("reading cached domains: %v", err)
+		http.Error(w, "reading cached domains failed", http.StatusInternalServerError)
+		return nil, time.Time{}, false
+	}
+	return domains, updated, true
+}
+
+func (s *Server) loadPricing() map[string]api.TLDPricing {
+	pricing, _, err := s.Store.LoadPricing()
+	if err != nil {
+		s.logf("reading cached pricing: %v", err)
+	}
+	return pricing
+}
+
+type domainRow struct {
+	api.Domain
+	Days     int
+	Severity string // worst alert, "" for none
+	Alerts   string
+}
+
+type domainsData struct {
+	Query string
+	Sort  string
+	Total int
+	Rows  []domainRow
+}
+
+func (s *Server) domainsPage(w http.ResponseWriter, r *http.Request) {
+	domains, updated, ok := s.loadDomains(w)
+	if !ok {
+		return
+	}
+	now := s.now()
+
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+	sortBy := r.URL.Query().Get("sort")
+	if sortBy != "expiry" {
+		sortBy = "name"
+	}
+
+	hits := make(map[string]alerts.Hit)
+	for _, h := range alerts.Evaluate(s.Rules, domains, now) {
+		hits[h.Domain.Name] = h
+	}
+
+	data := domainsData{Query: query, Sort: sortBy, Total: len(domains)}
+	for _, d := range domains {
+		if query != "" && !matches(d, query) {
+			continue
+		}
+		row := domainRow{Domain: d, Days: int(d.ExpireDate.Sub(now).Hours() / 24)}
+		if h, ok := hits[d.Name]; ok {
+			row.Severity = h.Severity.String()
+			row.Alerts = h.RuleNames()
+		}
+		data.Rows = append(data.Rows, row)
+	}
+	sort.SliceStable(data.Rows, func(i, j int) bool {
+		a, b := data.Rows[i], data.Rows[j]
+		if sortBy == "expiry" && !a.ExpireDate.Equal(b.ExpireDate) {
+			return a.ExpireDate.Before(b.ExpireDate)
+		}
+		return a.Name < b.Name
+	})
+
+	s.render(w, "domains", page{Title: "Domains", Nav: "domains", Updated: updated, Data: data})
+}
+
+// matches is the TUI's filter: a substring of the name or of a label.
+func matches(d api.Domain, query string) bool {
+	query = strings.ToLower(query)
+	if strings.Contains(strings.ToLower(d.Name), query) {
+		return true
+	}
+	return slices.ContainsFunc(d.Labels, func(l string) bool {
+		return strings.Contains(strings.ToLower(l), query)
+	})
+}
+
+type calendarMonth struct {
+	portfolio.MonthTotal
+	OverBudget bool
+}
+
+type calendarData struct {
+	Months   []calendarMonth
+	Budget   float64
+	Charged  float64
+	Lapsing  float64
+	Lapses   int
+	Unpriced int
+}
+
+func (s *Server) calendarPage(w http.ResponseWriter, r *http.Request) {
+	domains, updated, ok := s.loadDomains(w)
+	if !ok {
+		return
+	}
+
+	data := calendarData{Budget: s.Budget}
+	for _, m := range portfolio.ExpiringByMonth(domains, s.loadPricing()) {
+		data.Months = append(data.Months, calendarMonth{MonthTotal: m, OverBudget: m.OverBudget(s.Budget)})
+		data.Charged += m.ChargeCost
+		data.Lapsing += m.LapseCost
+		data.Lapses += m.LapseCount
+		data.Unpriced += m.Unpriced
+	}
+
+	s.render(w, "calendar", page{Title: "Calendar", Nav: "calendar", Updated: updated, Data: data})
+}
+
+type tldsData struct {
+	TLDs    []portfolio.TLDTotal
+	Domains int
+	Total   float64
+}
+
+func (s *Server) tldsPage(w http.ResponseWriter, r *http.Request) {
+	domains, updated, ok := s.loadDomains(w)
+	if !ok {
+		return
+	}
+
+	data := tldsData{TLDs: portfolio.CostByTLD(domains, s.loadPricing()), Domains: len(domains)}
+	for _, t := range data.TLDs {
+		data.Total += t.TotalCost
+	}
+
+	s.render(w, "tlds", page{Title: "TLD costs", Nav: "tlds", Updated: updated, Data: data})
+}
+
+type domainData struct {
+	Domain     api.Domain
+	Days       int
+	Price      float64
+	Alerts     string
+	Records    []api.DNSRecord
+	DNSUpdated time.Time // zero when never fetched
+	DNSError   string
+	CanRefresh bool
+}
+
+func (s *Server) domainPage(w http.ResponseWriter, r *http.Request) {
+	domains, updated, ok := s.loadDomains(w)
+	if !ok {
+		return
+	}
+	d, found := findDomain(domains, r.PathValue("name"))
+	if !found {
+		http.NotFound(w, r)
+		return
+	}
+	now := s.now()
+
+	data := domainData{
+		Domain:     d,
+		Days:       int(d.ExpireDate.Sub(now).Hours() / 24),
+		Price:      portfolio.RenewalPrice(s.loadPricing(), d.TLD),
+		CanRefresh: s.DNS != nil,
+	}
+	if hits := alerts.Evaluate(s.Rules, []api.Domain{d}, now); len(hits) > 0 {
+		data.Alerts = hits[0].Severity.String() + ": " + hits[0].RuleNames()
+	}
+
+	var err error
+	data.Records, data.DNSUpdated, err = s.dnsRecords(r.Context(), d.Name, r.URL.Query().Get("refresh") != "")
+	if err != nil {
+		data.DNSError = err.Error()
+	}
+
+	s.render(w, "domain", page{Title: d.Name, Nav: "domains", Updated: updated, Data: data})
+}
+
+// dnsRefreshEvery is how often a domain's DNS records may be fetched live.
+// ?refresh needs no more auth than reading, so without it anyone who can
+// reach the server could spend the account's API rate limit.
+const dnsRefreshEvery = time.Minute
+
+// errFetchThrottled is a fetch skipped by dnsRefreshEvery with nothing
+// cached to serve instead.
+var errFetchThrottled = errors.New("DNS records were fetched less than a minute ago; try again shortly")
+
+// dnsRecords returns the cached records for domain, fetching them when
+// they were never cached or refresh is set and a DNSSource is configured.
+// A failed fetch is returned alongside the cached records. Fetches for a
+// domain are at least dnsRefreshEvery apart; in between, the cache is
+// served.
+func (s *Server) dnsRecords(ctx context.Context, domain string, refresh bool) ([]api.DNSRecord, time.Time, error) {
+	records, at, err := s.Store.LoadDNS(domain)
+	if err != nil {
+		s.logf("reading cached DNS for %s: %v", domain, err)
+	}
+	if s.DNS == nil || (!at.IsZero() && !refresh) {
+		return records, at, nil
+	}
+	if !s.claimFetch(domain, false) {
+		if at.IsZero() {
+			return nil, at, errFetchThrottled
+		}
+		return records, at, nil
+	}
+	fresh, err := s.fetchDNS(ctx, domain)
+	if err != nil {
+		return records, at, err
+	}
+	return fresh, s.now(), nil
+}
+
+// fetchDNS reads domain's records from the DNSSource and caches them.
+func (s *Server) fetchDNS(ctx context.Context, domain string) ([]api.DNSRecord, error) {
+	fresh, err := s.DNS.GetDNSRecords(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Store.SaveDNS(domain, fresh); err != nil {
+		s.logf("caching DNS for %s: %v", domain, err)
+	}
+	return fresh, nil
+}
+
+// claimFetch reports whether domain may be fetched live now, and if so
+// records the attempt, failed or not. force claims it regardless of the
+// last fetch, for the re-read after a change.
+func (s *Server) claimFetch(domain string, force bool) bool {
+	s.fetchMu.Lock()
+	defer s.fetchMu.Unlock()
+	now := s.now()
+	if last, ok := s.fetchedAt[domain]; ok && !force && now.Sub(last) < dnsRefreshEvery {
+		return false
+	}
+	if s.fetchedAt == nil {
+		s.fetchedAt = make(map[string]time.Time)
+	}
+	s.fetchedAt[domain] = now
+	return true
+}
+
+// findDomain looks name up in the portfolio. Only portfolio domains reach
+// the cache path and the API.
+func findDomain(domains []api.Domain, name string) (api.Domain, bool) {
+	name = strings.ToLower(name)
+	i := slices.IndexFunc(domains, func(d api.Domain) bool { return d.Name == name })
+	if i < 0 {
+		return api.Domain{}, false
+	}
+	return domains[i], true
+}
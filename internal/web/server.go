@@ -0,0 +1,126 @@
+// Package web serves a read-only HTML dashboard of the portfolio (the
+// domain list, expiration calendar, TLD costs and per-domain DNS) and a
+// versioned JSON API under /v1 for other tools. Both read the on-disk
+// cache on every request, so whatever keeps the cache fresh (the serve
+// command's refresh loop, porkbun-tui watch, the TUI) shows up on the
+// next reload. The API's nameserver and DNS changes need a token.
+package web
+
+import (
+	"context"
+	"crypto/subtle"
+	"embed"
+	"html/template"
+	"io/fs"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/bc/porkbun-tui/internal/alerts"
+	"github.com/bc/porkbun-tui/internal/api"
+)
+
+//go:embed templates/*.html
+var templateFS embed.FS
+
+//go:embed static
+var staticFS embed.FS
+
+// Store is where pages read the portfolio from; *cache.Cache satisfies it.
+type Store interface {
+	LoadDomains() ([]api.Domain, time.Time, error)
+	LoadPricing() (map[string]api.TLDPricing, time.Time, error)
+	LoadDNS(domain string) ([]api.DNSRecord, time.Time, error)
+	SaveDNS(domain string, records []api.DNSRecord) error
+}
+
+// DNSSource fetches DNS records for domains whose records aren't cached
+// yet, or on an explicit refresh; *api.Client satisfies it.
+type DNSSource interface {
+	GetDNSRecords(ctx context.Context, domain string) ([]api.DNSRecord, error)
+}
+
+// Server is the dashboard. Store is required; the rest is optional.
+type Server struct {
+	Store  Store
+	DNS    DNSSource // nil: cached DNS records only
+	Rules  []alerts.Rule
+	Budget float64 // monthly auto-renew budget, 0 for none
+
+	// Username and Password, when Username is set, require HTTP basic
+	// auth on every page.
+	Username string
+	Password string
+
+	// Token enables the JSON API's changes, sent as a bearer token. It
+	// also stands in for basic auth on API reads.
+	Token  string
+	Writer Mutator // nil: changes answer 503
+
+	Now func() time.Time
+	Log *log.Logger
+
+	pages map[string]*template.Template
+
+	// fetchedAt throttles live DNS fetches per domain; see dnsRefreshEvery.
+	fetchMu   sync.Mutex
+	fetchedAt map[string]time.Time
+}
+
+// Handler returns the dashboard's routes behind basic auth, if set, and
+// the JSON API behind apiAuth.
+func (s *Server) Handler() http.Handler {
+	s.pages = parsePages()
+
+	static, _ := fs.Sub(staticFS, "static")
+	pages := http.NewServeMux()
+	pages.Handle("GET /static/", http.StripPrefix("/static/", http.FileServerFS(static)))
+	pages.HandleFunc("GET /{$}", s.domainsPage)
+	pages.HandleFunc("GET /calendar", s.calendarPage)
+	pages.HandleFunc("GET /tlds", s.tldsPage)
+	pages.HandleFunc("GET /domains/{name}", s.domainPage)
+
+	v1 := http.NewServeMux()
+	s.apiRoutes(v1)
+
+	mux := http.NewServeMux()
+	mux.Handle("/v1/", s.apiAuth(v1))
+	if s.Username == "" {
+		mux.Handle("/", pages)
+	} else {
+		mux.Handle("/", s.basicAuth(pages))
+	}
+	return mux
+}
+
+func (s *Server) validBasicAuth(r *http.Request) bool {
+	user, pass, ok := r.BasicAuth()
+	userOK := subtle.ConstantTimeCompare([]byte(user), []byte(s.Username)) == 1
+	passOK := subtle.ConstantTimeCompare([]byte(pass), []byte(s.Password)) == 1
+	return ok && userOK && passOK
+}
+
+func (s *Server) basicAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !s.validBasicAuth(r) {
+			w.Header().Set("WWW-Authenticate", `Basic realm="porkbun-tui", charset="UTF-8"`)
+			http.Error(w, "authentication required", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) now() time.Time {
+	if s.Now != nil {
+		return s.Now()
+	}
+	return time.Now()
+}
+
+func (s *Server) logf(format string, args ...any) {
+	if s.Log != nil {
+		s.Log.Printf(format, args...)
+	}
+}
@@ -0,0 +1,270 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bc/porkbun-tui/internal/alerts"
+	"github.com/bc/porkbun-tui/internal/api"
+)
+
+var now = time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+
+type fakeStore struct {
+	domains   []api.Domain
+	domainsAt time.Time
+	pricing   map[string]api.TLDPricing
+	dns       map[string][]api.DNSRecord
+}
+
+func (s *fakeStore) LoadDomains() ([]api.Domain, time.Time, error) {
+	return s.domains, s.domainsAt, nil
+}
+
+func (s *fakeStore) LoadPricing() (map[string]api.TLDPricing, time.Time, error) {
+	return s.pricing, now, nil
+}
+
+func (s *fakeStore) LoadDNS(domain string) ([]api.DNSRecord, time.Time, error) {
+	records, ok := s.dns[domain]
+	if !ok {
+		return nil, time.Time{}, nil
+	}
+	return records, now.Add(-time.Hour), nil
+}
+
+func (s *fakeStore) SaveDNS(domain string, records []api.DNSRecord) error {
+	if s.dns == nil {
+		s.dns = make(map[string][]api.DNSRecord)
+	}
+	s.dns[domain] = records
+	return nil
+}
+
+type fakeDNS struct {
+	records []api.DNSRecord
+	err     error
+	calls   int
+}
+
+func (f *fakeDNS) GetDNSRecords(context.Context, string) ([]api.DNSRecord, error) {
+	f.calls++
+	return f.records, f.err
+}
+
+func testStore() *fakeStore {
+	return &fakeStore{
+		domains: []api.Domain{
+			{Name: "soon.com", TLD: "com", ExpireDate: now.AddDate(0, 0, 10), Labels: []string{"prod"}},
+			{Name: "later.io", TLD: "io", ExpireDate: now.AddDate(0, 5, 0), AutoRenew: true, SecurityLock: true},
+			{Name: "x<script>.com", TLD: "com", ExpireDate: now.AddDate(1, 0, 0), AutoRenew: true},
+		},
+		domainsAt: now.Add(-time.Hour),
+		pricing:   map[string]api.TLDPricing{"com": {Renewal: "10.00"}, "io": {Renewal: "30.00"}},
+	}
+}
+
+func testServer(store *fakeStore) *Server {
+	rule, _ := alerts.ExpiresWithin("30d", alerts.Critical)
+	return &Server{
+		Store: store,
+		Rules: []alerts.Rule{rule},
+		Now:   func() time.Time { return now },
+		Log:   log.New(io.Discard, "", 0),
+	}
+}
+
+func get(t *testing.T, h http.Handler, target string) (int, string) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+	return rec.Code, rec.Body.String()
+}
+
+func TestDomainsPage(t *testing.T) {
+	h := testServer(testStore()).Handler()
+
+	code, body := get(t, h, "/?sort=expiry")
+	if code != http.StatusOK {
+		t.Fatalf("status %d", code)
+	}
+	if strings.Index(body, "soon.com") > strings.Index(body, "later.io") {
+		t.Error("not sorted by expiry")
+	}
+	if !strings.Contains(body, `class="alert-critical"`) {
+		t.Error("alert hit not highlighted")
+	}
+	if strings.Contains(body, "x<script>.com") {
+		t.Error("domain name not escaped")
+	}
+
+	_, body = get(t, h, "/?q=prod")
+	if !strings.Contains(body, "soon.com") || strings.Contains(body, "later.io") {
+		t.Error("label filter did not narrow the list")
+	}
+}
+
+func TestCalendarAndTLDPages(t *testing.T) {
+	s := testServer(testStore())
+	s.Budget = 5
+	h := s.Handler()
+
+	_, body := get(t, h, "/calendar")
+	for _, want := range []string{"March 2026", "August 2026", "over budget", "1 lapse ($10.00)"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("calendar missing %q", want)
+		}
+	}
+
+	_, body = get(t, h, "/tlds")
+	if strings.Index(body, ".io") > strings.Index(body, ".com") || !strings.Contains(body, "$50.00") {
+		t.Errorf("TLD page wrong:\n%s", body)
+	}
+}
+
+func TestDomainPageDNS(t *testing.T) {
+	store := testStore()
+	store.dns = map[string][]api.DNSRecord{"soon.com": {{Name: "soon.com", Type: "A", Content: "192.0.2.1", TTL: "600"}}}
+	src := &fakeDNS{records: []api.DNSRecord{{Name: "later.io", Type: "MX", Content: "mx.later.io"}}}
+	s := testServer(store)
+	s.DNS = src
+	h := s.Handler()
+
+	_, body := get(t, h, "/domains/soon.com")
+	if !strings.Contains(body, "192.0.2.1") || src.calls != 0 {
+		t.Errorf("cached records not served from the cache (calls=%d)", src.calls)
+	}
+
+	_, body = get(t, h, "/domains/later.io")
+	if !strings.Contains(body, "mx.later.io") || src.calls != 1 {
+		t.Error("uncached records not fetched")
+	}
+	if len(store.dns["later.io"]) != 1 {
+		t.Error("fetched records not cached")
+	}
+
+	src.err = errors.New("rate limited")
+	_, body = get(t, h, "/domains/soon.com?refresh=1")
+	if !strings.Contains(body, "rate limited") || !strings.Contains(body, "192.0.2.1") {
+		t.Error("failed refresh should show the error and the cached records")
+	}
+}
+
+func TestDNSRefreshIsThrottledPerDomain(t *testing.T) {
+	store := testStore()
+	store.dns = map[string][]api.DNSRecord{"soon.com": {{Name: "soon.com", Type: "A", Content: "192.0.2.1"}}}
+	src := &fakeDNS{err: errors.New("porkbun down")}
+	s := testServer(store)
+	s.DNS = src
+	clock := now
+	s.Now = func() time.Time { return clock }
+	h := s.Handler()
+
+	for range 3 {
+		get(t, h, "/domains/soon.com?refresh=1")
+		do(t, h, http.MethodGet, "/v1/domains/soon.com/dns?refresh=1", "")
+	}
+	if src.calls != 1 {
+		t.Errorf("%d live fetches for repeated refreshes, want 1", src.calls)
+	}
+
+	// Nothing cached and the fetch just failed: say so instead of an empty list.
+	get(t, h, "/domains/later.io")
+	rec := do(t, h, http.MethodGet, "/v1/domains/later.io/dns", "")
+	if rec.Code != http.StatusTooManyRequests || rec.Header().Get("Retry-After") == "" {
+		t.Errorf("throttled uncached fetch: status %d, Retry-After %q", rec.Code, rec.Header().Get("Retry-After"))
+	}
+
+	clock = clock.Add(dnsRefreshEvery)
+	get(t, h, "/domains/soon.com?refresh=1")
+	if src.calls != 3 {
+		t.Errorf("%d live fetches, want a new one once the window passed", src.calls)
+	}
+}
+
+func TestChangesRefreshDNSDespiteThrottle(t *testing.T) {
+	store := testStore()
+	src := &fakeDNS{}
+	s := testServer(store)
+	s.DNS, s.Writer, s.Token = src, &fakeWriter{}, "tok"
+	h := s.Handler()
+
+	for _, content := range []string{"192.0.2.1", "192.0.2.2"} {
+		src.records = []api.DNSRecord{{ID: "1", Name: "soon.com", Type: "A", Content: content, TTL: "600"}}
+		body := `{"type": "A", "content": "` + content + `", "ttl": "600"}`
+		if rec := do(t, h, http.MethodPost, "/v1/domains/soon.com/dns", body, "Authorization", "Bearer tok"); rec.Code != http.StatusCreated {
+			t.Fatalf("create %s: status %d", content, rec.Code)
+		}
+	}
+	if src.calls != 2 {
+		t.Errorf("%d re-reads for two changes within a minute, want 2", src.calls)
+	}
+	if _, body := get(t, h, "/v1/domains/soon.com/dns"); !strings.Contains(body, "192.0.2.2") {
+		t.Errorf("records after the second change:\n%s", body)
+	}
+
+	// The re-read still counts against ?refresh.
+	do(t, h, http.MethodGet, "/v1/domains/soon.com/dns?refresh=1", "")
+	if src.calls != 2 {
+		t.Errorf("?refresh right after a change fetched again")
+	}
+}
+
+func TestDomainPageUnknownDomain(t *testing.T) {
+	src := &fakeDNS{}
+	s := testServer(testStore())
+	s.DNS = src
+
+	if code, _ := get(t, s.Handler(), "/domains/not-mine.com"); code != http.StatusNotFound {
+		t.Errorf("status %d, want 404", code)
+	}
+	if src.calls != 0 {
+		t.Error("API called for a domain outside the portfolio")
+	}
+}
+
+func TestEmptyCache(t *testing.T) {
+	_, body := get(t, testServer(&fakeStore{}).Handler(), "/")
+	if !strings.Contains(body, "No cached data yet") {
+		t.Error("empty cache not explained")
+	}
+}
+
+func TestBasicAuth(t *testing.T) {
+	s := testServer(testStore())
+	s.Username, s.Password = "team", "s3cret"
+	h := s.Handler()
+
+	if code, _ := get(t, h, "/"); code != http.StatusUnauthorized {
+		t.Errorf("no credentials: status %d", code)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/tlds", nil)
+	req.SetBasicAuth("team", "wrong")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("wrong password: status %d", rec.Code)
+	}
+
+	req.SetBasicAuth("team", "s3cret")
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Errorf("valid credentials: status %d", rec.Code)
+	}
+}
+
+func TestStaticAssets(t *testing.T) {
+	code, body := get(t, testServer(testStore()).Handler(), "/static/style.css")
+	if code != http.StatusOK || !strings.Contains(body, "table") {
+		t.Errorf("style.css: status %d", code)
+	}
+}